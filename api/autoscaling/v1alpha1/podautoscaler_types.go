@@ -64,6 +64,7 @@ type PodAutoscalerSpec struct {
 	MaxReplicas int32 `json:"maxReplicas"`
 
 	// MetricsSources defines a list of sources from which metrics are collected to make scaling decisions.
+	// When multiple sources are defined, a replica count is proposed for each of them and the largest one is used.
 	// +kubebuilder:validation:MinItems=1
 	MetricsSources []MetricSource `json:"metricsSources,omitempty"`
 
@@ -144,6 +145,24 @@ type PodAutoscalerStatus struct {
 	// Conditions is the set of conditions required for this autoscaler to scale its target,
 	// and indicates whether or not those conditions are met.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// CurrentMetrics is the last observed state of each metric source,
+	// including the number of replicas recommended by that metric.
+	// +optional
+	CurrentMetrics []MetricStatus `json:"currentMetrics,omitempty"`
+}
+
+// MetricStatus describes the last observed state of a single metric source.
+type MetricStatus struct {
+	// TargetMetric is the metric of the source, see MetricSource.TargetMetric.
+	TargetMetric string `json:"targetMetric"`
+	// CurrentValue is the observed value of the metric.
+	// +optional
+	CurrentValue string `json:"currentValue,omitempty"`
+	// TargetValue is the desired value of the metric, see MetricSource.TargetValue.
+	TargetValue string `json:"targetValue"`
+	// DesiredReplicas is the number of replicas recommended by this metric.
+	DesiredReplicas int32 `json:"desiredReplicas"`
 }
 
 // +kubebuilder:object:root=true
@@ -169,10 +188,18 @@ const (
 	QPS = "qps"
 )
 
-// GetPaMetricSources Currently, we don't support metric resources that are more than one yet.
-func GetPaMetricSources(pa PodAutoscaler) (MetricSource, error) {
-	if len(pa.Spec.MetricsSources) != 1 {
-		return MetricSource{}, fmt.Errorf("for now we only support one MetricsSource")
+// GetPaMetricSources returns the metric sources of the PodAutoscaler.
+// At least one source is required, and each source must target a different metric.
+func GetPaMetricSources(pa PodAutoscaler) ([]MetricSource, error) {
+	if len(pa.Spec.MetricsSources) == 0 {
+		return nil, fmt.Errorf("at least one MetricsSource is required")
+	}
+	seen := make(map[string]struct{}, len(pa.Spec.MetricsSources))
+	for _, source := range pa.Spec.MetricsSources {
+		if _, ok := seen[source.TargetMetric]; ok {
+			return nil, fmt.Errorf("duplicated MetricsSource for metric %q", source.TargetMetric)
+		}
+		seen[source.TargetMetric] = struct{}{}
 	}
-	return pa.Spec.MetricsSources[0], nil
+	return pa.Spec.MetricsSources, nil
 }
@@ -75,4 +75,29 @@ func TestPodAutoscalerInitialization(t *testing.T) {
 
 }
 
+// TestGetPaMetricSources tests that multiple metric sources are accepted as long as each targets a different metric.
+func TestGetPaMetricSources(t *testing.T) {
+	pa := PodAutoscaler{}
+	if _, err := GetPaMetricSources(pa); err == nil {
+		t.Errorf("GetPaMetricSources() expected an error without metric sources")
+	}
+
+	pa.Spec.MetricsSources = []MetricSource{
+		{TargetMetric: "gpu_cache_usage_perc", TargetValue: "0.5"},
+		{TargetMetric: "num_requests_waiting", TargetValue: "10"},
+	}
+	sources, err := GetPaMetricSources(pa)
+	if err != nil {
+		t.Fatalf("GetPaMetricSources() unexpected error: %v", err)
+	}
+	if got, want := len(sources), 2; got != want {
+		t.Errorf("len(GetPaMetricSources()) = %v, want %v", got, want)
+	}
+
+	pa.Spec.MetricsSources = append(pa.Spec.MetricsSources, MetricSource{TargetMetric: "num_requests_waiting", TargetValue: "5"})
+	if _, err := GetPaMetricSources(pa); err == nil {
+		t.Errorf("GetPaMetricSources() expected an error with duplicated metrics")
+	}
+}
+
 // Additional test cases can be added here to further validate other aspects of the PodAutoscaler.
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ModelAliasBackend is a model serving a share of the requests to an alias
+type ModelAliasBackend struct {
+	// ModelName is the name of the model the requests are sent to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ModelName string `json:"modelName"`
+
+	// Weight is the share of the requests to the alias sent to the model, relative to the other backends.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// +optional
+	Weight int32 `json:"weight"`
+}
+
+// ModelAliasSpec defines a virtual model name that splits its requests between models
+// +kubebuilder:validation:XValidation:rule="self.backends.exists(b, b.weight > 0)",message="at least one backend must have a positive weight"
+type ModelAliasSpec struct {
+	// Alias is the virtual model name clients set in the model field of requests.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Alias string `json:"alias"`
+
+	// Backends are the models serving the requests to the alias, chosen per request according to their weights.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=modelName
+	Backends []ModelAliasBackend `json:"backends"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Alias",type="string",JSONPath=".spec.alias"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ModelAlias is the Schema for the modelaliases API
+type ModelAlias struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ModelAliasSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ModelAliasList contains a list of ModelAlias
+type ModelAliasList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ModelAlias `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ModelAlias{}, &ModelAliasList{})
+}
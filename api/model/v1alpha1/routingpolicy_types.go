@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RoutingPolicySpec defines the routing defaults of a model
+type RoutingPolicySpec struct {
+	// ModelName is the name of the model the policy applies to, as set in the model field of requests.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ModelName string `json:"modelName"`
+
+	// Algorithm is the routing algorithm of the requests to the model, e.g. least-request or prefix-cache.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Algorithm string `json:"algorithm"`
+
+	// Parameters tune the routing algorithm for the model, e.g. the load imbalance threshold of prefix-cache.
+	// Parameters not set fall back to the gateway plugin configuration.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// AllowOverride is whether clients may select another algorithm with the routing-strategy header.
+	// +optional
+	// +kubebuilder:default=true
+	AllowOverride *bool `json:"allowOverride,omitempty"`
+
+	// RequestTimeout is the default deadline of the requests to the model, measured from their arrival at the gateway.
+	// Requests still queued when the deadline expires are rejected, the x-request-timeout-ms header overrides it.
+	// +optional
+	RequestTimeout *metav1.Duration `json:"requestTimeout,omitempty"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Model",type="string",JSONPath=".spec.modelName"
+// +kubebuilder:printcolumn:name="Algorithm",type="string",JSONPath=".spec.algorithm"
+// +kubebuilder:printcolumn:name="Override",type="boolean",JSONPath=".spec.allowOverride"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RoutingPolicy is the Schema for the routingpolicies API
+type RoutingPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RoutingPolicySpec `json:"spec,omitempty"`
+}
+
+// IsOverrideAllowed returns whether clients may override the algorithm of the policy, true if not set.
+func (p *RoutingPolicy) IsOverrideAllowed() bool {
+	return p.Spec.AllowOverride == nil || *p.Spec.AllowOverride
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RoutingPolicyList contains a list of RoutingPolicy
+type RoutingPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RoutingPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RoutingPolicy{}, &RoutingPolicyList{})
+}
@@ -325,6 +325,10 @@ func setupControllers(mgr ctrl.Manager, runtimeConfig config.RuntimeConfig, cert
 			setupLog.Error(err, "unable to setup webhook", "webhook", "KVCache")
 			os.Exit(1)
 		}
+		if err := apiwebhook.SetupRoutingPolicyWebhook(mgr); err != nil {
+			setupLog.Error(err, "unable to setup webhook", "webhook", "RoutingPolicy")
+			os.Exit(1)
+		}
 	}
 
 	// Kind controller registration is encapsulated inside the pkg/controller/controller.go
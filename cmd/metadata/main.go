@@ -56,7 +56,8 @@ func main() {
 	}
 
 	cache.InitWithOptions(config, stopCh, cache.InitOptions{
-		RedisClient: redisClient,
+		RedisClient:        redisClient,
+		EnableModelAliases: true,
 		// EnableKVSync defaults to false
 		// ModelRouterProvider defaults to nil
 	})
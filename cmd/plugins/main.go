@@ -24,6 +24,9 @@ import (
 	"os/signal"
 	"strconv"
 	"syscall"
+	"time"
+
+	"github.com/redis/go-redis/v9"
 
 	"google.golang.org/grpc"
 	"k8s.io/client-go/kubernetes"
@@ -33,9 +36,12 @@ import (
 
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
 	"github.com/vllm-project/aibrix/pkg/cache"
+	aibrixversioned "github.com/vllm-project/aibrix/pkg/client/clientset/versioned"
+	"github.com/vllm-project/aibrix/pkg/constants"
 	"github.com/vllm-project/aibrix/pkg/plugins/gateway"
 	routing "github.com/vllm-project/aibrix/pkg/plugins/gateway/algorithms"
 	"github.com/vllm-project/aibrix/pkg/utils"
+	syncindexer "github.com/vllm-project/aibrix/pkg/utils/syncprefixcacheindexer"
 	"google.golang.org/grpc/health"
 	healthPb "google.golang.org/grpc/health/grpc_health_v1"
 	"sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
@@ -83,10 +89,12 @@ func main() {
 	kvSyncEnabled, _ := strconv.ParseBool(utils.LoadEnv("AIBRIX_KV_EVENT_SYNC_ENABLED", "false"))
 	remoteTokenizerEnabled, _ := strconv.ParseBool(utils.LoadEnv("AIBRIX_USE_REMOTE_TOKENIZER", "false"))
 
-	cache.InitWithOptions(config, stopCh, cache.InitOptions{
+	cacheStore := cache.InitWithOptions(config, stopCh, cache.InitOptions{
 		EnableKVSync:        kvSyncEnabled && remoteTokenizerEnabled,
 		RedisClient:         redisClient,
 		ModelRouterProvider: routing.ModelRouterFactory,
+		KVSyncSnapshot:      kvSyncSnapshotOptions(redisClient),
+		EnableModelAliases:  true,
 	})
 
 	k8sClient, err := kubernetes.NewForConfig(config)
@@ -103,7 +111,12 @@ func main() {
 		klog.Fatalf("Error on creating gateway k8s client: %v", err)
 	}
 
-	gatewayServer := gateway.NewServer(redisClient, k8sClient, gatewayK8sClient)
+	aibrixClient, err := aibrixversioned.NewForConfig(config)
+	if err != nil {
+		klog.Fatalf("Error on creating aibrix k8s client: %v", err)
+	}
+
+	gatewayServer := gateway.NewServer(redisClient, k8sClient, gatewayK8sClient, aibrixClient)
 
 	if err := gatewayServer.StartMetricsServer(metricsAddr); err != nil {
 		klog.Fatalf("Failed to start metrics server: %v", err)
@@ -132,6 +145,7 @@ func main() {
 		klog.Warningf("signal received: %v, initiating graceful shutdown...", sig)
 		gatewayServer.Shutdown()
 		s.GracefulStop()
+		cacheStore.Close()
 		os.Exit(0)
 	}()
 
@@ -139,3 +153,28 @@ func main() {
 		panic(err)
 	}
 }
+
+// kvSyncSnapshotOptions returns the snapshot options of the KV sync prefix index, nil if snapshots are disabled
+func kvSyncSnapshotOptions(redisClient *redis.Client) *cache.KVSyncSnapshotOptions {
+	var store syncindexer.SnapshotStore
+	switch backend := utils.LoadEnv(constants.EnvKVEventSyncSnapshotBackend, ""); backend {
+	case "":
+		return nil
+	case "file":
+		path := utils.LoadEnv(constants.EnvKVEventSyncSnapshotPath, "")
+		if path == "" {
+			klog.Fatalf("%s is required by the file snapshot backend", constants.EnvKVEventSyncSnapshotPath)
+		}
+		store = syncindexer.NewFileSnapshotStore(path)
+	case "redis":
+		store = syncindexer.NewRedisSnapshotStore(redisClient,
+			utils.LoadEnv(constants.EnvKVEventSyncSnapshotRedisKey, "aibrix:kv_sync_snapshot"))
+	default:
+		klog.Fatalf("unknown KV sync snapshot backend %q, expected file or redis", backend)
+	}
+
+	return &cache.KVSyncSnapshotOptions{
+		Store:    store,
+		Interval: time.Duration(utils.LoadEnvInt(constants.EnvKVEventSyncSnapshotIntervalSeconds, 60)) * time.Second,
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/cache"
+	"github.com/vllm-project/aibrix/pkg/plugins/gateway/simulator"
+)
+
+var (
+	tracePath    string
+	profilePath  string
+	model        string
+	algorithms   string
+	jsonOutput   bool
+	verbose      bool
+	simulatorCfg = simulator.Config{Pod: simulator.DefaultPodConfig()}
+)
+
+func main() {
+	flag.StringVar(&tracePath, "trace", "", "Path of the JSONL request trace to replay, in the format of benchmarks/generator/workload_generator.")
+	flag.StringVar(&model, "model", "", "Model of the simulated pods, defaults to the model of the first request in the trace.")
+	flag.StringVar(&algorithms, "algorithms", "random,least-request,least-kv-cache,prefix-cache,slo", "Comma separated routing algorithms to compare.")
+	flag.StringVar(&profilePath, "profile", "", "Path of the model GPU profile in JSON used by the slo routers, optional.")
+	flag.BoolVar(&jsonOutput, "json", false, "Print the reports in JSON instead of a table.")
+	flag.BoolVar(&verbose, "verbose", false, "Print the logs of the routers.")
+	flag.IntVar(&simulatorCfg.Pods, "pods", 4, "Number of simulated pods.")
+	flag.IntVar(&simulatorCfg.Pod.MaxRunning, "max-running", simulatorCfg.Pod.MaxRunning, "Max number of running requests of a pod.")
+	flag.IntVar(&simulatorCfg.Pod.KVCacheTokens, "kv-cache-tokens", simulatorCfg.Pod.KVCacheTokens, "KV cache capacity of a pod in tokens.")
+	flag.IntVar(&simulatorCfg.Pod.BlockSize, "block-size", simulatorCfg.Pod.BlockSize, "Number of tokens of a KV cache block.")
+	flag.Float64Var(&simulatorCfg.Pod.PrefillTokensPerSecond, "prefill-tokens-per-second", simulatorCfg.Pod.PrefillTokensPerSecond, "Prefill throughput of a request.")
+	flag.DurationVar(&simulatorCfg.Pod.TPOT, "tpot", simulatorCfg.Pod.TPOT, "Time per output token of a request running alone.")
+	flag.Float64Var(&simulatorCfg.Pod.BatchSlowdown, "batch-slowdown", simulatorCfg.Pod.BatchSlowdown, "Increase of the time per output token with a full batch.")
+	flag.DurationVar(&simulatorCfg.MetricInterval, "metric-interval", 0, "Interval of the metrics scraped from pods in simulated time.")
+	klog.InitFlags(flag.CommandLine)
+	defer klog.Flush()
+	flag.Parse()
+
+	if !verbose {
+		// Routers log on every request, which is too verbose for a replay.
+		klog.LogToStderr(false)
+		klog.SetOutput(io.Discard)
+	}
+
+	if tracePath == "" {
+		exitf("--trace is required")
+	}
+	traceFile, err := os.Open(tracePath)
+	if err != nil {
+		exitf("failed to open trace: %v", err)
+	}
+	trace, err := simulator.LoadTrace(traceFile, model)
+	_ = traceFile.Close()
+	if err != nil {
+		exitf("failed to load trace: %v", err)
+	}
+
+	if profilePath != "" {
+		data, err := os.ReadFile(profilePath)
+		if err != nil {
+			exitf("failed to read profile: %v", err)
+		}
+		simulatorCfg.Profile = &cache.ModelGPUProfile{}
+		if err := json.Unmarshal(data, simulatorCfg.Profile); err != nil {
+			exitf("failed to parse profile: %v", err)
+		}
+	}
+	simulatorCfg.Model = model
+
+	sim, err := simulator.New(simulatorCfg, trace)
+	if err != nil {
+		exitf("failed to create simulator: %v", err)
+	}
+	defer sim.Close()
+
+	var reports []*simulator.Report
+	for _, algorithm := range strings.Split(algorithms, ",") {
+		report, err := sim.Run(strings.TrimSpace(algorithm))
+		if err != nil {
+			exitf("failed to replay trace: %v", err)
+		}
+		reports = append(reports, report)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(reports)
+	} else {
+		err = simulator.WriteReports(os.Stdout, reports)
+	}
+	if err != nil {
+		exitf("failed to write reports: %v", err)
+	}
+}
+
+// exitf reports the error on stderr, as the logs may be discarded, and exits.
+func exitf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package cache
 
 import (
+	modelv1alpha1 "github.com/vllm-project/aibrix/api/model/v1alpha1"
 	"github.com/vllm-project/aibrix/pkg/metrics"
 	"github.com/vllm-project/aibrix/pkg/types"
 	v1 "k8s.io/api/core/v1"
@@ -75,6 +76,19 @@ type ModelCache interface {
 	//   map[string]struct{}: Set of model names
 	//   error: Error information if operation fails
 	ListModelsByPod(podName, podNamespace string) ([]string, error)
+
+	// GetModelAlias gets the backends of a virtual model name
+	// Parameters:
+	//   alias: Virtual model name
+	// Returns:
+	//   []modelv1alpha1.ModelAliasBackend: Weighted backend models
+	//   bool: True if the alias exists, false otherwise
+	GetModelAlias(alias string) ([]modelv1alpha1.ModelAliasBackend, bool)
+
+	// ListModelAliases gets all virtual model names
+	// Returns:
+	//   []string: List of virtual model names
+	ListModelAliases() []string
 }
 
 // MetricCache defines operations for metric data caching
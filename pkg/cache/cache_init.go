@@ -31,14 +31,16 @@ import (
 	"k8s.io/klog/v2"
 
 	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	modelv1alpha1 "github.com/vllm-project/aibrix/api/model/v1alpha1"
 	"github.com/vllm-project/aibrix/pkg/metrics"
 	"github.com/vllm-project/aibrix/pkg/utils"
 	syncindexer "github.com/vllm-project/aibrix/pkg/utils/syncprefixcacheindexer"
 )
 
 var (
-	store = &Store{} // Global cache store instance
-	once  sync.Once  // Singleton pattern control lock
+	store       = &Store{} // Global cache store instance
+	customCache Cache      // Cache instance overriding the global store, see InitWithCache
+	once        sync.Once  // Singleton pattern control lock
 )
 
 // InitOptions configures the cache initialization behavior
@@ -51,6 +53,12 @@ type InitOptions struct {
 
 	// ModelRouterProvider is needed only by the gateway. Can be nil.
 	ModelRouterProvider ModelRouterProviderFunc
+
+	// KVSyncSnapshot enables snapshots of the KV sync prefix index, restored on startup. Can be nil.
+	KVSyncSnapshot *KVSyncSnapshotOptions
+
+	// EnableModelAliases configures whether to watch the ModelAlias resources
+	EnableModelAliases bool
 }
 
 const (
@@ -88,6 +96,12 @@ type Store struct {
 	enableProfileCaching bool                                    // Default to load from enableModelGPUProfileCaching, can be configured.
 	deploymentProfiles   utils.SyncMap[string, *ModelGPUProfile] // aibrix:profile_[model_name]_[deployment_name] -> *ModelGPUProfile
 
+	// Model alias related storage
+	enableModelAliases bool
+	aliasMu            sync.RWMutex
+	aliasObjects       map[string]*modelv1alpha1.ModelAlias // namespace/name -> *ModelAlias
+	aliases            map[string]*modelv1alpha1.ModelAlias // alias -> applied *ModelAlias
+
 	// buffer for sync map operations
 	bufferPod   *Pod
 	bufferModel *Model
@@ -104,6 +118,11 @@ type Store struct {
 
 	// KV event management - optional enhancement
 	kvEventManager *KVEventManager
+
+	// Snapshots of the sync prefix indexer - only used when configured
+	kvSyncSnapshot       *KVSyncSnapshotOptions
+	kvSyncSnapshotStopCh chan struct{}
+	kvSyncSnapshotWg     sync.WaitGroup
 }
 
 // Get retrieves the cache instance
@@ -112,6 +131,9 @@ type Store struct {
 //	Cache: Cache interface instance
 //	error: Returns error if cache is not initialized
 func Get() (Cache, error) {
+	if customCache != nil {
+		return customCache, nil
+	}
 	if !store.initialized {
 		return nil, errors.New("cache is not initialized")
 	}
@@ -252,6 +274,12 @@ func InitWithPodsMetrics(st *Store, podMetrics map[string]map[string]metrics.Met
 	return st
 }
 
+// InitWithCache replaces the cache instance returned by Get(), so that routers can run against another Cache
+// implementation, e.g. the simulated pods of an offline replay. Passing nil restores the global store.
+func InitWithCache(c Cache) {
+	customCache = c
+}
+
 // InitForTest initialize the global store object for testing.
 func InitForTest() *Store {
 	store = NewForTest()
@@ -296,6 +324,7 @@ func InitWithOptions(config *rest.Config, stopCh <-chan struct{}, opts InitOptio
 
 		// Create store with provided dependencies
 		store = New(opts.RedisClient, initPrometheusAPI(), opts.ModelRouterProvider)
+		store.enableModelAliases = opts.EnableModelAliases
 
 		// Initialize cache components
 		if err := initCacheInformers(store, config, stopCh); err != nil {
@@ -318,6 +347,9 @@ func InitWithOptions(config *rest.Config, stopCh <-chan struct{}, opts InitOptio
 			if opts.RedisClient == nil {
 				klog.Fatalf("InitOptions: EnableKVSync is true but RedisClient is nil")
 			}
+			if opts.KVSyncSnapshot != nil && opts.KVSyncSnapshot.Store != nil {
+				store.kvSyncSnapshot = opts.KVSyncSnapshot
+			}
 			if err := store.initKVEventSync(); err != nil {
 				klog.Errorf("Failed to initialize KV event sync: %v", err)
 				// Continue without KV sync - this is not a fatal error
@@ -472,11 +504,20 @@ func (s *Store) initKVEventSync() error {
 		return fmt.Errorf("failed to create sync prefix indexer")
 	}
 
+	// Restore the index before subscribing, so subscriptions replay from the snapshot
+	if s.kvSyncSnapshot != nil {
+		s.restoreKVSyncSnapshot()
+	}
+
 	// Start event manager
 	if err := s.kvEventManager.Start(); err != nil {
 		return fmt.Errorf("failed to start KV event sync: %w", err)
 	}
 
+	if s.kvSyncSnapshot != nil {
+		s.startKVSyncSnapshotWorker()
+	}
+
 	// Mark as successfully initialized
 	initialized = true
 	klog.Info("KV event synchronization initialized successfully")
@@ -488,6 +529,9 @@ func (s *Store) initKVEventSync() error {
 func (s *Store) cleanupKVEventSync() {
 	klog.Info("Cleaning up KV event sync resources")
 
+	// Stop snapshot worker first, it takes a last snapshot on exit
+	s.stopKVSyncSnapshotWorker()
+
 	// Stop event manager if it exists
 	if s.kvEventManager != nil {
 		s.kvEventManager.Stop()
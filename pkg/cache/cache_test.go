@@ -139,6 +139,10 @@ func (r *testRouter) Len() int {
 	return 0
 }
 
+func (r *testRouter) UserLen(_ string) int {
+	return 0
+}
+
 func testModelRouterProvider(modelName string) (types.QueueRouter, error) {
 	return &testRouter{Model: modelName}, nil
 }
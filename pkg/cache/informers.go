@@ -76,6 +76,18 @@ func initCacheInformers(instance *Store, config *rest.Config, stopCh <-chan stru
 		return err
 	}
 
+	// ModelAliases are not waited for, so that a missing CRD does not block the startup.
+	if instance.enableModelAliases {
+		aliasInformer := crdFactory.Model().V1alpha1().ModelAliases().Informer()
+		if _, err = aliasInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    instance.addModelAlias,
+			UpdateFunc: instance.updateModelAlias,
+			DeleteFunc: instance.deleteModelAlias,
+		}); err != nil {
+			return err
+		}
+	}
+
 	factory.Start(stopCh)
 	crdFactory.Start(stopCh)
 
@@ -68,3 +68,11 @@ func (m *KVEventManager) OnPodUpdate(oldPod, newPod *v1.Pod) {}
 
 // OnPodDelete is a no-op
 func (m *KVEventManager) OnPodDelete(pod *v1.Pod) {}
+
+// setReplaySequences is a no-op
+func (m *KVEventManager) setReplaySequences(sequences map[string]int64) {}
+
+// lastSequences returns no sequences
+func (m *KVEventManager) lastSequences() map[string]int64 {
+	return nil
+}
@@ -48,6 +48,9 @@ type KVEventManager struct {
 	// Configuration
 	enabled bool
 
+	// Sequences restored from a snapshot, consumed by the first subscription of each pod
+	replaySequences map[string]int64 // podKey -> last processed sequence
+
 	// Lifecycle
 	ctx     context.Context
 	cancel  context.CancelFunc
@@ -299,6 +302,9 @@ func (m *KVEventManager) subscribeToPod(pod *v1.Pod) error {
 
 	// Create ZMQ client with default config
 	config := kvcache.DefaultZMQClientConfig(podKey, pod.Status.PodIP, modelName)
+	if lastSeq, ok := m.takeReplaySequence(podKey); ok {
+		config.ReplayFromSeq = lastSeq + 1
+	}
 	client := kvcache.NewZMQClient(config, handler)
 
 	// Start subscription
@@ -326,3 +332,31 @@ func (m *KVEventManager) unsubscribeFromPod(podKey string) {
 
 	klog.Infof("Unsubscribed from KV events for pod %s", podKey)
 }
+
+// setReplaySequences sets the last processed sequences restored from a snapshot
+func (m *KVEventManager) setReplaySequences(sequences map[string]int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.replaySequences = sequences
+}
+
+// takeReplaySequence returns and forgets the restored sequence of a pod
+func (m *KVEventManager) takeReplaySequence(podKey string) (int64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lastSeq, ok := m.replaySequences[podKey]
+	delete(m.replaySequences, podKey)
+	return lastSeq, ok
+}
+
+// lastSequences returns the last processed sequence of each subscribed pod
+func (m *KVEventManager) lastSequences() map[string]int64 {
+	sequences := make(map[string]int64)
+	m.subscribers.Range(func(podKey string, client *kvcache.ZMQClient) bool {
+		if lastSeq := client.GetLastSequence(); lastSeq >= 0 {
+			sequences[podKey] = lastSeq
+		}
+		return true
+	})
+	return sequences
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	syncindexer "github.com/vllm-project/aibrix/pkg/utils/syncprefixcacheindexer"
+)
+
+const (
+	defaultKVSyncSnapshotInterval = 60 * time.Second
+	kvSyncSnapshotTimeout         = 30 * time.Second
+)
+
+// KVSyncSnapshotOptions configures the snapshots of the KV event synced prefix index
+type KVSyncSnapshotOptions struct {
+	// Store persists the snapshots, e.g. a local file or a Redis key
+	Store syncindexer.SnapshotStore
+
+	// Interval between two snapshots, defaults to 60s
+	Interval time.Duration
+}
+
+// restoreKVSyncSnapshot restores the sync prefix indexer from the latest snapshot and
+// records the sequences the KV event subscriptions replay from. Entries of pods that
+// no longer exist, or were recreated since the snapshot, are dropped.
+func (s *Store) restoreKVSyncSnapshot() {
+	ctx, cancel := context.WithTimeout(context.Background(), kvSyncSnapshotTimeout)
+	defer cancel()
+
+	snapshot, err := s.kvSyncSnapshot.Store.Load(ctx)
+	if err != nil {
+		klog.Warningf("Failed to load KV sync snapshot, starting with an empty index: %v", err)
+		return
+	}
+	if snapshot == nil {
+		klog.Info("No KV sync snapshot found, starting with an empty index")
+		return
+	}
+
+	livePods := make(map[string]struct{})
+	sequences := make(map[string]int64)
+	s.metaPods.Range(func(podKey string, pod *Pod) bool {
+		state, recorded := snapshot.Pods[podKey]
+		if recorded && state.UID != "" && state.UID != string(pod.UID) {
+			return true
+		}
+		livePods[podKey] = struct{}{}
+		if recorded && state.LastSequence >= 0 {
+			sequences[podKey] = state.LastSequence
+		}
+		return true
+	})
+
+	if err := s.syncPrefixIndexer.Restore(snapshot, livePods); err != nil {
+		klog.Warningf("Failed to restore KV sync snapshot, starting with an empty index: %v", err)
+		return
+	}
+	s.kvEventManager.setReplaySequences(sequences)
+
+	klog.InfoS("restored KV sync snapshot",
+		"created_at", snapshot.CreatedAt,
+		"contexts", len(snapshot.Contexts),
+		"live_pods", len(livePods),
+		"replay_pods", len(sequences))
+}
+
+// saveKVSyncSnapshot saves the sync prefix indexer along with the last processed sequence of each pod
+func (s *Store) saveKVSyncSnapshot() {
+	// Read the sequences before the index: events processed in between are replayed
+	// on restore, which is harmless as the events are applied in order again.
+	sequences := s.kvEventManager.lastSequences()
+	snapshot := s.syncPrefixIndexer.Snapshot()
+	snapshot.Pods = make(map[string]syncindexer.PodState, len(sequences))
+	for podKey, lastSeq := range sequences {
+		state := syncindexer.PodState{LastSequence: lastSeq}
+		if pod, ok := s.metaPods.Load(podKey); ok {
+			state.UID = string(pod.UID)
+		}
+		snapshot.Pods[podKey] = state
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kvSyncSnapshotTimeout)
+	defer cancel()
+	if err := s.kvSyncSnapshot.Store.Save(ctx, snapshot); err != nil {
+		klog.Errorf("Failed to save KV sync snapshot: %v", err)
+		return
+	}
+	klog.V(4).InfoS("saved KV sync snapshot", "contexts", len(snapshot.Contexts), "pods", len(snapshot.Pods))
+}
+
+// startKVSyncSnapshotWorker saves snapshots periodically, and a last one when stopped
+func (s *Store) startKVSyncSnapshotWorker() {
+	interval := s.kvSyncSnapshot.Interval
+	if interval <= 0 {
+		interval = defaultKVSyncSnapshotInterval
+	}
+
+	stopCh := make(chan struct{})
+	s.kvSyncSnapshotStopCh = stopCh
+	s.kvSyncSnapshotWg.Add(1)
+	go func() {
+		defer s.kvSyncSnapshotWg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.saveKVSyncSnapshot()
+			case <-stopCh:
+				s.saveKVSyncSnapshot()
+				return
+			}
+		}
+	}()
+}
+
+// stopKVSyncSnapshotWorker stops the snapshot worker if it is running
+func (s *Store) stopKVSyncSnapshotWorker() {
+	if s.kvSyncSnapshotStopCh == nil {
+		return
+	}
+	close(s.kvSyncSnapshotStopCh)
+	s.kvSyncSnapshotWg.Wait()
+	s.kvSyncSnapshotStopCh = nil
+}
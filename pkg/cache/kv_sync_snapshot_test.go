@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	syncindexer "github.com/vllm-project/aibrix/pkg/utils/syncprefixcacheindexer"
+)
+
+func newKVSyncSnapshotTestStore(snapshotStore syncindexer.SnapshotStore, podUIDs map[string]string) *Store {
+	s := &Store{
+		syncPrefixIndexer: syncindexer.NewSyncPrefixHashTable(),
+		kvSyncSnapshot:    &KVSyncSnapshotOptions{Store: snapshotStore},
+	}
+	s.kvEventManager = NewKVEventManager(s)
+	for name, uid := range podUIDs {
+		s.metaPods.Store("default/"+name, &Pod{Pod: &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(uid)},
+		}})
+	}
+	return s
+}
+
+func TestKVSyncSnapshotRestore(t *testing.T) {
+	snapshotStore := syncindexer.NewFileSnapshotStore(filepath.Join(t.TempDir(), "snapshot.json"))
+	tokens := make([]byte, 16)
+
+	// Nothing to restore on first start
+	s := newKVSyncSnapshotTestStore(snapshotStore, map[string]string{"pod-a": "uid-a", "pod-b": "uid-b", "pod-c": "uid-c"})
+	defer s.syncPrefixIndexer.Close()
+	s.restoreKVSyncSnapshot()
+	for _, pod := range []string{"default/pod-a", "default/pod-b", "default/pod-c"} {
+		require.NoError(t, s.syncPrefixIndexer.ProcessBlockStored(syncindexer.BlockStored{
+			BlockHashes: []int64{1}, Tokens: [][]byte{tokens}, ModelName: "m", LoraID: -1, SourcePod: pod,
+		}))
+	}
+	s.saveKVSyncSnapshot()
+
+	// Record sequences as the event manager would
+	snapshot, err := snapshotStore.Load(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	snapshot.Pods = map[string]syncindexer.PodState{
+		"default/pod-a": {UID: "uid-a", LastSequence: 10},
+		"default/pod-b": {UID: "uid-b", LastSequence: 20},
+	}
+	require.NoError(t, snapshotStore.Save(context.Background(), snapshot))
+
+	// pod-b is recreated and pod-c is gone, only pod-a is restored
+	restored := newKVSyncSnapshotTestStore(snapshotStore, map[string]string{"pod-a": "uid-a", "pod-b": "uid-b2"})
+	defer restored.syncPrefixIndexer.Close()
+	restored.restoreKVSyncSnapshot()
+
+	readyPods := map[string]struct{}{"default/pod-a": {}, "default/pod-b": {}, "default/pod-c": {}}
+	match, _ := restored.syncPrefixIndexer.MatchPrefix("m", -1, tokens, readyPods)
+	assert.Equal(t, map[string]int{"default/pod-a": 100}, match)
+}
+
+func TestKVSyncSnapshotWorker(t *testing.T) {
+	snapshotStore := syncindexer.NewFileSnapshotStore(filepath.Join(t.TempDir(), "snapshot.json"))
+	s := newKVSyncSnapshotTestStore(snapshotStore, nil)
+	defer s.syncPrefixIndexer.Close()
+
+	s.startKVSyncSnapshotWorker()
+	s.stopKVSyncSnapshotWorker()
+	s.stopKVSyncSnapshotWorker() // Ensure idempotency
+
+	// A last snapshot is saved when the worker stops
+	snapshot, err := snapshotStore.Load(context.Background())
+	assert.NoError(t, err)
+	assert.NotNil(t, snapshot)
+}
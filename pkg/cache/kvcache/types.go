@@ -35,6 +35,10 @@ type ZMQClientConfig struct {
 	PollTimeout    time.Duration
 	ReplayTimeout  time.Duration
 	ReconnectDelay time.Duration
+
+	// ReplayFromSeq is the sequence the initial replay starts from, the events
+	// before it are considered processed. Zero replays all buffered events.
+	ReplayFromSeq int64
 }
 
 // Constants for ZMQ client configuration
@@ -61,7 +61,7 @@ func NewZMQClient(config *ZMQClientConfig, handler EventHandler) *ZMQClient {
 	return &ZMQClient{
 		config:         config,
 		eventHandler:   handler,
-		lastSeq:        -1,
+		lastSeq:        config.ReplayFromSeq - 1,
 		reconnectDelay: config.ReconnectDelay,
 		ctx:            ctx,
 		cancel:         cancel,
@@ -145,8 +145,8 @@ func (c *ZMQClient) Start() error {
 		return fmt.Errorf("initial connection failed: %w", err)
 	}
 
-	// Request full replay on startup
-	if err := c.requestReplay(0); err != nil {
+	// Request replay of the events not processed yet on startup
+	if err := c.requestReplay(c.config.ReplayFromSeq); err != nil {
 		klog.Warningf("Failed to request initial replay for %s: %v", c.config.PodKey, err)
 		// Don't fail startup if replay fails
 	}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"sort"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	modelv1alpha1 "github.com/vllm-project/aibrix/api/model/v1alpha1"
+)
+
+// GetModelAlias returns the backends of a virtual model name defined by a ModelAlias.
+// If several ModelAliases define the same name, the oldest one applies.
+// The backends are shared and must be treated as read-only.
+func (c *Store) GetModelAlias(alias string) ([]modelv1alpha1.ModelAliasBackend, bool) {
+	c.aliasMu.RLock()
+	defer c.aliasMu.RUnlock()
+	modelAlias, ok := c.aliases[alias]
+	if !ok {
+		return nil, false
+	}
+	return modelAlias.Spec.Backends, true
+}
+
+// ListModelAliases returns all virtual model names defined by ModelAliases
+func (c *Store) ListModelAliases() []string {
+	c.aliasMu.RLock()
+	defer c.aliasMu.RUnlock()
+	aliases := make([]string, 0, len(c.aliases))
+	for alias := range c.aliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+func (c *Store) addModelAlias(obj interface{}) {
+	modelAlias, ok := obj.(*modelv1alpha1.ModelAlias)
+	if !ok {
+		return
+	}
+	c.aliasMu.Lock()
+	defer c.aliasMu.Unlock()
+
+	c.ensureModelAliasesLocked()
+	c.aliasObjects[modelAliasKey(modelAlias)] = modelAlias
+	c.resolveModelAliasLocked(modelAlias.Spec.Alias)
+	klog.InfoS("model alias added", "modelAlias", klog.KObj(modelAlias), "alias", modelAlias.Spec.Alias, "backends", modelAlias.Spec.Backends)
+}
+
+func (c *Store) updateModelAlias(oldObj interface{}, newObj interface{}) {
+	oldAlias, ok := oldObj.(*modelv1alpha1.ModelAlias)
+	if !ok {
+		return
+	}
+	newAlias, ok := newObj.(*modelv1alpha1.ModelAlias)
+	if !ok {
+		return
+	}
+	c.aliasMu.Lock()
+	defer c.aliasMu.Unlock()
+
+	c.ensureModelAliasesLocked()
+	c.aliasObjects[modelAliasKey(newAlias)] = newAlias
+	c.resolveModelAliasLocked(newAlias.Spec.Alias)
+	if oldAlias.Spec.Alias != newAlias.Spec.Alias {
+		c.resolveModelAliasLocked(oldAlias.Spec.Alias)
+	}
+	klog.InfoS("model alias updated", "modelAlias", klog.KObj(newAlias), "alias", newAlias.Spec.Alias, "backends", newAlias.Spec.Backends)
+}
+
+func (c *Store) deleteModelAlias(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	modelAlias, ok := obj.(*modelv1alpha1.ModelAlias)
+	if !ok {
+		return
+	}
+	c.aliasMu.Lock()
+	defer c.aliasMu.Unlock()
+
+	c.ensureModelAliasesLocked()
+	delete(c.aliasObjects, modelAliasKey(modelAlias))
+	c.resolveModelAliasLocked(modelAlias.Spec.Alias)
+	klog.InfoS("model alias deleted", "modelAlias", klog.KObj(modelAlias), "alias", modelAlias.Spec.Alias)
+}
+
+func (c *Store) ensureModelAliasesLocked() {
+	if c.aliasObjects == nil {
+		c.aliasObjects = map[string]*modelv1alpha1.ModelAlias{}
+		c.aliases = map[string]*modelv1alpha1.ModelAlias{}
+	}
+}
+
+// resolveModelAliasLocked selects the ModelAlias applied to the alias among the ModelAliases defining it.
+func (c *Store) resolveModelAliasLocked(alias string) {
+	var applied *modelv1alpha1.ModelAlias
+	conflicts := 0
+	for _, modelAlias := range c.aliasObjects {
+		if modelAlias.Spec.Alias != alias {
+			continue
+		}
+		conflicts++
+		if applied == nil || modelAliasOlderThan(modelAlias, applied) {
+			applied = modelAlias
+		}
+	}
+
+	if applied == nil {
+		delete(c.aliases, alias)
+		return
+	}
+	if conflicts > 1 {
+		klog.InfoS("multiple model aliases define the alias, applying the oldest one", "alias", alias, "modelAlias", klog.KObj(applied))
+	}
+	c.aliases[alias] = applied
+}
+
+// modelAliasOlderThan orders ModelAliases by creation time, then by namespace and name.
+func modelAliasOlderThan(a, b *modelv1alpha1.ModelAlias) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return modelAliasKey(a) < modelAliasKey(b)
+}
+
+func modelAliasKey(modelAlias *modelv1alpha1.ModelAlias) string {
+	return modelAlias.Namespace + "/" + modelAlias.Name
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	modelv1alpha1 "github.com/vllm-project/aibrix/api/model/v1alpha1"
+)
+
+func newTestModelAlias(name, alias string, created time.Time, backends ...string) *modelv1alpha1.ModelAlias {
+	modelAlias := &modelv1alpha1.ModelAlias{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, CreationTimestamp: metav1.NewTime(created)},
+		Spec:       modelv1alpha1.ModelAliasSpec{Alias: alias},
+	}
+	for _, backend := range backends {
+		modelAlias.Spec.Backends = append(modelAlias.Spec.Backends, modelv1alpha1.ModelAliasBackend{ModelName: backend, Weight: 1})
+	}
+	return modelAlias
+}
+
+func TestModelAliases(t *testing.T) {
+	s := &Store{}
+	now := time.Now()
+	_, ok := s.GetModelAlias("chat-default")
+	assert.False(t, ok)
+	assert.Empty(t, s.ListModelAliases())
+
+	older := newTestModelAlias("older", "chat-default", now, "llama-3-70b")
+	newer := newTestModelAlias("newer", "chat-default", now.Add(time.Minute), "llama-3-8b")
+	s.addModelAlias(newer)
+	s.addModelAlias(older)
+	s.addModelAlias(newTestModelAlias("fast", "chat-fast", now, "llama-3-8b"))
+	assert.Equal(t, []string{"chat-default", "chat-fast"}, s.ListModelAliases())
+
+	// The oldest alias applies
+	backends, ok := s.GetModelAlias("chat-default")
+	assert.True(t, ok)
+	assert.Equal(t, "llama-3-70b", backends[0].ModelName)
+
+	// Renaming the applied alias hands the alias over
+	renamed := newTestModelAlias("older", "chat-large", now, "llama-3-70b")
+	s.updateModelAlias(older, renamed)
+	backends, _ = s.GetModelAlias("chat-default")
+	assert.Equal(t, "llama-3-8b", backends[0].ModelName)
+	backends, _ = s.GetModelAlias("chat-large")
+	assert.Equal(t, "llama-3-70b", backends[0].ModelName)
+
+	s.deleteModelAlias(newer)
+	s.deleteModelAlias(cache.DeletedFinalStateUnknown{Key: "default/fast", Obj: newTestModelAlias("fast", "chat-fast", now)})
+	assert.Equal(t, []string{"chat-large"}, s.ListModelAliases())
+}
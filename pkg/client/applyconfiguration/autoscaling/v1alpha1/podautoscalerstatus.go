@@ -25,10 +25,11 @@ import (
 // PodAutoscalerStatusApplyConfiguration represents a declarative configuration of the PodAutoscalerStatus type for use
 // with apply.
 type PodAutoscalerStatusApplyConfiguration struct {
-	LastScaleTime *v1.Time                             `json:"lastScaleTime,omitempty"`
-	DesiredScale  *int32                               `json:"desiredScale,omitempty"`
-	ActualScale   *int32                               `json:"actualScale,omitempty"`
-	Conditions    []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	LastScaleTime  *v1.Time                             `json:"lastScaleTime,omitempty"`
+	DesiredScale   *int32                               `json:"desiredScale,omitempty"`
+	ActualScale    *int32                               `json:"actualScale,omitempty"`
+	Conditions     []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	CurrentMetrics []MetricStatusApplyConfiguration     `json:"currentMetrics,omitempty"`
 }
 
 // PodAutoscalerStatusApplyConfiguration constructs a declarative configuration of the PodAutoscalerStatus type for use with
@@ -73,3 +74,16 @@ func (b *PodAutoscalerStatusApplyConfiguration) WithConditions(values ...*metav1
 	}
 	return b
 }
+
+// WithCurrentMetrics adds the given value to the CurrentMetrics field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CurrentMetrics field.
+func (b *PodAutoscalerStatusApplyConfiguration) WithCurrentMetrics(values ...*MetricStatusApplyConfiguration) *PodAutoscalerStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithCurrentMetrics")
+		}
+		b.CurrentMetrics = append(b.CurrentMetrics, *values[i])
+	}
+	return b
+}
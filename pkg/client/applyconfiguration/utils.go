@@ -37,6 +37,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	// Group=autoscaling, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithKind("MetricSource"):
 		return &autoscalingv1alpha1.MetricSourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("MetricStatus"):
+		return &autoscalingv1alpha1.MetricStatusApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PodAutoscaler"):
 		return &autoscalingv1alpha1.PodAutoscalerApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PodAutoscalerSpec"):
@@ -51,6 +53,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationmodelv1alpha1.ModelAdapterSpecApplyConfiguration{}
 	case modelv1alpha1.SchemeGroupVersion.WithKind("ModelAdapterStatus"):
 		return &applyconfigurationmodelv1alpha1.ModelAdapterStatusApplyConfiguration{}
+	case modelv1alpha1.SchemeGroupVersion.WithKind("ModelAlias"):
+		return &applyconfigurationmodelv1alpha1.ModelAliasApplyConfiguration{}
+	case modelv1alpha1.SchemeGroupVersion.WithKind("ModelAliasBackend"):
+		return &applyconfigurationmodelv1alpha1.ModelAliasBackendApplyConfiguration{}
+	case modelv1alpha1.SchemeGroupVersion.WithKind("ModelAliasSpec"):
+		return &applyconfigurationmodelv1alpha1.ModelAliasSpecApplyConfiguration{}
+	case modelv1alpha1.SchemeGroupVersion.WithKind("RoutingPolicy"):
+		return &applyconfigurationmodelv1alpha1.RoutingPolicyApplyConfiguration{}
+	case modelv1alpha1.SchemeGroupVersion.WithKind("RoutingPolicySpec"):
+		return &applyconfigurationmodelv1alpha1.RoutingPolicySpecApplyConfiguration{}
 
 		// Group=orchestration, Version=v1alpha1
 	case orchestrationv1alpha1.SchemeGroupVersion.WithKind("RayClusterFleet"):
@@ -18,3 +18,7 @@ limitations under the License.
 package v1alpha1
 
 type ModelAdapterExpansion interface{}
+
+type ModelAliasExpansion interface{}
+
+type RoutingPolicyExpansion interface{}
@@ -28,6 +28,8 @@ import (
 type ModelV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ModelAdaptersGetter
+	ModelAliasesGetter
+	RoutingPoliciesGetter
 }
 
 // ModelV1alpha1Client is used to interact with features provided by the model group.
@@ -39,6 +41,14 @@ func (c *ModelV1alpha1Client) ModelAdapters(namespace string) ModelAdapterInterf
 	return newModelAdapters(c, namespace)
 }
 
+func (c *ModelV1alpha1Client) ModelAliases(namespace string) ModelAliasInterface {
+	return newModelAliases(c, namespace)
+}
+
+func (c *ModelV1alpha1Client) RoutingPolicies(namespace string) RoutingPolicyInterface {
+	return newRoutingPolicies(c, namespace)
+}
+
 // NewForConfig creates a new ModelV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
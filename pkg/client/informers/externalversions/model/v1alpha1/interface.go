@@ -25,6 +25,10 @@ import (
 type Interface interface {
 	// ModelAdapters returns a ModelAdapterInformer.
 	ModelAdapters() ModelAdapterInformer
+	// ModelAliases returns a ModelAliasInformer.
+	ModelAliases() ModelAliasInformer
+	// RoutingPolicies returns a RoutingPolicyInformer.
+	RoutingPolicies() RoutingPolicyInformer
 }
 
 type version struct {
@@ -42,3 +46,13 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) ModelAdapters() ModelAdapterInformer {
 	return &modelAdapterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// ModelAliases returns a ModelAliasInformer.
+func (v *version) ModelAliases() ModelAliasInformer {
+	return &modelAliasInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// RoutingPolicies returns a RoutingPolicyInformer.
+func (v *version) RoutingPolicies() RoutingPolicyInformer {
+	return &routingPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,24 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constants
+
+const (
+	// AutoscalingActivationAnnotation is set on a PodAutoscaler by the gateway when requests are waiting
+	// for a model scaled to zero. The value is the RFC3339 time of the latest request for activation.
+	// Example: "autoscaling.aibrix.ai/activation-requested-at": "2025-01-01T00:00:00Z"
+	AutoscalingActivationAnnotation = "autoscaling.aibrix.ai/activation-requested-at"
+)
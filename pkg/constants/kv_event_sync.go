@@ -48,6 +48,19 @@ const (
 	// EnvPrefixCacheMetricsEnabled enables prefix cache metrics
 	// Added as part of KV Event Sync to control metrics registration
 	EnvPrefixCacheMetricsEnabled = "AIBRIX_PREFIX_CACHE_METRICS_ENABLED"
+
+	// EnvKVEventSyncSnapshotBackend selects where the sync prefix index is snapshotted
+	// Either "file" or "redis", snapshots are disabled when empty
+	EnvKVEventSyncSnapshotBackend = "AIBRIX_KV_EVENT_SYNC_SNAPSHOT_BACKEND"
+
+	// EnvKVEventSyncSnapshotPath specifies the snapshot file of the "file" backend
+	EnvKVEventSyncSnapshotPath = "AIBRIX_KV_EVENT_SYNC_SNAPSHOT_PATH"
+
+	// EnvKVEventSyncSnapshotRedisKey specifies the snapshot key of the "redis" backend
+	EnvKVEventSyncSnapshotRedisKey = "AIBRIX_KV_EVENT_SYNC_SNAPSHOT_REDIS_KEY"
+
+	// EnvKVEventSyncSnapshotIntervalSeconds specifies the interval between snapshots
+	EnvKVEventSyncSnapshotIntervalSeconds = "AIBRIX_KV_EVENT_SYNC_SNAPSHOT_INTERVAL_SECONDS"
 )
 
 // Helper functions for KV Event Sync labels
@@ -70,11 +70,14 @@ func NewBaseScalingContext() *BaseScalingContext {
 }
 
 // UpdateByPaTypes should be invoked in any scaling context that embeds BaseScalingContext.
+// A scaling context serves a single metric, so the first metrics source of the PodAutoscaler is used.
+// For PodAutoscalers with multiple metrics sources, see PodAutoscalerForMetricSource.
 func (b *BaseScalingContext) UpdateByPaTypes(pa *autoscalingv1alpha1.PodAutoscaler) error {
-	source, err := autoscalingv1alpha1.GetPaMetricSources(*pa)
+	sources, err := autoscalingv1alpha1.GetPaMetricSources(*pa)
 	if err != nil {
 		return err
 	}
+	source := sources[0]
 
 	b.ScalingMetric = source.TargetMetric
 	// parse target value
@@ -104,6 +107,14 @@ func (b *BaseScalingContext) UpdateByPaTypes(pa *autoscalingv1alpha1.PodAutoscal
 	return nil
 }
 
+// PodAutoscalerForMetricSource returns a copy of the PodAutoscaler with the given metrics source only,
+// which is used to create and update the scaler of that metric.
+func PodAutoscalerForMetricSource(pa autoscalingv1alpha1.PodAutoscaler, source autoscalingv1alpha1.MetricSource) autoscalingv1alpha1.PodAutoscaler {
+	paCopy := pa.DeepCopy()
+	paCopy.Spec.MetricsSources = []autoscalingv1alpha1.MetricSource{source}
+	return *paCopy
+}
+
 func (b *BaseScalingContext) SetCurrentUsePerPod(value float64) {
 	b.currentUsePerPod = value
 }
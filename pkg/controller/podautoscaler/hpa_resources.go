@@ -64,59 +64,71 @@ func makeHPA(pa *pav1.PodAutoscaler) (*autoscalingv2.HorizontalPodAutoscaler, er
 	if minReplicas != nil && *minReplicas > 0 {
 		hpa.Spec.MinReplicas = minReplicas
 	}
-	source, err := pav1.GetPaMetricSources(*pa)
+	sources, err := pav1.GetPaMetricSources(*pa)
 	if err != nil {
 		return nil, fmt.Errorf("failed to GetPaMetricSources: %w", err)
 	}
 
-	if targetValue, err := strconv.ParseFloat(source.TargetValue, 64); err != nil {
-		return nil, fmt.Errorf("failed to parse target value of the metric source: %w", err)
-	} else {
-		klog.V(4).InfoS("Creating HPA", "metric", source.TargetMetric, "target", targetValue)
+	// HPA natively proposes replicas for each metric and takes the largest one.
+	for _, source := range sources {
+		metric, err := makeHPAMetric(source)
+		if err != nil {
+			return nil, err
+		}
+		hpa.Spec.Metrics = append(hpa.Spec.Metrics, metric)
+	}
+
+	return hpa, nil
+}
+
+// makeHPAMetric converts a metric source of the PodAutoscaler to an HPA metric.
+func makeHPAMetric(source pav1.MetricSource) (autoscalingv2.MetricSpec, error) {
+	targetValue, err := strconv.ParseFloat(source.TargetValue, 64)
+	if err != nil {
+		return autoscalingv2.MetricSpec{}, fmt.Errorf("failed to parse target value of the metric source: %w", err)
+	}
+	klog.V(4).InfoS("Creating HPA metric", "metric", source.TargetMetric, "target", targetValue)
 
-		switch strings.ToLower(source.TargetMetric) {
-		case pav1.CPU:
-			cpu := int32(math.Ceil(targetValue))
-			hpa.Spec.Metrics = []autoscalingv2.MetricSpec{{
-				Type: autoscalingv2.ResourceMetricSourceType,
-				Resource: &autoscalingv2.ResourceMetricSource{
-					Name: corev1.ResourceCPU,
-					Target: autoscalingv2.MetricTarget{
-						Type:               autoscalingv2.UtilizationMetricType,
-						AverageUtilization: &cpu,
-					},
+	switch strings.ToLower(source.TargetMetric) {
+	case pav1.CPU:
+		cpu := int32(math.Ceil(targetValue))
+		return autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: &cpu,
 				},
-			}}
+			},
+		}, nil
 
-		case pav1.Memory:
-			memory := resource.NewQuantity(int64(targetValue)*1024*1024, resource.BinarySI)
-			hpa.Spec.Metrics = []autoscalingv2.MetricSpec{{
-				Type: autoscalingv2.ResourceMetricSourceType,
-				Resource: &autoscalingv2.ResourceMetricSource{
-					Name: corev1.ResourceMemory,
-					Target: autoscalingv2.MetricTarget{
-						Type:         autoscalingv2.AverageValueMetricType,
-						AverageValue: memory,
-					},
+	case pav1.Memory:
+		memory := resource.NewQuantity(int64(targetValue)*1024*1024, resource.BinarySI)
+		return autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceMemory,
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: memory,
 				},
-			}}
+			},
+		}, nil
 
-		default:
-			targetQuantity := resource.NewQuantity(int64(targetValue), resource.DecimalSI)
-			hpa.Spec.Metrics = []autoscalingv2.MetricSpec{{
-				Type: autoscalingv2.PodsMetricSourceType,
-				Pods: &autoscalingv2.PodsMetricSource{
-					Metric: autoscalingv2.MetricIdentifier{
-						Name: source.TargetMetric,
-					},
-					Target: autoscalingv2.MetricTarget{
-						Type:         autoscalingv2.AverageValueMetricType,
-						AverageValue: targetQuantity,
-					},
+	default:
+		targetQuantity := resource.NewQuantity(int64(targetValue), resource.DecimalSI)
+		return autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{
+					Name: source.TargetMetric,
 				},
-			}}
-		}
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: targetQuantity,
+				},
+			},
+		}, nil
 	}
-
-	return hpa, nil
 }
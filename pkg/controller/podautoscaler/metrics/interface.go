@@ -18,7 +18,6 @@ package metrics
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -36,23 +35,26 @@ type NamespaceNameMetric struct {
 	PaName               string
 }
 
-// NewNamespaceNameMetric creates a NamespaceNameMetric based on the PodAutoscaler's metrics source.
-// For consistency, it will return the corresponding MetricSource.
-// Currently, it supports only a single metric source. In the future, this could be extended to handle multiple metric sources.
-func NewNamespaceNameMetric(pa *autoscalingv1alpha1.PodAutoscaler) (NamespaceNameMetric, autoscalingv1alpha1.MetricSource, error) {
-	if len(pa.Spec.MetricsSources) != 1 {
-		return NamespaceNameMetric{}, autoscalingv1alpha1.MetricSource{}, fmt.Errorf("metrics sources must be 1, but got %d", len(pa.Spec.MetricsSources))
+// NewNamespaceNameMetrics creates a NamespaceNameMetric for each of the PodAutoscaler's metrics sources.
+// The returned MetricSource slice is index-aligned with the NamespaceNameMetric slice.
+func NewNamespaceNameMetrics(pa *autoscalingv1alpha1.PodAutoscaler) ([]NamespaceNameMetric, []autoscalingv1alpha1.MetricSource, error) {
+	metricSources, err := autoscalingv1alpha1.GetPaMetricSources(*pa)
+	if err != nil {
+		return nil, nil, err
 	}
-	metricSource := pa.Spec.MetricsSources[0]
-	return NamespaceNameMetric{
-		NamespacedName: types.NamespacedName{
-			Namespace: pa.Namespace,
-			Name:      pa.Spec.ScaleTargetRef.Name,
-		},
-		MetricName:  metricSource.TargetMetric,
-		PaNamespace: pa.Namespace,
-		PaName:      pa.Name,
-	}, metricSource, nil
+	metricKeys := make([]NamespaceNameMetric, 0, len(metricSources))
+	for _, metricSource := range metricSources {
+		metricKeys = append(metricKeys, NamespaceNameMetric{
+			NamespacedName: types.NamespacedName{
+				Namespace: pa.Namespace,
+				Name:      pa.Spec.ScaleTargetRef.Name,
+			},
+			MetricName:  metricSource.TargetMetric,
+			PaNamespace: pa.Namespace,
+			PaName:      pa.Name,
+		})
+	}
+	return metricKeys, metricSources, nil
 }
 
 // PodMetric contains pod metric value (the metric values are expected to be the metric as a milli-value)
@@ -19,11 +19,13 @@ package podautoscaler
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	autoscalingv1alpha1 "github.com/vllm-project/aibrix/api/autoscaling/v1alpha1"
 	orchestrationv1alpha1 "github.com/vllm-project/aibrix/api/orchestration/v1alpha1"
 	"github.com/vllm-project/aibrix/pkg/config"
+	scalingcontext "github.com/vllm-project/aibrix/pkg/controller/podautoscaler/common"
 	"github.com/vllm-project/aibrix/pkg/controller/podautoscaler/metrics"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -56,6 +58,9 @@ import (
 
 var (
 	DefaultRequeueDuration = 10 * time.Second
+	// DefaultActivationGracePeriod is how long a target scaled from zero on request of the gateway is kept
+	// from scaling back to zero.
+	DefaultActivationGracePeriod = 2 * time.Minute
 )
 
 // Add creates a new PodAutoscaler Controller and adds it to the Manager with default RBAC.
@@ -349,7 +354,7 @@ func (r *PodAutoscalerReconciler) reconcileCustomPA(ctx context.Context, pa auto
 	paStatusOriginal := pa.Status.DeepCopy()
 	paType := pa.Spec.ScalingStrategy
 	scaleReference := fmt.Sprintf("%s/%s/%s", pa.Spec.ScaleTargetRef.Kind, pa.Namespace, pa.Spec.ScaleTargetRef.Name)
-	metricKey, metricSource, err := metrics.NewNamespaceNameMetric(&pa)
+	metricKeys, metricSources, err := metrics.NewNamespaceNameMetrics(&pa)
 	if err != nil {
 		r.EventRecorder.Event(&pa, corev1.EventTypeWarning, "FailedGetMetricKey", err.Error())
 		return ctrl.Result{}, err
@@ -405,11 +410,16 @@ func (r *PodAutoscalerReconciler) reconcileCustomPA(ctx context.Context, pa auto
 	}
 	currentReplicas := int32(currentReplicasInt64)
 
+	// Remove the scalers of metrics that are no longer in the spec
+	r.deleteStaleMetricScalers(pa, metricKeys)
+
 	// Update the scale required metrics periodically
-	err = r.updateMetricsForScale(ctx, pa, scale, metricKey, metricSource, int(currentReplicas))
-	if err != nil {
-		r.EventRecorder.Event(&pa, corev1.EventTypeWarning, "FailedUpdateMetrics", err.Error())
-		return ctrl.Result{}, fmt.Errorf("failed to update metrics for scale target reference: %v", err)
+	for i, metricKey := range metricKeys {
+		err = r.updateMetricsForScale(ctx, pa, scale, metricKey, metricSources[i], int(currentReplicas))
+		if err != nil {
+			r.EventRecorder.Event(&pa, corev1.EventTypeWarning, "FailedUpdateMetrics", err.Error())
+			return ctrl.Result{}, fmt.Errorf("failed to update metrics for scale target reference: %v", err)
+		}
 	}
 
 	// desired replica count
@@ -429,16 +439,20 @@ func (r *PodAutoscalerReconciler) reconcileCustomPA(ctx context.Context, pa auto
 		// if the replica is 0, then we should not enable autoscaling
 		desiredReplicas = 0
 		rescale = false
+	} else if currentReplicas == int32(0) && activationRequested(pa, time.Now()) {
+		// requests are waiting in the gateway for the target scaled to zero
+		desiredReplicas = 1
+		rescaleReason = "activation requested"
 	} else if currentReplicas > pa.Spec.MaxReplicas {
 		desiredReplicas = pa.Spec.MaxReplicas
 	} else if currentReplicas < minReplicas {
 		desiredReplicas = minReplicas
 	} else {
 		// if the currentReplicas is within the range, we should
-		// computeReplicasForMetrics gives
-		// TODO: check why it return the metrics name here?
-		metricDesiredReplicas, metricName, metricTimestamp, err := r.computeReplicasForMetrics(ctx, pa, scale, metricKey)
-		if err != nil {
+		// computeReplicasForMetrics gives the largest replicas proposed by the metrics and the metric proposing it
+		metricDesiredReplicas, metricName, metricStatuses, metricTimestamp, err := r.computeReplicasForMetrics(ctx, pa, scale, metricKeys, metricSources)
+		pa.Status.CurrentMetrics = metricStatuses
+		if err != nil && metricDesiredReplicas == -1 {
 			r.setCurrentReplicasAndMetricsInStatus(&pa, currentReplicas)
 			if err := r.updateStatusIfNeeded(ctx, paStatusOriginal, &pa); err != nil {
 				return ctrl.Result{}, fmt.Errorf("failed to update the resource status")
@@ -446,6 +460,13 @@ func (r *PodAutoscalerReconciler) reconcileCustomPA(ctx context.Context, pa auto
 			r.EventRecorder.Event(&pa, corev1.EventTypeWarning, "FailedComputeMetricsReplicas", err.Error())
 			return ctrl.Result{}, fmt.Errorf("failed to compute desired number of replicas based on listed metrics for %s: %v", scaleReference, err)
 		}
+		if err != nil {
+			// Some metrics are still valid, scale on them but never scale down without the full picture, as HPA does.
+			r.EventRecorder.Event(&pa, corev1.EventTypeWarning, "FailedComputeMetricsReplicas", err.Error())
+			if metricDesiredReplicas < currentReplicas {
+				metricDesiredReplicas = currentReplicas
+			}
+		}
 
 		klog.V(4).InfoS("Proposing desired replicas",
 			"desiredReplicas", metricDesiredReplicas,
@@ -475,6 +496,11 @@ func (r *PodAutoscalerReconciler) reconcileCustomPA(ctx context.Context, pa auto
 				"recommendedReplicas", desiredReplicas, "adjustedTo", minReplicas)
 			desiredReplicas = minReplicas
 		}
+		if desiredReplicas == 0 && activationRequested(pa, time.Now()) {
+			klog.V(2).InfoS("Scaling adjustment: Keep one replica for the recently activated target.",
+				"recommendedReplicas", desiredReplicas, "adjustedTo", 1)
+			desiredReplicas = 1
+		}
 
 		rescale = desiredReplicas != currentReplicas
 	}
@@ -590,10 +616,11 @@ func (r *PodAutoscalerReconciler) setCurrentReplicasAndMetricsInStatus(pa *autos
 // desired replicas, as well as the metric statuses
 func (r *PodAutoscalerReconciler) setStatus(pa *autoscalingv1alpha1.PodAutoscaler, currentReplicas, desiredReplicas int32, rescale bool) {
 	pa.Status = autoscalingv1alpha1.PodAutoscalerStatus{
-		ActualScale:   currentReplicas,
-		DesiredScale:  desiredReplicas,
-		LastScaleTime: pa.Status.LastScaleTime,
-		Conditions:    pa.Status.Conditions,
+		ActualScale:    currentReplicas,
+		DesiredScale:   desiredReplicas,
+		LastScaleTime:  pa.Status.LastScaleTime,
+		Conditions:     pa.Status.Conditions,
+		CurrentMetrics: pa.Status.CurrentMetrics,
 	}
 
 	if rescale {
@@ -627,7 +654,8 @@ func (r *PodAutoscalerReconciler) updateStatus(ctx context.Context, pa *autoscal
 // It may return both valid metricDesiredReplicas and an error,
 // when some metrics still work and PA should perform scaling based on them.
 // If PodAutoscaler cannot do anything due to error, it returns -1 in metricDesiredReplicas as a failure signal.
-func (r *PodAutoscalerReconciler) computeReplicasForMetrics(ctx context.Context, pa autoscalingv1alpha1.PodAutoscaler, scale *unstructured.Unstructured, metricKey metrics.NamespaceNameMetric) (replicas int32, relatedMetrics string, timestamp time.Time, err error) {
+func (r *PodAutoscalerReconciler) computeReplicasForMetrics(ctx context.Context, pa autoscalingv1alpha1.PodAutoscaler, scale *unstructured.Unstructured,
+	metricKeys []metrics.NamespaceNameMetric, metricSources []autoscalingv1alpha1.MetricSource) (replicas int32, relatedMetrics string, statuses []autoscalingv1alpha1.MetricStatus, timestamp time.Time, err error) {
 	logger := klog.FromContext(ctx)
 	currentTimestamp := time.Now()
 
@@ -635,7 +663,7 @@ func (r *PodAutoscalerReconciler) computeReplicasForMetrics(ctx context.Context,
 	// and convert *metav1.LabelSelector object to labels.Selector structure
 	labelsSelector, err := extractLabelSelector(scale)
 	if err != nil {
-		return 0, "", currentTimestamp, err
+		return -1, "", nil, currentTimestamp, err
 	}
 
 	// Append ray head worker requirement for label selector
@@ -643,7 +671,7 @@ func (r *PodAutoscalerReconciler) computeReplicasForMetrics(ctx context.Context,
 		newRequirement, err := labels.NewRequirement("ray.io/node-type", selection.Equals, []string{"head"})
 		if err != nil {
 			klog.ErrorS(err, "Failed to add new requirements ray.io/node-type: head to label selector")
-			return 0, "", currentTimestamp, err
+			return -1, "", nil, currentTimestamp, err
 		}
 		labelsSelector = labelsSelector.Add(*newRequirement)
 	}
@@ -651,30 +679,64 @@ func (r *PodAutoscalerReconciler) computeReplicasForMetrics(ctx context.Context,
 	originalReadyPodsCount, err := scaler.GetReadyPodsCount(ctx, r.Client, pa.Namespace, labelsSelector)
 
 	if err != nil {
-		return 0, "", currentTimestamp, fmt.Errorf("error getting ready pods count: %w", err)
+		return -1, "", nil, currentTimestamp, fmt.Errorf("error getting ready pods count: %w", err)
+	}
+
+	logger.V(4).Info("Obtained selector and get ReadyPodsCount", "selector", labelsSelector, "originalReadyPodsCount", originalReadyPodsCount)
+
+	// Propose replicas for each metric and take the largest one, as HPA does.
+	replicas = -1
+	var invalidMetricErrs []error
+	for i, metricKey := range metricKeys {
+		metricReplicas, metricStatus, err := r.computeReplicasForMetric(ctx, pa, metricKey, metricSources[i], int(originalReadyPodsCount), currentTimestamp)
+		statuses = append(statuses, metricStatus)
+		if err != nil {
+			invalidMetricErrs = append(invalidMetricErrs, err)
+			continue
+		}
+		if metricReplicas > replicas {
+			replicas = metricReplicas
+			relatedMetrics = metricKey.MetricName
+		}
+	}
+
+	if len(invalidMetricErrs) > 0 {
+		return replicas, relatedMetrics, statuses, currentTimestamp, fmt.Errorf("invalid metrics (%d invalid out of %d), first error is: %v",
+			len(invalidMetricErrs), len(metricKeys), invalidMetricErrs[0])
+	}
+	return replicas, relatedMetrics, statuses, currentTimestamp, nil
+}
+
+// computeReplicasForMetric computes the desired number of replicas proposed by a single metric, and its status.
+func (r *PodAutoscalerReconciler) computeReplicasForMetric(ctx context.Context, pa autoscalingv1alpha1.PodAutoscaler, metricKey metrics.NamespaceNameMetric,
+	metricSource autoscalingv1alpha1.MetricSource, originalReadyPodsCount int, now time.Time) (int32, autoscalingv1alpha1.MetricStatus, error) {
+	logger := klog.FromContext(ctx)
+	status := autoscalingv1alpha1.MetricStatus{
+		TargetMetric: metricSource.TargetMetric,
+		TargetValue:  metricSource.TargetValue,
 	}
 
 	// TODO UpdateScalingContext (in updateScalerSpec) is duplicate invoked in computeReplicasForMetrics and updateMetricsForScale
-	err = r.updateScalerSpec(ctx, pa, metricKey)
+	err := r.updateScalerSpec(ctx, scalingcontext.PodAutoscalerForMetricSource(pa, metricSource), metricKey)
 	if err != nil {
-		klog.ErrorS(err, "Failed to update scaler spec from pa_types")
-		return 0, "", currentTimestamp, fmt.Errorf("error update scaler spec: %w", err)
+		klog.ErrorS(err, "Failed to update scaler spec from pa_types", "metricKey", metricKey)
+		return 0, status, fmt.Errorf("error update scaler spec: %w", err)
 	}
 
-	logger.V(4).Info("Obtained selector and get ReadyPodsCount", "selector", labelsSelector, "originalReadyPodsCount", originalReadyPodsCount)
-
 	// Calculate the desired number of pods using the autoscaler logic.
 	autoScaler, ok := r.AutoscalerMap[metricKey]
 	if !ok {
-		return 0, "", currentTimestamp, fmt.Errorf("unsupported scaling strategy: %s", pa.Spec.ScalingStrategy)
+		return 0, status, fmt.Errorf("unsupported scaling strategy: %s", pa.Spec.ScalingStrategy)
 	}
-	scaleResult := autoScaler.Scale(int(originalReadyPodsCount), metricKey, currentTimestamp)
-	if scaleResult.ScaleValid {
-		logger.V(4).Info("Successfully called Scale Algorithm", "scaleResult", scaleResult)
-		return scaleResult.DesiredPodCount, metricKey.MetricName, currentTimestamp, nil
+	scaleResult := autoScaler.Scale(originalReadyPodsCount, metricKey, now)
+	if !scaleResult.ScaleValid {
+		return 0, status, fmt.Errorf("can not calculate metric %s for scale %s", metricKey.MetricName, pa.Spec.ScaleTargetRef.Name)
 	}
 
-	return 0, "", currentTimestamp, fmt.Errorf("can not calculate metrics for scale %s", pa.Spec.ScaleTargetRef.Name)
+	logger.V(4).Info("Successfully called Scale Algorithm", "metricKey", metricKey, "scaleResult", scaleResult)
+	status.CurrentValue = strconv.FormatFloat(scaleResult.MetricValue, 'f', 2, 64)
+	status.DesiredReplicas = scaleResult.DesiredPodCount
+	return scaleResult.DesiredPodCount, status, nil
 }
 
 // refer to knative-serving.
@@ -688,9 +750,28 @@ func (r *PodAutoscalerReconciler) updateScalerSpec(ctx context.Context, pa autos
 	return autoScaler.UpdateScalingContext(pa)
 }
 
+// deleteStaleMetricScalers removes the scalers of the PodAutoscaler whose metric is no longer in its metrics sources.
+func (r *PodAutoscalerReconciler) deleteStaleMetricScalers(pa autoscalingv1alpha1.PodAutoscaler, metricKeys []metrics.NamespaceNameMetric) {
+	current := make(map[metrics.NamespaceNameMetric]struct{}, len(metricKeys))
+	for _, metricKey := range metricKeys {
+		current[metricKey] = struct{}{}
+	}
+	for metricKey := range r.AutoscalerMap {
+		if metricKey.PaNamespace != pa.Namespace || metricKey.PaName != pa.Name {
+			continue
+		}
+		if _, ok := current[metricKey]; !ok {
+			klog.InfoS("Delete scaler of stale metric", "PaName", pa.Name, "PaNamespace", pa.Namespace, "metric", metricKey.MetricName)
+			delete(r.AutoscalerMap, metricKey)
+		}
+	}
+}
+
 // updateMetricsForScale: we pass into the currentReplicas to construct autoScaler, as KNative implementation
 func (r *PodAutoscalerReconciler) updateMetricsForScale(ctx context.Context, pa autoscalingv1alpha1.PodAutoscaler, scale *unstructured.Unstructured, metricKey metrics.NamespaceNameMetric, metricSource autoscalingv1alpha1.MetricSource, currentReplicas int) (err error) {
 	currentTimestamp := time.Now()
+	// Each metric has its own scaler, which only sees its own metrics source.
+	pa = scalingcontext.PodAutoscalerForMetricSource(pa, metricSource)
 	var autoScaler scaler.Scaler
 	// it's similar to knative: pkg/autoscaler/scaling/multiscaler.go: func (m *MultiScaler) Create
 	autoScaler, exists := r.AutoscalerMap[metricKey]
@@ -176,6 +176,7 @@ func (a *ApaAutoscaler) Scale(originalReadyPodsCount int, metricKey metrics.Name
 		DesiredPodCount:     desiredPodCount,
 		ExcessBurstCapacity: 0,
 		ScaleValid:          true,
+		MetricValue:         currentUsePerPod,
 	}
 }
 
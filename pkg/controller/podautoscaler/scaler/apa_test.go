@@ -60,10 +60,11 @@ func TestAPAScale(t *testing.T) {
 		},
 	}
 
-	metricKey, _, err := metrics.NewNamespaceNameMetric(&pa)
+	metricKeys, _, err := metrics.NewNamespaceNameMetrics(&pa)
 	if err != nil {
-		t.Errorf("NewNamespaceNameMetric() failed: %v", err)
+		t.Fatalf("NewNamespaceNameMetrics() failed: %v", err)
 	}
+	metricKey := metricKeys[0]
 	_ = apaMetricsClient.UpdateMetricIntoWindow(now.Add(-60*time.Second), 10.0)
 	_ = apaMetricsClient.UpdateMetricIntoWindow(now.Add(-50*time.Second), 11.0)
 	_ = apaMetricsClient.UpdateMetricIntoWindow(now.Add(-40*time.Second), 12.0)
@@ -198,10 +199,11 @@ func TestApaScale2(t *testing.T) {
 
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
-	metricKey, _, err := metrics.NewNamespaceNameMetric(pa)
+	metricKeys, _, err := metrics.NewNamespaceNameMetrics(pa)
 	if err != nil {
-		t.Errorf("NewNamespaceNameMetric() failed: %v", err)
+		t.Fatalf("NewNamespaceNameMetrics() failed: %v", err)
 	}
+	metricKey := metricKeys[0]
 
 	type TestData struct {
 		ts              time.Time
@@ -122,4 +122,6 @@ type ScaleResult struct {
 	// ScaleValid specifies whether this scale result is valid, i.e. whether
 	// Autoscaler had all the necessary information to compute a suggestion.
 	ScaleValid bool
+	// MetricValue is the observed value of the metric per ready pod, which is comparable to the target value.
+	MetricValue float64
 }
@@ -386,10 +386,16 @@ func (k *KpaAutoscaler) Scale(originalReadyPodsCount int, metricKey metrics.Name
 		excessBCF = math.Floor(totCap - spec.TargetBurstCapacity - observedPanicValue)
 	}
 
+	observedValue := observedStableValue
+	if k.InPanicMode() {
+		observedValue = observedPanicValue
+	}
+
 	return ScaleResult{
 		DesiredPodCount:     desiredPodCount,
 		ExcessBurstCapacity: int32(excessBCF),
 		ScaleValid:          true,
+		MetricValue:         observedValue / math.Max(1, readyPodsCount),
 	}
 }
 
@@ -98,11 +98,11 @@ func TestKpaScale(t *testing.T) {
 		},
 	}
 
-	metricKey, _, err := metrics.NewNamespaceNameMetric(&pa)
-
+	metricKeys, _, err := metrics.NewNamespaceNameMetrics(&pa)
 	if err != nil {
-		t.Errorf("NewNamespaceNameMetric() failed: %v", err)
+		t.Fatalf("NewNamespaceNameMetrics() failed: %v", err)
 	}
+	metricKey := metricKeys[0]
 
 	result := kpaScaler.Scale(readyPodCount, metricKey, now)
 	// recent rapid rising metric value make scaler adapt turn on panic mode
@@ -243,10 +243,11 @@ func TestKpaScale2(t *testing.T) {
 
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
-	metricKey, _, err := metrics.NewNamespaceNameMetric(pa)
+	metricKeys, _, err := metrics.NewNamespaceNameMetrics(pa)
 	if err != nil {
-		t.Errorf("NewNamespaceNameMetric() failed: %v", err)
+		t.Fatalf("NewNamespaceNameMetrics() failed: %v", err)
 	}
+	metricKey := metricKeys[0]
 
 	type TestData struct {
 		ts              time.Time
@@ -18,6 +18,11 @@ package podautoscaler
 
 import (
 	"fmt"
+	"time"
+
+	autoscalingv1alpha1 "github.com/vllm-project/aibrix/api/autoscaling/v1alpha1"
+	"github.com/vllm-project/aibrix/pkg/constants"
+	"k8s.io/klog/v2"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -50,3 +55,18 @@ func extractLabelSelector(scale *unstructured.Unstructured) (labels.Selector, er
 
 	return labelsSelector, nil
 }
+
+// activationRequested returns true if the gateway requested the PodAutoscaler to scale from zero
+// within the activation grace period.
+func activationRequested(pa autoscalingv1alpha1.PodAutoscaler, now time.Time) bool {
+	value, ok := pa.Annotations[constants.AutoscalingActivationAnnotation]
+	if !ok {
+		return false
+	}
+	requestedAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		klog.ErrorS(err, "Invalid activation annotation", "PodAutoscaler", klog.KObj(&pa), "value", value)
+		return false
+	}
+	return now.Sub(requestedAt) < DefaultActivationGracePeriod
+}
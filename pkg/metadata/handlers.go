@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/redis/go-redis/v9"
@@ -50,6 +51,9 @@ func NewHTTPServer(addr string, redis *redis.Client) *http.Server {
 	r.HandleFunc("/ReadUser", server.readUser).Methods("POST")
 	r.HandleFunc("/UpdateUser", server.updateUser).Methods("POST")
 	r.HandleFunc("/DeleteUser", server.deleteUser).Methods("POST")
+	// API key related handlers
+	r.HandleFunc("/CreateAPIKey", server.createAPIKey).Methods("POST")
+	r.HandleFunc("/RevokeAPIKey", server.revokeAPIKey).Methods("POST")
 	// OpenAI API related handlers
 	r.HandleFunc("/v1/models", server.models).Methods("GET")
 	// Health related handlers
@@ -62,9 +66,9 @@ func NewHTTPServer(addr string, redis *redis.Client) *http.Server {
 	}
 }
 
-// models returns base and lora adapters registered to aibrix control plane
+// models returns base and lora adapters registered to aibrix control plane, along with model aliases
 func (s *httpServer) models(w http.ResponseWriter, r *http.Request) {
-	modelNames := s.cache.ListModels()
+	modelNames := MergeModelAliases(s.cache.ListModels(), s.cache.ListModelAliases())
 	response := BuildModelsResponse(modelNames)
 	jsonBytes, err := json.Marshal(response)
 	if err != nil {
@@ -183,6 +187,109 @@ func (s *httpServer) deleteUser(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "Deleted User: %+v", u)
 }
 
+type createAPIKeyRequest struct {
+	User string `json:"user" validate:"required"`
+	// ExpiresIn is the lifetime of the key in seconds, 0 means the key never expires.
+	ExpiresIn int64 `json:"expires_in" validate:"gte=0"`
+}
+
+type createAPIKeyResponse struct {
+	Key       string     `json:"key"`
+	Hash      string     `json:"hash"`
+	User      string     `json:"user"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type revokeAPIKeyRequest struct {
+	Key  string `json:"key" validate:"required_without=Hash"`
+	Hash string `json:"hash" validate:"required_without=Key"`
+}
+
+func (s *httpServer) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req createAPIKeyRequest
+
+	err := decodeJSONBody(w, r, &req)
+	if err != nil {
+		var mr *malformedRequest
+		if errors.As(err, &mr) {
+			http.Error(w, mr.msg, mr.status)
+		} else {
+			klog.Info(err.Error())
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		}
+		return
+	}
+
+	if !utils.CheckUser(r.Context(), utils.User{Name: req.User}, s.redisClient) {
+		http.Error(w, fmt.Sprintf("User: %+v does not exists", req.User), http.StatusNotFound)
+		return
+	}
+
+	key, err := utils.GenerateAPIKey()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error occurred on generating api key: %+v", err), http.StatusInternalServerError)
+		return
+	}
+	apiKey := utils.APIKey{
+		Hash:      utils.HashAPIKey(key),
+		User:      req.User,
+		CreatedAt: time.Now().UTC(),
+	}
+	if req.ExpiresIn > 0 {
+		expiresAt := apiKey.CreatedAt.Add(time.Duration(req.ExpiresIn) * time.Second)
+		apiKey.ExpiresAt = &expiresAt
+	}
+	if err := utils.SetAPIKey(r.Context(), apiKey, s.redisClient); err != nil {
+		http.Error(w, fmt.Sprintf("error occurred on creating api key: %+v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// The raw key is only returned once, only its hash is stored.
+	jsonBytes, err := json.Marshal(createAPIKeyResponse{
+		Key:       key,
+		Hash:      apiKey.Hash,
+		User:      apiKey.User,
+		ExpiresAt: apiKey.ExpiresAt,
+	})
+	if err != nil {
+		http.Error(w, "error in processing api key", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, "%s", string(jsonBytes))
+}
+
+func (s *httpServer) revokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req revokeAPIKeyRequest
+
+	err := decodeJSONBody(w, r, &req)
+	if err != nil {
+		var mr *malformedRequest
+		if errors.As(err, &mr) {
+			http.Error(w, mr.msg, mr.status)
+		} else {
+			klog.Info(err.Error())
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		}
+		return
+	}
+
+	hash := req.Hash
+	if hash == "" {
+		hash = utils.HashAPIKey(req.Key)
+	}
+	if err := utils.RevokeAPIKey(r.Context(), hash, s.redisClient); err != nil {
+		if errors.Is(err, utils.ErrAPIKeyNotFound) {
+			http.Error(w, "api key does not exists", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("error occurred on revoking api key: %+v", err), http.StatusInternalServerError)
+		return
+	}
+
+	fmt.Fprintf(w, "Revoked API key: %s", hash)
+}
+
 func (s *httpServer) healthz(w http.ResponseWriter, r *http.Request) {
 	// Simple check to verify the service is running
 	w.WriteHeader(http.StatusOK)
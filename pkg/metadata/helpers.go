@@ -25,7 +25,6 @@ import (
 	"strings"
 
 	"github.com/go-playground/validator/v10"
-	"github.com/vllm-project/aibrix/pkg/utils"
 )
 
 type malformedRequest struct {
@@ -37,7 +36,7 @@ func (mr *malformedRequest) Error() string {
 	return mr.msg
 }
 
-func decodeJSONBody(w http.ResponseWriter, r *http.Request, dst *utils.User) error {
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, dst interface{}) error {
 	ct := r.Header.Get("Content-Type")
 	if ct != "" {
 		mediaType := strings.ToLower(strings.TrimSpace(strings.Split(ct, ";")[0]))
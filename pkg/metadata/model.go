@@ -51,3 +51,19 @@ func BuildModelsResponse(modelNames []string) ModelListResponse {
 
 	return response
 }
+
+// MergeModelAliases appends the virtual model names of aliases to the model names, listing each name once
+func MergeModelAliases(modelNames []string, aliases []string) []string {
+	seen := make(map[string]struct{}, len(modelNames))
+	for _, model := range modelNames {
+		seen[model] = struct{}{}
+	}
+	for _, alias := range aliases {
+		if _, ok := seen[alias]; ok {
+			continue
+		}
+		seen[alias] = struct{}{}
+		modelNames = append(modelNames, alias)
+	}
+	return modelNames
+}
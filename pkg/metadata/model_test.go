@@ -18,6 +18,7 @@ package metadata
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -104,3 +105,15 @@ func TestBuildModelsResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeModelAliases(t *testing.T) {
+	merged := MergeModelAliases([]string{"llama-3-70b", "chat-default"}, []string{"chat-default", "chat-fast"})
+	expected := []string{"llama-3-70b", "chat-default", "chat-fast"}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+
+	if merged := MergeModelAliases(nil, nil); len(merged) != 0 {
+		t.Errorf("expected no model, got %v", merged)
+	}
+}
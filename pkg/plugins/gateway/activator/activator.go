@@ -0,0 +1,214 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+const (
+	DefaultQueueSize      = 100
+	DefaultTimeout        = 50 * time.Second
+	DefaultPollInterval   = 500 * time.Millisecond
+	DefaultSignalInterval = 10 * time.Second
+)
+
+var (
+	ErrNotActivatable    = errors.New("model is not configured to scale from zero")
+	ErrQueueFull         = errors.New("activation queue is full")
+	ErrActivationTimeout = errors.New("timed out waiting for model to scale from zero")
+)
+
+// PodLister lists the pods of a model, it is implemented by cache.Cache.
+type PodLister interface {
+	ListPodsByModel(modelName string) (types.PodList, error)
+}
+
+// Signaler asks the autoscaler of a model to scale it from zero.
+type Signaler interface {
+	// Activatable returns true if the model can be scaled from zero.
+	Activatable(model string) bool
+	// Signal requests the model to be scaled from zero.
+	Signal(ctx context.Context, model string) error
+}
+
+type Options struct {
+	// QueueSize is the maximum number of requests waiting for a model.
+	QueueSize int
+	// Timeout is the maximum time a request waits for a routable pod.
+	Timeout time.Duration
+	// PollInterval is the interval between two checks of the model pods.
+	PollInterval time.Duration
+	// SignalInterval is the interval between two signals while requests are waiting.
+	SignalInterval time.Duration
+}
+
+// Activator holds the requests of models scaled to zero until a pod of the model becomes routable.
+type Activator struct {
+	pods     PodLister
+	signaler Signaler
+	opts     Options
+
+	mu     sync.Mutex
+	models map[string]*activation
+}
+
+// activation tracks the requests waiting for a model, ready is closed once the model has a routable pod.
+type activation struct {
+	waiting int
+	ready   chan struct{}
+}
+
+func NewActivator(pods PodLister, signaler Signaler, opts Options) *Activator {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultQueueSize
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.SignalInterval <= 0 {
+		opts.SignalInterval = DefaultSignalInterval
+	}
+	return &Activator{
+		pods:     pods,
+		signaler: signaler,
+		opts:     opts,
+		models:   map[string]*activation{},
+	}
+}
+
+// Activatable returns true if requests of the model can wait for the model to scale from zero.
+func (a *Activator) Activatable(model string) bool {
+	return a.signaler.Activatable(model)
+}
+
+// Activate blocks until the model has a routable pod. It fails if the queue of the model is full,
+// or if no pod becomes routable before the timeout.
+func (a *Activator) Activate(ctx context.Context, model string) error {
+	if !a.signaler.Activatable(model) {
+		return ErrNotActivatable
+	}
+	act, err := a.enqueue(model)
+	if err != nil {
+		return err
+	}
+	defer a.dequeue(act)
+
+	timer := time.NewTimer(a.opts.Timeout)
+	defer timer.Stop()
+	select {
+	case <-act.ready:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%w: no ready pod for model %s after %s", ErrActivationTimeout, model, a.opts.Timeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Waiting returns the number of requests waiting for the model.
+func (a *Activator) Waiting(model string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if act, ok := a.models[model]; ok {
+		return act.waiting
+	}
+	return 0
+}
+
+func (a *Activator) enqueue(model string) (*activation, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	act, ok := a.models[model]
+	if !ok {
+		act = &activation{ready: make(chan struct{})}
+		a.models[model] = act
+		go a.watch(model, act)
+	}
+	if act.waiting >= a.opts.QueueSize {
+		return nil, fmt.Errorf("%w: %d requests waiting for model %s", ErrQueueFull, act.waiting, model)
+	}
+	act.waiting++
+	return act, nil
+}
+
+func (a *Activator) dequeue(act *activation) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	act.waiting--
+}
+
+// watch signals the model to scale from zero, and releases the waiting requests once a pod is routable.
+// It stops when no request is waiting anymore.
+func (a *Activator) watch(model string, act *activation) {
+	var lastSignal time.Time
+	ticker := time.NewTicker(a.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if a.routable(model) {
+			klog.InfoS("model activated, releasing requests", "model", model, "waiting", a.Waiting(model))
+			a.release(model, act)
+			return
+		}
+
+		// All requests left, stop watching under the lock so that no new request joins this activation.
+		a.mu.Lock()
+		waiting := act.waiting
+		if waiting == 0 {
+			delete(a.models, model)
+			a.mu.Unlock()
+			return
+		}
+		a.mu.Unlock()
+
+		if time.Since(lastSignal) >= a.opts.SignalInterval {
+			if err := a.signaler.Signal(context.Background(), model); err != nil {
+				klog.ErrorS(err, "failed to signal model activation", "model", model)
+			} else {
+				klog.InfoS("signaled model activation", "model", model, "waiting", waiting)
+			}
+			lastSignal = time.Now()
+		}
+		<-ticker.C
+	}
+}
+
+func (a *Activator) release(model string, act *activation) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.models, model)
+	close(act.ready)
+}
+
+func (a *Activator) routable(model string) bool {
+	pods, err := a.pods.ListPodsByModel(model)
+	return err == nil && pods != nil && utils.CountRoutablePods(pods.All()) > 0
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	autoscalingv1alpha1 "github.com/vllm-project/aibrix/api/autoscaling/v1alpha1"
+	"github.com/vllm-project/aibrix/pkg/client/clientset/versioned/fake"
+	"github.com/vllm-project/aibrix/pkg/constants"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+type fakePodLister struct {
+	ready atomic.Bool
+}
+
+func (l *fakePodLister) ListPodsByModel(_ string) (types.PodList, error) {
+	if !l.ready.Load() {
+		return &utils.PodArray{}, nil
+	}
+	return &utils.PodArray{Pods: []*v1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{Name: "p1"},
+		Status: v1.PodStatus{
+			PodIP:      "10.0.0.1",
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+		},
+	}}}, nil
+}
+
+type fakeSignaler struct {
+	signals atomic.Int32
+}
+
+func (s *fakeSignaler) Activatable(model string) bool {
+	return model == "m1"
+}
+
+func (s *fakeSignaler) Signal(_ context.Context, _ string) error {
+	s.signals.Add(1)
+	return nil
+}
+
+func TestActivate(t *testing.T) {
+	pods := &fakePodLister{}
+	signaler := &fakeSignaler{}
+	a := NewActivator(pods, signaler, Options{QueueSize: 2, Timeout: 5 * time.Second, PollInterval: 10 * time.Millisecond})
+
+	assert.ErrorIs(t, a.Activate(context.Background(), "m2"), ErrNotActivatable)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = a.Activate(context.Background(), "m1")
+		}(i)
+	}
+	assert.Eventually(t, func() bool { return a.Waiting("m1") == 2 }, time.Second, time.Millisecond)
+
+	// The queue of the model is bounded.
+	assert.ErrorIs(t, a.Activate(context.Background(), "m1"), ErrQueueFull)
+
+	// Waiting requests are released once a pod is routable.
+	pods.ready.Store(true)
+	wg.Wait()
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	assert.Equal(t, int32(1), signaler.signals.Load())
+	assert.Equal(t, 0, a.Waiting("m1"))
+}
+
+func TestActivateTimeout(t *testing.T) {
+	signaler := &fakeSignaler{}
+	a := NewActivator(&fakePodLister{}, signaler, Options{Timeout: 50 * time.Millisecond, PollInterval: 10 * time.Millisecond, SignalInterval: 20 * time.Millisecond})
+
+	assert.ErrorIs(t, a.Activate(context.Background(), "m1"), ErrActivationTimeout)
+	// The model is signaled again while requests are waiting.
+	assert.Greater(t, signaler.signals.Load(), int32(1))
+	assert.Eventually(t, func() bool {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return len(a.models) == 0
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, a.Activate(ctx, "m1"), context.Canceled)
+}
+
+func TestPodAutoscalerSignaler(t *testing.T) {
+	newPA := func(name, model string, minReplicas int32, strategy autoscalingv1alpha1.ScalingStrategyType) *autoscalingv1alpha1.PodAutoscaler {
+		return &autoscalingv1alpha1.PodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{constants.ModelLabelName: model}},
+			Spec:       autoscalingv1alpha1.PodAutoscalerSpec{MinReplicas: &minReplicas, ScalingStrategy: strategy},
+		}
+	}
+	client := fake.NewSimpleClientset(
+		newPA("m1-kpa", "m1", 0, autoscalingv1alpha1.KPA),
+		newPA("m2-kpa", "m2", 1, autoscalingv1alpha1.KPA),
+		newPA("m3-hpa", "m3", 0, autoscalingv1alpha1.HPA),
+	)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	s := NewPodAutoscalerSignaler(client, stopCh).(*podAutoscalerSignaler)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	assert.Eventually(t, func() bool { return s.Activatable("m1") }, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, s.Activatable("m2"))
+	assert.False(t, s.Activatable("m3"))
+	assert.False(t, s.Activatable("unknown"))
+
+	ctx := context.Background()
+	assert.NoError(t, s.Signal(ctx, "m1"))
+	pa, err := client.AutoscalingV1alpha1().PodAutoscalers("default").Get(ctx, "m1-kpa", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-01-01T00:00:00Z", pa.Annotations[constants.AutoscalingActivationAnnotation])
+	assert.ErrorIs(t, s.Signal(ctx, "m2"), ErrNotActivatable)
+}
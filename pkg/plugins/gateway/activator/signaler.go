@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	autoscalingv1alpha1 "github.com/vllm-project/aibrix/api/autoscaling/v1alpha1"
+	"github.com/vllm-project/aibrix/pkg/client/clientset/versioned"
+	"github.com/vllm-project/aibrix/pkg/client/informers/externalversions"
+	listers "github.com/vllm-project/aibrix/pkg/client/listers/autoscaling/v1alpha1"
+	"github.com/vllm-project/aibrix/pkg/constants"
+)
+
+const podAutoscalerResyncPeriod = 10 * time.Minute
+
+// podAutoscalerSignaler signals the PodAutoscalers of a model by annotating them with the time of the request
+// for activation, the PodAutoscaler controller then scales the target from zero.
+// A model can be activated if it is labeled on a custom PodAutoscaler with minReplicas set to 0.
+type podAutoscalerSignaler struct {
+	client versioned.Interface
+	lister listers.PodAutoscalerLister
+	now    func() time.Time
+}
+
+// NewPodAutoscalerSignaler returns a Signaler backed by an informer of PodAutoscalers, running until stopCh is closed.
+func NewPodAutoscalerSignaler(client versioned.Interface, stopCh <-chan struct{}) Signaler {
+	factory := externalversions.NewSharedInformerFactory(client, podAutoscalerResyncPeriod)
+	lister := factory.Autoscaling().V1alpha1().PodAutoscalers().Lister()
+	factory.Start(stopCh)
+
+	return &podAutoscalerSignaler{
+		client: client,
+		lister: lister,
+		now:    time.Now,
+	}
+}
+
+func (s *podAutoscalerSignaler) Activatable(model string) bool {
+	return len(s.podAutoscalers(model)) > 0
+}
+
+func (s *podAutoscalerSignaler) Signal(ctx context.Context, model string) error {
+	pas := s.podAutoscalers(model)
+	if len(pas) == 0 {
+		return ErrNotActivatable
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				constants.AutoscalingActivationAnnotation: s.now().UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, pa := range pas {
+		_, err := s.client.AutoscalingV1alpha1().PodAutoscalers(pa.Namespace).Patch(ctx, pa.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to annotate podautoscaler %s/%s: %w", pa.Namespace, pa.Name, err))
+			continue
+		}
+		klog.V(4).InfoS("requested podautoscaler activation", "model", model, "podautoscaler", klog.KObj(pa))
+	}
+	return errors.Join(errs...)
+}
+
+// podAutoscalers returns the custom PodAutoscalers of the model which can scale to zero.
+func (s *podAutoscalerSignaler) podAutoscalers(model string) []*autoscalingv1alpha1.PodAutoscaler {
+	pas, err := s.lister.List(labels.SelectorFromSet(labels.Set{constants.ModelLabelName: model}))
+	if err != nil {
+		klog.ErrorS(err, "failed to list podautoscalers", "model", model)
+		return nil
+	}
+
+	activatable := make([]*autoscalingv1alpha1.PodAutoscaler, 0, len(pas))
+	for _, pa := range pas {
+		if pa.Spec.ScalingStrategy == autoscalingv1alpha1.HPA {
+			continue
+		}
+		if pa.Spec.MinReplicas == nil || *pa.Spec.MinReplicas != 0 {
+			continue
+		}
+		activatable = append(activatable, pa)
+	}
+	return activatable
+}
@@ -52,17 +52,43 @@ func (r leastExpectedLatencyRouter) Route(ctx *types.RoutingContext, readyPodLis
 	var targetPod *v1.Pod
 	minExpectedLatency := math.MaxFloat64
 
+	expectedLatencies := getExpectedLatencies(r.cache, ctx.Model, readyPodList.All())
+	for _, pod := range readyPodList.All() {
+		totalExpectedLatency, ok := expectedLatencies[pod.Name]
+		if ok && totalExpectedLatency <= minExpectedLatency {
+			minExpectedLatency = totalExpectedLatency
+			targetPod = pod
+		}
+	}
+
+	// Use fallback if no valid metrics
+	if targetPod == nil {
+		var err error
+		targetPod, err = SelectRandomPodAsFallback(ctx, readyPodList.All(), rand.Intn)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	ctx.SetTargetPod(targetPod)
+	return ctx.TargetAddress(), nil
+}
+
+// getExpectedLatencies estimates the latency of a request on each pod from its queuing time, and its prefill and
+// decode time per token. Pods without the metrics are omitted.
+func getExpectedLatencies(cache cache.Cache, model string, readyPods []*v1.Pod) map[string]float64 {
+	expectedLatencies := make(map[string]float64, len(readyPods))
 	sumPromptTokens := 0.0
 	sumGenerationTokens := 0.0
 	cntPromt := 0
 	cntGeneration := 0
-	for _, pod := range readyPodList.All() {
-		avgPromptTokens, err := r.cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, ctx.Model, metrics.AvgPromptToksPerReq)
+	for _, pod := range readyPods {
+		avgPromptTokens, err := cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, model, metrics.AvgPromptToksPerReq)
 		if err != nil {
 			klog.Error(err)
 			continue
 		}
-		avgGenerationTokens, err := r.cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, ctx.Model, metrics.AvgGenerationToksPerReq)
+		avgGenerationTokens, err := cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, model, metrics.AvgGenerationToksPerReq)
 		if err != nil {
 			klog.Error(err)
 			continue
@@ -85,21 +111,21 @@ func (r leastExpectedLatencyRouter) Route(ctx *types.RoutingContext, readyPodLis
 		guessGenerationTokens = sumGenerationTokens / float64(cntGeneration)
 	}
 
-	for _, pod := range readyPodList.All() {
+	for _, pod := range readyPods {
 		// expected queuing latency
-		queuingLatency, err := r.cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, ctx.Model, metrics.RequestQueueTimeSeconds)
+		queuingLatency, err := cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, model, metrics.RequestQueueTimeSeconds)
 		if err != nil {
 			klog.Error(err)
 			continue
 		}
 
 		// expected prefill latency
-		avgPromptTokens, err := r.cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, ctx.Model, metrics.AvgPromptToksPerReq)
+		avgPromptTokens, err := cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, model, metrics.AvgPromptToksPerReq)
 		if err != nil {
 			klog.Error(err)
 			continue
 		}
-		PrefillTime, err := r.cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, ctx.Model, metrics.RequestPrefillTimeSeconds)
+		PrefillTime, err := cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, model, metrics.RequestPrefillTimeSeconds)
 		if err != nil {
 			klog.Error(err)
 			continue
@@ -107,12 +133,12 @@ func (r leastExpectedLatencyRouter) Route(ctx *types.RoutingContext, readyPodLis
 		prefillLatency := PrefillTime.GetHistogramValue().GetMean() / avgPromptTokens.GetSimpleValue() * guessPromptTokens
 
 		// expected decode latency
-		avgGenerationTokens, err := r.cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, ctx.Model, metrics.AvgGenerationToksPerReq)
+		avgGenerationTokens, err := cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, model, metrics.AvgGenerationToksPerReq)
 		if err != nil {
 			klog.Error(err)
 			continue
 		}
-		DecodeTime, err := r.cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, ctx.Model, metrics.RequestDecodeTimeSeconds)
+		DecodeTime, err := cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, model, metrics.RequestDecodeTimeSeconds)
 		if err != nil {
 			klog.Error(err)
 			continue
@@ -123,21 +149,7 @@ func (r leastExpectedLatencyRouter) Route(ctx *types.RoutingContext, readyPodLis
 		klog.V(4).Infof("pod: %v, podIP: %v, queuingLatency: %v, prefillLatency: %v, decodeLatency: %v, totalExpectedLatency: %v",
 			pod.Name, pod.Status.PodIP, queuingLatency.GetSimpleValue(), prefillLatency, decodeLatency, totalExpectedLatency)
 
-		if totalExpectedLatency <= minExpectedLatency {
-			minExpectedLatency = totalExpectedLatency
-			targetPod = pod
-		}
-	}
-
-	// Use fallback if no valid metrics
-	if targetPod == nil {
-		var err error
-		targetPod, err = SelectRandomPodAsFallback(ctx, readyPodList.All(), rand.Intn)
-		if err != nil {
-			return "", err
-		}
+		expectedLatencies[pod.Name] = totalExpectedLatency
 	}
-
-	ctx.SetTargetPod(targetPod)
-	return ctx.TargetAddress(), nil
+	return expectedLatencies
 }
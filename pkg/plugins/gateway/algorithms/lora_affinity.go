@@ -0,0 +1,221 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routingalgorithms
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/cache"
+	"github.com/vllm-project/aibrix/pkg/metrics"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+const (
+	RouterLoraAffinity types.RoutingAlgorithm = "lora-affinity"
+
+	defaultLoraAffinityResidencyWeight float64 = 1.0
+	defaultLoraAffinityHeadroomWeight  float64 = 0.5
+	defaultLoraAffinityLoadWeight      float64 = 1.0
+
+	// Residency scores of an adapter running on, or waiting to be loaded by, the engine of a pod.
+	loraRunningResidency = 1.0
+	loraWaitingResidency = 0.5
+)
+
+var (
+	loraAffinityResidencyWeight = utils.LoadEnvFloat("AIBRIX_LORA_AFFINITY_RESIDENCY_WEIGHT", defaultLoraAffinityResidencyWeight)
+	loraAffinityHeadroomWeight  = utils.LoadEnvFloat("AIBRIX_LORA_AFFINITY_HEADROOM_WEIGHT", defaultLoraAffinityHeadroomWeight)
+	loraAffinityLoadWeight      = utils.LoadEnvFloat("AIBRIX_LORA_AFFINITY_LOAD_WEIGHT", defaultLoraAffinityLoadWeight)
+)
+
+func init() {
+	Register(RouterLoraAffinity, NewLoraAffinityRouter)
+}
+
+// loraAffinityRouter prefers the pods having the requested LoRA adapter loaded, so that requests do not pay for
+// adapter swaps on cold pods. Pods are scored by adapter residency, free adapter slots and load.
+type loraAffinityRouter struct {
+	cache           cache.Cache
+	residencyWeight float64 // Weight of the adapter residency in the pod score
+	headroomWeight  float64 // Weight of the free adapter slots in the pod score
+	loadWeight      float64 // Weight of the pod load in the pod score
+}
+
+func NewLoraAffinityRouter() (types.Router, error) {
+	c, err := cache.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	klog.InfoS("lora-affinity router configured", "residencyWeight", loraAffinityResidencyWeight,
+		"headroomWeight", loraAffinityHeadroomWeight, "loadWeight", loraAffinityLoadWeight)
+	return loraAffinityRouter{
+		cache:           c,
+		residencyWeight: loraAffinityResidencyWeight,
+		headroomWeight:  loraAffinityHeadroomWeight,
+		loadWeight:      loraAffinityLoadWeight,
+	}, nil
+}
+
+// loraPodState is the LoRA adapter state reported by the engine of a pod.
+type loraPodState struct {
+	reported bool     // Whether the engine reports any LoRA adapter metric
+	running  []string // Adapters loaded and serving requests
+	waiting  []string // Adapters of requests waiting to be served
+	maxLoras int      // Max number of adapters loaded at the same time, 0 if unknown
+}
+
+// getLoraPodState reads the LoRA adapter label metrics of the pod.
+func getLoraPodState(c cache.MetricCache, pod *v1.Pod) loraPodState {
+	var state loraPodState
+	if value, ok := getPodLabelMetricValue(c, pod, metrics.RunningLoraAdapters); ok {
+		state.reported = true
+		state.running = splitLoraAdapters(value)
+	}
+	if value, ok := getPodLabelMetricValue(c, pod, metrics.WaitingLoraAdapters); ok {
+		state.reported = true
+		state.waiting = splitLoraAdapters(value)
+	}
+	if value, ok := getPodLabelMetricValue(c, pod, metrics.MaxLora); ok {
+		state.reported = true
+		if maxLoras, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && maxLoras > 0 {
+			state.maxLoras = int(maxLoras)
+		}
+	}
+	return state
+}
+
+// residency returns how hot the adapter is on the pod: 1 if running, 0.5 if waiting to be loaded, 0 otherwise.
+func (s loraPodState) residency(adapter string) float64 {
+	for _, name := range s.running {
+		if name == adapter {
+			return loraRunningResidency
+		}
+	}
+	for _, name := range s.waiting {
+		if name == adapter {
+			return loraWaitingResidency
+		}
+	}
+	return 0
+}
+
+// headroom returns the ratio of free adapter slots of the pod, 0 if max loras is unknown.
+func (s loraPodState) headroom() float64 {
+	if s.maxLoras <= 0 {
+		return 0
+	}
+	return math.Max(float64(s.maxLoras-len(s.running)), 0) / float64(s.maxLoras)
+}
+
+// Route routes the request to the pod of the highest score, picking randomly among ties. The score is
+// residencyWeight * residency + headroomWeight * headroom - loadWeight * load, where load is the running requests
+// normalized by the busiest pod. If no pod reports LoRA adapter metrics, the pod of the fewest running requests is picked.
+func (r loraAffinityRouter) Route(ctx *types.RoutingContext, readyPodList types.PodList) (string, error) {
+	readyPods := readyPodList.All()
+	targetPod := r.selectPodByScore(ctx, readyPods)
+	if targetPod == nil {
+		klog.V(4).InfoS("no lora adapter metrics reported, falling back to least-request", "requestID", ctx.RequestID, "model", ctx.Model)
+		targetPod = selectTargetPodWithLeastRequestCount(r.cache, readyPods)
+	}
+
+	// Use fallback if no valid metrics
+	if targetPod == nil {
+		var err error
+		targetPod, err = SelectRandomPodAsFallback(ctx, readyPods, rand.Intn)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	ctx.SetTargetPod(targetPod)
+	return ctx.TargetAddress(), nil
+}
+
+// selectPodByScore returns the pod of the highest score, or nil if no pod reports LoRA adapter metrics.
+func (r loraAffinityRouter) selectPodByScore(ctx *types.RoutingContext, pods []*v1.Pod) *v1.Pod {
+	states := make([]loraPodState, len(pods))
+	runningRequests := make([]float64, len(pods))
+	reported := false
+	maxRunningRequests := 0.0
+	for i, pod := range pods {
+		states[i] = getLoraPodState(r.cache, pod)
+		reported = reported || states[i].reported
+		runningRequests[i] = getPodMetricValue(r.cache, pod, "", metrics.RealtimeNumRequestsRunning)
+		maxRunningRequests = math.Max(maxRunningRequests, runningRequests[i])
+	}
+	if !reported {
+		return nil
+	}
+
+	var candidates []*v1.Pod
+	bestScore := math.Inf(-1)
+	for i, pod := range pods {
+		residency, headroom, load := states[i].residency(ctx.Model), states[i].headroom(), 0.0
+		if maxRunningRequests > 0 {
+			load = runningRequests[i] / maxRunningRequests
+		}
+		score := r.residencyWeight*residency + r.headroomWeight*headroom - r.loadWeight*load
+
+		klog.V(4).InfoS("lora-affinity pod score", "requestID", ctx.RequestID, "pod", pod.Name, "adapter", ctx.Model,
+			"residency", residency, "headroom", headroom, "runningRequests", runningRequests[i], "score", score)
+
+		if score > bestScore {
+			bestScore = score
+			candidates = []*v1.Pod{pod}
+		} else if score == bestScore {
+			candidates = append(candidates, pod)
+		}
+	}
+	return candidates[rand.Intn(len(candidates))]
+}
+
+func (r *loraAffinityRouter) SubscribedMetrics() []string {
+	return []string{
+		metrics.RealtimeNumRequestsRunning,
+		metrics.RunningLoraAdapters,
+		metrics.WaitingLoraAdapters,
+		metrics.MaxLora,
+	}
+}
+
+// getPodLabelMetricValue returns the label value of the pod metric, false if the metric is not available.
+func getPodLabelMetricValue(c cache.MetricCache, pod *v1.Pod, metricName string) (string, bool) {
+	value, err := c.GetMetricValueByPod(pod.Name, pod.Namespace, metricName)
+	if err != nil || value == nil {
+		return "", false
+	}
+	return value.GetLabelValue(), true
+}
+
+// splitLoraAdapters splits the comma separated adapter names reported by the engine.
+func splitLoraAdapters(value string) []string {
+	var adapters []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			adapters = append(adapters, name)
+		}
+	}
+	return adapters
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routingalgorithms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vllm-project/aibrix/pkg/metrics"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+func TestLoraAffinityRouter_Route(t *testing.T) {
+	pods := newP2CTestPods(3)
+
+	tests := []struct {
+		name         string
+		podMetrics   map[string]map[string]float64
+		labelMetrics map[string]map[string]string
+		expected     string
+	}{
+		{
+			name: "adapter running on a busier pod",
+			podMetrics: map[string]map[string]float64{
+				"p0": {metrics.RealtimeNumRequestsRunning: 2},
+				"p1": {metrics.RealtimeNumRequestsRunning: 4},
+				"p2": {metrics.RealtimeNumRequestsRunning: 1},
+			},
+			labelMetrics: map[string]map[string]string{
+				"p0": {metrics.RunningLoraAdapters: "other", metrics.MaxLora: "2"},
+				"p1": {metrics.RunningLoraAdapters: "other, adapter", metrics.MaxLora: "2"},
+				"p2": {metrics.RunningLoraAdapters: "x,y", metrics.MaxLora: "2"},
+			},
+			expected: "p1",
+		},
+		{
+			name: "adapter running preferred over waiting",
+			labelMetrics: map[string]map[string]string{
+				"p0": {metrics.WaitingLoraAdapters: "adapter"},
+				"p1": {metrics.RunningLoraAdapters: "adapter"},
+				"p2": {metrics.RunningLoraAdapters: ""},
+			},
+			expected: "p1",
+		},
+		{
+			name: "cold pods ranked by free adapter slots",
+			labelMetrics: map[string]map[string]string{
+				"p0": {metrics.RunningLoraAdapters: "a,b", metrics.MaxLora: "2"},
+				"p1": {metrics.RunningLoraAdapters: "a", metrics.MaxLora: "4"},
+				"p2": {metrics.RunningLoraAdapters: "a,b,c", metrics.MaxLora: "4"},
+			},
+			expected: "p1",
+		},
+		{
+			name: "overloaded resident pod loses to an idle pod",
+			podMetrics: map[string]map[string]float64{
+				"p0": {metrics.RealtimeNumRequestsRunning: 10},
+				"p1": {metrics.RealtimeNumRequestsRunning: 0},
+				"p2": {metrics.RealtimeNumRequestsRunning: 10},
+			},
+			labelMetrics: map[string]map[string]string{
+				"p0": {metrics.WaitingLoraAdapters: "adapter", metrics.MaxLora: "1"},
+				"p1": {metrics.RunningLoraAdapters: "other", metrics.MaxLora: "2"},
+				"p2": {metrics.RunningLoraAdapters: "other", metrics.MaxLora: "1"},
+			},
+			expected: "p1",
+		},
+		{
+			name: "no lora metrics falls back to least-request",
+			podMetrics: map[string]map[string]float64{
+				"p0": {metrics.RealtimeNumRequestsRunning: 3},
+				"p1": {metrics.RealtimeNumRequestsRunning: 2},
+				"p2": {metrics.RealtimeNumRequestsRunning: 1},
+			},
+			expected: "p2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := loraAffinityRouter{
+				cache:           &fakeMetricCache{podMetrics: tt.podMetrics, podLabelMetrics: tt.labelMetrics},
+				residencyWeight: defaultLoraAffinityResidencyWeight,
+				headroomWeight:  defaultLoraAffinityHeadroomWeight,
+				loadWeight:      defaultLoraAffinityLoadWeight,
+			}
+			ctx := types.NewRoutingContext(context.Background(), RouterLoraAffinity, "adapter", "message", "request", "user")
+			defer ctx.Delete()
+			_, err := r.Route(ctx, &utils.PodArray{Pods: pods})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ctx.TargetPod().Name)
+		})
+	}
+
+	r := loraAffinityRouter{cache: &fakeMetricCache{}}
+	ctx := types.NewRoutingContext(context.Background(), RouterLoraAffinity, "adapter", "message", "request", "user")
+	defer ctx.Delete()
+	_, err := r.Route(ctx, &utils.PodArray{})
+	assert.Error(t, err)
+}
+
+func TestGetLoraPodState(t *testing.T) {
+	pods := newP2CTestPods(2)
+	fakeCache := &fakeMetricCache{
+		podLabelMetrics: map[string]map[string]string{
+			"p0": {metrics.RunningLoraAdapters: " a, b ,", metrics.WaitingLoraAdapters: "c", metrics.MaxLora: "invalid"},
+		},
+	}
+
+	state := getLoraPodState(fakeCache, pods[0])
+	assert.True(t, state.reported)
+	assert.Equal(t, []string{"a", "b"}, state.running)
+	assert.Equal(t, []string{"c"}, state.waiting)
+	assert.Equal(t, 0, state.maxLoras)
+	assert.Equal(t, 1.0, state.residency("b"))
+	assert.Equal(t, 0.5, state.residency("c"))
+	assert.Equal(t, 0.0, state.residency("d"))
+	assert.Equal(t, 0.0, state.headroom())
+
+	assert.False(t, getLoraPodState(fakeCache, pods[1]).reported)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routingalgorithms
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/cache"
+	"github.com/vllm-project/aibrix/pkg/metrics"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+const (
+	RouterP2C types.RoutingAlgorithm = "p2c"
+
+	// Load signals of the p2c router
+	P2CLoadRunning = "running"
+	P2CLoadWaiting = "waiting"
+	P2CLoadKVCache = "kv-cache"
+	P2CLoadPending = "pending"
+
+	defaultP2CChoices = 2
+)
+
+var (
+	p2cChoices    = utils.LoadEnvInt("AIBRIX_P2C_CHOICES", defaultP2CChoices)
+	p2cLoadSignal = utils.LoadEnv("AIBRIX_P2C_LOAD_SIGNAL", P2CLoadRunning)
+)
+
+func init() {
+	Register(RouterP2C, NewP2CRouter)
+}
+
+// p2cRouter samples a few random pods and routes to the least loaded one. Unlike the least-* routers, bursts of
+// requests are spread over the pods even when the load signal is stale.
+type p2cRouter struct {
+	loadProvider cache.LoadProvider
+	loadSignal   string
+	choices      int
+	randIntn     func(int) int
+}
+
+func NewP2CRouter() (types.Router, error) {
+	c, err := cache.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	var loadProvider cache.LoadProvider
+	switch p2cLoadSignal {
+	case P2CLoadRunning:
+		loadProvider = &metricLoadProvider{cache: c, metricName: metrics.RealtimeNumRequestsRunning}
+	case P2CLoadWaiting:
+		loadProvider = &metricLoadProvider{cache: c, metricName: metrics.NumRequestsWaiting, modelScoped: true}
+	case P2CLoadKVCache:
+		loadProvider = &metricLoadProvider{cache: c, metricName: metrics.GPUCacheUsagePerc, modelScoped: true}
+	case P2CLoadPending:
+		if loadProvider, err = cache.NewPendingLoadProvider(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported p2c load signal %q", p2cLoadSignal)
+	}
+
+	choices := p2cChoices
+	if choices < 2 {
+		klog.Warningf("invalid p2c choices %d, using default %d", choices, defaultP2CChoices)
+		choices = defaultP2CChoices
+	}
+	klog.InfoS("p2c router configured", "loadSignal", p2cLoadSignal, "choices", choices)
+	return p2cRouter{
+		loadProvider: loadProvider,
+		loadSignal:   p2cLoadSignal,
+		choices:      choices,
+		randIntn:     rand.Intn,
+	}, nil
+}
+
+// Route samples the configured number of pods and routes the request to the one with the lowest load.
+func (r p2cRouter) Route(ctx *types.RoutingContext, readyPodList types.PodList) (string, error) {
+	var targetPod *v1.Pod
+	minLoad := math.MaxFloat64
+	for _, pod := range samplePods(readyPodList.All(), r.choices, r.randIntn) {
+		load, err := r.loadProvider.GetUtilization(ctx, pod)
+		if err != nil {
+			klog.V(4).InfoS("failed to get pod load, assuming idle", "requestID", ctx.RequestID, "pod", pod.Name, "loadSignal", r.loadSignal, "error", err)
+			load = 0
+		}
+		if load < minLoad {
+			targetPod, minLoad = pod, load
+		}
+	}
+	if targetPod == nil {
+		return "", fmt.Errorf("no pods to forward request")
+	}
+
+	klog.V(4).InfoS("p2c routing", "requestID", ctx.RequestID, "pod", targetPod.Name, "loadSignal", r.loadSignal, "load", minLoad)
+	ctx.SetTargetPod(targetPod)
+	return ctx.TargetAddress(), nil
+}
+
+func (r *p2cRouter) SubscribedMetrics() []string {
+	switch r.loadSignal {
+	case P2CLoadWaiting:
+		return []string{metrics.NumRequestsWaiting}
+	case P2CLoadKVCache:
+		return []string{metrics.GPUCacheUsagePerc}
+	case P2CLoadPending:
+		return []string{metrics.RealtimeNormalizedPendings}
+	default:
+		return []string{metrics.RealtimeNumRequestsRunning}
+	}
+}
+
+// samplePods returns n distinct random pods, or all pods if there are no more than n.
+func samplePods(pods []*v1.Pod, n int, randIntn func(int) int) []*v1.Pod {
+	if len(pods) <= n {
+		return pods
+	}
+	sampled := make([]*v1.Pod, len(pods))
+	copy(sampled, pods)
+	for i := 0; i < n; i++ {
+		j := i + randIntn(len(sampled)-i)
+		sampled[i], sampled[j] = sampled[j], sampled[i]
+	}
+	return sampled[:n]
+}
+
+// metricLoadProvider reads the load of a pod from a pod or pod-model metric in the cache.
+type metricLoadProvider struct {
+	cache       cache.MetricCache
+	metricName  string
+	modelScoped bool
+}
+
+func (p *metricLoadProvider) GetUtilization(ctx *types.RoutingContext, pod *v1.Pod) (float64, error) {
+	var value metrics.MetricValue
+	var err error
+	if p.modelScoped {
+		value, err = p.cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, ctx.Model, p.metricName)
+	} else {
+		value, err = p.cache.GetMetricValueByPod(pod.Name, pod.Namespace, p.metricName)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return value.GetSimpleValue(), nil
+}
+
+func (p *metricLoadProvider) GetConsumption(ctx *types.RoutingContext, pod *v1.Pod) (float64, error) {
+	return 0, cache.ErrorNotSupport
+}
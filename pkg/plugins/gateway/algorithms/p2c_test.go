@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routingalgorithms
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vllm-project/aibrix/pkg/metrics"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+func newP2CTestPods(n int) []*v1.Pod {
+	pods := make([]*v1.Pod, n)
+	for i := range pods {
+		pods[i] = &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("p%d", i), Namespace: "default"},
+			Status: v1.PodStatus{
+				PodIP:      fmt.Sprintf("10.0.0.%d", i),
+				Phase:      v1.PodRunning,
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+			},
+		}
+	}
+	return pods
+}
+
+func TestP2CRouter_Route(t *testing.T) {
+	pods := newP2CTestPods(3)
+	fakeCache := &fakeMetricCache{
+		podMetrics: map[string]map[string]float64{
+			"p0": {metrics.RealtimeNumRequestsRunning: 5},
+			"p1": {metrics.RealtimeNumRequestsRunning: 1},
+		},
+		podModelMetrics: map[string]map[string]float64{
+			"p0": {metrics.GPUCacheUsagePerc: 0.1},
+			"p1": {metrics.GPUCacheUsagePerc: 0.9},
+			"p2": {metrics.GPUCacheUsagePerc: 0.5},
+		},
+	}
+	// Always sample p0 and p1
+	sampleFirst := func(int) int { return 0 }
+
+	tests := []struct {
+		name         string
+		loadProvider *metricLoadProvider
+		choices      int
+		expected     string
+	}{
+		{
+			name:         "running requests of sampled pods",
+			loadProvider: &metricLoadProvider{cache: fakeCache, metricName: metrics.RealtimeNumRequestsRunning},
+			choices:      2,
+			expected:     "p1",
+		},
+		{
+			name:         "kv cache usage of sampled pods",
+			loadProvider: &metricLoadProvider{cache: fakeCache, metricName: metrics.GPUCacheUsagePerc, modelScoped: true},
+			choices:      2,
+			expected:     "p0",
+		},
+		{
+			name:         "pods without metrics are idle",
+			loadProvider: &metricLoadProvider{cache: fakeCache, metricName: metrics.RealtimeNumRequestsRunning},
+			choices:      3,
+			expected:     "p2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := p2cRouter{loadProvider: tt.loadProvider, choices: tt.choices, randIntn: sampleFirst}
+			ctx := types.NewRoutingContext(context.Background(), RouterP2C, "m1", "message", "request", "user")
+			defer ctx.Delete()
+			_, err := r.Route(ctx, &utils.PodArray{Pods: pods})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ctx.TargetPod().Name)
+		})
+	}
+
+	r := p2cRouter{loadProvider: &metricLoadProvider{cache: fakeCache}, choices: 2, randIntn: sampleFirst}
+	ctx := types.NewRoutingContext(context.Background(), RouterP2C, "m1", "message", "request", "user")
+	defer ctx.Delete()
+	_, err := r.Route(ctx, &utils.PodArray{})
+	assert.Error(t, err)
+}
+
+func TestSamplePods(t *testing.T) {
+	pods := newP2CTestPods(5)
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		sampled := samplePods(pods, 2, rng.Intn)
+		assert.Len(t, sampled, 2)
+		assert.NotEqual(t, sampled[0], sampled[1])
+	}
+	assert.Equal(t, pods, samplePods(pods, 5, rng.Intn))
+	assert.Equal(t, []*v1.Pod{pods[0], pods[1], pods[2], pods[3], pods[4]}, pods, "pods must not be reordered")
+}
+
+// TestP2CRouter_StaleMetricsSimulation routes bursts of requests with running request metrics refreshed only
+// between bursts, and compares the load imbalance of p2c and least-request.
+func TestP2CRouter_StaleMetricsSimulation(t *testing.T) {
+	const (
+		numPods    = 8
+		rounds     = 50
+		burst      = 32
+		completion = 4 // Requests completed by each pod per round
+	)
+	pods := newP2CTestPods(numPods)
+
+	simulate := func(route func(fakeCache *fakeMetricCache) *v1.Pod) int {
+		load := make(map[string]int, numPods)
+		for i, pod := range pods {
+			load[pod.Name] = i // Distinct initial loads
+		}
+		maxImbalance := 0
+		for round := 0; round < rounds; round++ {
+			// Metrics are scraped once per round
+			fakeCache := &fakeMetricCache{podMetrics: map[string]map[string]float64{}}
+			for name, running := range load {
+				fakeCache.podMetrics[name] = map[string]float64{metrics.RealtimeNumRequestsRunning: float64(running)}
+			}
+			for i := 0; i < burst; i++ {
+				load[route(fakeCache).Name]++
+			}
+
+			minLoad, maxLoad := burst*rounds, 0
+			for name, running := range load {
+				minLoad, maxLoad = min(minLoad, running), max(maxLoad, running)
+				load[name] = max(running-completion, 0)
+			}
+			maxImbalance = max(maxImbalance, maxLoad-minLoad)
+		}
+		return maxImbalance
+	}
+
+	leastRequestImbalance := simulate(func(fakeCache *fakeMetricCache) *v1.Pod {
+		return selectTargetPodWithLeastRequestCount(fakeCache, pods)
+	})
+	rng := rand.New(rand.NewSource(1))
+	p2cImbalance := simulate(func(fakeCache *fakeMetricCache) *v1.Pod {
+		r := p2cRouter{
+			loadProvider: &metricLoadProvider{cache: fakeCache, metricName: metrics.RealtimeNumRequestsRunning},
+			choices:      2,
+			randIntn:     rng.Intn,
+		}
+		ctx := types.NewRoutingContext(context.Background(), RouterP2C, "m1", "message", "request", "user")
+		defer ctx.Delete()
+		_, err := r.Route(ctx, &utils.PodArray{Pods: pods})
+		assert.NoError(t, err)
+		return ctx.TargetPod()
+	})
+
+	t.Logf("max load imbalance: least-request %d, p2c %d", leastRequestImbalance, p2cImbalance)
+	assert.Less(t, p2cImbalance, leastRequestImbalance)
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"math/rand"
 	"net/http"
 	"strconv"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/vllm-project/aibrix/pkg/cache"
 	"github.com/vllm-project/aibrix/pkg/constants"
+	"github.com/vllm-project/aibrix/pkg/metrics"
 	"github.com/vllm-project/aibrix/pkg/types"
 	"github.com/vllm-project/aibrix/pkg/utils"
 	"github.com/vllm-project/aibrix/pkg/utils/prefixcacheindexer"
@@ -48,10 +50,14 @@ const (
 	RoleReplicaIndex              string                 = "stormservice.orchestration.aibrix.ai/role-replica-index"
 	PodGroupIndex                 string                 = "stormservice.orchestration.aibrix.ai/pod-group-index"
 	defaultPrefillRequestTimeout  int                    = 30
+	defaultPDPrefixMatchWeight    float64                = 1.0
+	defaultPDLoadWeight           float64                = 1.0
 )
 
 var (
-	prefillRequestTimeout int = utils.LoadEnvInt("AIBRIX_PREFILL_REQUEST_TIMEOUT", defaultPrefillRequestTimeout)
+	prefillRequestTimeout int     = utils.LoadEnvInt("AIBRIX_PREFILL_REQUEST_TIMEOUT", defaultPrefillRequestTimeout)
+	pdPrefixMatchWeight   float64 = utils.LoadEnvFloat("AIBRIX_PD_PREFIX_MATCH_WEIGHT", defaultPDPrefixMatchWeight)
+	pdLoadWeight          float64 = utils.LoadEnvFloat("AIBRIX_PD_LOAD_WEIGHT", defaultPDLoadWeight)
 )
 
 func init() {
@@ -61,15 +67,17 @@ func init() {
 type pdRouter struct {
 	cache              cache.Cache
 	tokenizer          tokenizer.Tokenizer
+	tokenizerPool      *TokenizerPool // Per-model local tokenizers, nil unless a tokenizer directory is configured
 	prefixCacheIndexer *prefixcacheindexer.PrefixHashTable
+	prefixMatchWeight  float64 // Weight of the prefix match ratio in the pod score
+	loadWeight         float64 // Weight of the pod load in the pod score
 }
 
 func NewPDRouter() (types.Router, error) {
-	var tokenizerObj tokenizer.Tokenizer
-	if tokenizerType == tokenizerTypeTiktoken {
-		tokenizerObj = tokenizer.NewTiktokenTokenizer()
-	} else {
-		tokenizerObj = tokenizer.NewCharacterTokenizer()
+	tokenizerObj, localTokenizerDir, err := newLocalTokenizer()
+	if err != nil {
+		klog.ErrorS(err, "fail to create local tokenizer in pd disaggregation router", "tokenizer_type", tokenizerType)
+		return nil, err
 	}
 
 	c, err := cache.Get()
@@ -78,11 +86,20 @@ func NewPDRouter() (types.Router, error) {
 		return nil, err
 	}
 
-	return pdRouter{
+	router := pdRouter{
 		cache:              c,
 		tokenizer:          tokenizerObj,
 		prefixCacheIndexer: prefixcacheindexer.NewPrefixHashTable(),
-	}, nil
+		prefixMatchWeight:  pdPrefixMatchWeight,
+		loadWeight:         pdLoadWeight,
+	}
+	if localTokenizerDir != "" {
+		router.tokenizerPool = NewTokenizerPool(TokenizerPoolConfig{
+			DefaultTokenizer:  tokenizerObj,
+			LocalTokenizerDir: localTokenizerDir,
+		}, c)
+	}
+	return router, nil
 }
 
 func (r pdRouter) Route(ctx *types.RoutingContext, readyPodList types.PodList) (string, error) {
@@ -97,7 +114,7 @@ func (r pdRouter) Route(ctx *types.RoutingContext, readyPodList types.PodList) (
 		return "", err
 	}
 
-	decodePod := r.selectDecodePod(prefillPod, decodePods)
+	decodePod := r.selectDecodePod(ctx, prefillPod, decodePods)
 	if decodePod == nil {
 		return "", fmt.Errorf("decode pod not found")
 	}
@@ -133,7 +150,11 @@ func (r *pdRouter) filterPrefillDecodePods(readyPods []*v1.Pod) ([]*v1.Pod, []*v
 }
 
 func (r *pdRouter) evaluatePrefixCache(ctx *types.RoutingContext, prefillPods []*v1.Pod) (*v1.Pod, []uint64, error) {
-	tokens, err := r.tokenizer.TokenizeInputText(ctx.Message)
+	tokenizerToUse := r.tokenizer
+	if r.tokenizerPool != nil {
+		tokenizerToUse = r.tokenizerPool.GetTokenizer(ctx.Model, nil)
+	}
+	tokens, err := tokenizerToUse.TokenizeInputText(ctx.Message)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -144,18 +165,14 @@ func (r *pdRouter) evaluatePrefixCache(ctx *types.RoutingContext, prefillPods []
 	}
 	matchedPods, prefixHashes := r.prefixCacheIndexer.MatchPrefix(tokens, ctx.Model, readyPodsMap)
 
-	var prefillPod *v1.Pod
-	if len(matchedPods) > 0 {
-		prefillPod = getTargetPodFromMatchedPods(r.cache, prefillPods, matchedPods)
-	}
+	prefillPod := r.selectPodByScore(ctx, prefillPods, matchedPods)
 	if prefillPod == nil {
-		prefillPod, err = utils.SelectRandomPod(prefillPods, rand.Intn)
+		return nil, nil, fmt.Errorf("prefill pod not found")
 	}
-
-	return prefillPod, prefixHashes, err
+	return prefillPod, prefixHashes, nil
 }
 
-func (r *pdRouter) selectDecodePod(prefillPod *v1.Pod, decodePods []*v1.Pod) *v1.Pod {
+func (r *pdRouter) selectDecodePod(ctx *types.RoutingContext, prefillPod *v1.Pod, decodePods []*v1.Pod) *v1.Pod {
 	prefillRoleSet, ok := prefillPod.Labels[PDRoleSetIdentifier]
 	if !ok {
 		return nil
@@ -171,8 +188,61 @@ func (r *pdRouter) selectDecodePod(prefillPod *v1.Pod, decodePods []*v1.Pod) *v1
 		return nil
 	}
 
-	decodePod, _ := utils.SelectRandomPod(filteredDecodePods, rand.Intn)
-	return decodePod
+	return r.selectPodByScore(ctx, filteredDecodePods, nil)
+}
+
+// selectPodByScore returns the pod of the highest score, picking randomly among ties. The score is
+// prefixMatchWeight * prefix match ratio - loadWeight * load, where load is the mean of the running
+// requests normalized by the busiest pod, the GPU KV cache usage and the normalized pending load.
+func (r *pdRouter) selectPodByScore(ctx *types.RoutingContext, pods []*v1.Pod, prefixMatches map[string]int) *v1.Pod {
+	if len(pods) == 0 {
+		return nil
+	}
+
+	runningRequests := make([]float64, len(pods))
+	maxRunningRequests := 0.0
+	for i, pod := range pods {
+		runningRequests[i] = getPodMetricValue(r.cache, pod, "", metrics.RealtimeNumRequestsRunning)
+		maxRunningRequests = math.Max(maxRunningRequests, runningRequests[i])
+	}
+
+	var candidates []*v1.Pod
+	bestScore := math.Inf(-1)
+	for i, pod := range pods {
+		load := math.Min(getPodMetricValue(r.cache, pod, ctx.Model, metrics.GPUCacheUsagePerc), 1.0) +
+			math.Min(getPodMetricValue(r.cache, pod, "", metrics.RealtimeNormalizedPendings), 1.0)
+		if maxRunningRequests > 0 {
+			load += runningRequests[i] / maxRunningRequests
+		}
+		score := r.prefixMatchWeight*float64(prefixMatches[pod.Name])/100 - r.loadWeight*load/3
+
+		klog.V(4).InfoS("pd pod score", "request_id", ctx.RequestID, "pod", pod.Name,
+			"prefix_match", prefixMatches[pod.Name], "running_requests", runningRequests[i], "load", load/3, "score", score)
+
+		if score > bestScore {
+			bestScore = score
+			candidates = []*v1.Pod{pod}
+		} else if score == bestScore {
+			candidates = append(candidates, pod)
+		}
+	}
+	return candidates[rand.Intn(len(candidates))]
+}
+
+// getPodMetricValue returns the metric value of the pod, 0 if the metric is not available.
+// A non empty model reads the metric of the pod-model pair.
+func getPodMetricValue(c cache.MetricCache, pod *v1.Pod, model string, metricName string) float64 {
+	var value metrics.MetricValue
+	var err error
+	if model == "" {
+		value, err = c.GetMetricValueByPod(pod.Name, pod.Namespace, metricName)
+	} else {
+		value, err = c.GetMetricValueByPodModel(pod.Name, pod.Namespace, model, metricName)
+	}
+	if err != nil || value == nil {
+		return 0
+	}
+	return math.Max(value.GetSimpleValue(), 0)
 }
 
 func (r *pdRouter) doPrefillRequest(routingCtx *types.RoutingContext, prefillPods []*v1.Pod, llmEngine string) (*v1.Pod, error) {
@@ -286,7 +356,11 @@ func (r *pdRouter) executeHTTPRequest(url string, routingCtx *types.RoutingConte
 }
 
 func (r *pdRouter) SubscribedMetrics() []string {
-	return []string{}
+	return []string{
+		metrics.RealtimeNumRequestsRunning,
+		metrics.GPUCacheUsagePerc,
+		metrics.RealtimeNormalizedPendings,
+	}
 }
 
 func getLLMEngine(pod *v1.Pod, labelName string, defaultValue string) string {
@@ -19,6 +19,7 @@ package routingalgorithms
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/vllm-project/aibrix/pkg/cache"
+	"github.com/vllm-project/aibrix/pkg/metrics"
 	"github.com/vllm-project/aibrix/pkg/types"
 	"github.com/vllm-project/aibrix/pkg/utils"
 	"github.com/vllm-project/aibrix/pkg/utils/prefixcacheindexer"
@@ -235,6 +237,106 @@ func TestDoPrefillRequest(t *testing.T) {
 	}
 }
 
+// fakeMetricCache serves pod and pod-model metrics from maps, metrics not set are not found
+type fakeMetricCache struct {
+	cache.Cache
+	podMetrics      map[string]map[string]float64 // pod name -> metric name -> value
+	podModelMetrics map[string]map[string]float64 // pod name -> metric name -> value
+	podLabelMetrics map[string]map[string]string  // pod name -> metric name -> label value
+}
+
+func (c *fakeMetricCache) GetMetricValueByPod(podName, podNamespace, metricName string) (metrics.MetricValue, error) {
+	if value, ok := c.podLabelMetrics[podName][metricName]; ok {
+		return &metrics.LabelValueMetricValue{Value: value}, nil
+	}
+	if value, ok := c.podMetrics[podName][metricName]; ok {
+		return &metrics.SimpleMetricValue{Value: value}, nil
+	}
+	return nil, fmt.Errorf("metric %s not found for pod %s", metricName, podName)
+}
+
+func (c *fakeMetricCache) GetMetricValueByPodModel(podName, podNamespace, modelName, metricName string) (metrics.MetricValue, error) {
+	if value, ok := c.podModelMetrics[podName][metricName]; ok {
+		return &metrics.SimpleMetricValue{Value: value}, nil
+	}
+	return nil, fmt.Errorf("metric %s not found for pod %s model %s", metricName, podName, modelName)
+}
+
+func TestPDRouter_SelectPodByScore(t *testing.T) {
+	pods := []*v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "p1", Labels: map[string]string{PDRoleSetIdentifier: "rs1"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "p2", Labels: map[string]string{PDRoleSetIdentifier: "rs1"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "p3", Labels: map[string]string{PDRoleSetIdentifier: "rs2"}}},
+	}
+	fakeCache := &fakeMetricCache{
+		podMetrics: map[string]map[string]float64{
+			"p1": {metrics.RealtimeNumRequestsRunning: 8},
+			"p2": {metrics.RealtimeNumRequestsRunning: 2, metrics.RealtimeNormalizedPendings: 0.1},
+			"p3": {metrics.RealtimeNumRequestsRunning: 0},
+		},
+		podModelMetrics: map[string]map[string]float64{
+			"p1": {metrics.GPUCacheUsagePerc: 0.9},
+			"p2": {metrics.GPUCacheUsagePerc: 0.2},
+		},
+	}
+	ctx := types.NewRoutingContext(context.Background(), RouterPD, "m1", "message", "request", "user")
+
+	tests := []struct {
+		name              string
+		prefixMatchWeight float64
+		loadWeight        float64
+		prefixMatches     map[string]int
+		expected          string
+	}{
+		{
+			name:       "least loaded pod without prefix match",
+			loadWeight: 1,
+			expected:   "p3",
+		},
+		{
+			name:              "prefix match outweighs load",
+			prefixMatchWeight: 2,
+			loadWeight:        1,
+			prefixMatches:     map[string]int{"p1": 100, "p2": 20},
+			expected:          "p1",
+		},
+		{
+			name:              "load outweighs prefix match",
+			prefixMatchWeight: 0.5,
+			loadWeight:        1,
+			prefixMatches:     map[string]int{"p1": 100, "p2": 20},
+			expected:          "p2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := pdRouter{cache: fakeCache, prefixMatchWeight: tt.prefixMatchWeight, loadWeight: tt.loadWeight}
+			candidates := pods
+			if tt.prefixMatches != nil {
+				candidates = pods[:2]
+			}
+			pod := r.selectPodByScore(ctx, candidates, tt.prefixMatches)
+			assert.NotNil(t, pod)
+			assert.Equal(t, tt.expected, pod.Name)
+		})
+	}
+
+	// Decode pods are scored within the roleset of the prefill pod
+	r := pdRouter{cache: fakeCache, prefixMatchWeight: 1, loadWeight: 1}
+	decodePod := r.selectDecodePod(ctx, pods[0], pods)
+	assert.NotNil(t, decodePod)
+	assert.Equal(t, "p2", decodePod.Name)
+	assert.Nil(t, r.selectDecodePod(ctx, &v1.Pod{}, pods))
+
+	// Ties are broken randomly
+	r = pdRouter{cache: &fakeMetricCache{}, loadWeight: 1}
+	selected := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		selected[r.selectPodByScore(ctx, pods, nil).Name] = true
+	}
+	assert.Len(t, selected, 3)
+}
+
 // Common test utilities
 func setupTestServer(t *testing.T, code int, resp string, llmEngine string) *httptest.Server {
 	l, err := net.Listen("tcp", "127.0.0.1:8000")
@@ -0,0 +1,363 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routingalgorithms
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/cache"
+	"github.com/vllm-project/aibrix/pkg/types"
+)
+
+const EnvRoutingPipelineConfig = "AIBRIX_ROUTING_PIPELINE_CONFIG"
+
+// FilterFallback is the action taken when a filter removes all pods.
+type FilterFallback string
+
+const (
+	// FilterFallbackSkip skips the filter and keeps the pods it was given, so that the request is still served.
+	FilterFallbackSkip FilterFallback = "skip"
+	// FilterFallbackFail fails the request.
+	FilterFallbackFail FilterFallback = "fail"
+)
+
+// FilterPlugin removes the pods that should not serve the request.
+type FilterPlugin interface {
+	Filter(ctx *types.RoutingContext, pods []*v1.Pod) []*v1.Pod
+}
+
+// ScorePlugin scores the pods for the request, in the order of the pods, a higher score is better.
+// The scores are normalized to [0, 1] across the pods before being weighted.
+type ScorePlugin interface {
+	Score(ctx *types.RoutingContext, pods []*v1.Pod) []float64
+}
+
+// ReservePlugin is notified of the pod the request is routed to, e.g. to record the prefix cached by the pod.
+type ReservePlugin interface {
+	Reserve(ctx *types.RoutingContext, pod *v1.Pod)
+}
+
+type FilterPluginFactory func(c cache.Cache, args json.RawMessage) (FilterPlugin, error)
+type ScorePluginFactory func(c cache.Cache, args json.RawMessage) (ScorePlugin, error)
+
+var (
+	filterPlugins = map[string]FilterPluginFactory{}
+	scorePlugins  = map[string]ScorePluginFactory{}
+
+	pipelineFilterFallbackCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "aibrix_gateway_pipeline_filter_fallbacks_total",
+			Help: "Number of requests for which a filter of a routing pipeline removed all pods, by fallback action",
+		},
+		[]string{"pipeline", "model", "filter", "action"},
+	)
+)
+
+// RegisterFilterPlugin registers a filter plugin to be used in routing pipelines. It is not thread-safe and should be
+// called from init functions.
+func RegisterFilterPlugin(name string, factory FilterPluginFactory) {
+	filterPlugins[name] = factory
+}
+
+// RegisterScorePlugin registers a score plugin to be used in routing pipelines. It is not thread-safe and should be
+// called from init functions.
+func RegisterScorePlugin(name string, factory ScorePluginFactory) {
+	scorePlugins[name] = factory
+}
+
+// PipelineConfig declares the routing pipelines, each pipeline name is exposed as a routing strategy.
+type PipelineConfig struct {
+	Pipelines []PipelineSpec `json:"pipelines"`
+}
+
+// PipelineSpec declares the plugins of a routing pipeline. A routing strategy has a pipeline per model, and
+// a pipeline without model for the other models.
+type PipelineSpec struct {
+	Name    string       `json:"name"`
+	Model   string       `json:"model,omitempty"`
+	Filters []PluginSpec `json:"filters,omitempty"`
+	Scorers []PluginSpec `json:"scorers,omitempty"`
+}
+
+type PluginSpec struct {
+	Name string `json:"name"`
+	// Weight of the scorer, defaults to 1. Ignored for filters.
+	Weight *float64 `json:"weight,omitempty"`
+	// OnEmpty is the action taken when the filter removes all pods, skip by default. Ignored for scorers.
+	OnEmpty FilterFallback `json:"onEmpty,omitempty"`
+	// Args of the plugin, see the plugin for the supported args.
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+// LoadPipelineConfig reads the routing pipelines from a YAML or JSON file, such as one mounted from a ConfigMap.
+func LoadPipelineConfig(path string) (*PipelineConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config PipelineConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid routing pipeline config %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// registerPipelines registers a router for each pipeline name of the config, pipelines named after another
+// routing strategy are ignored.
+func (rm *RouterManager) registerPipelines(config *PipelineConfig) {
+	specs := map[string][]PipelineSpec{}
+	for _, spec := range config.Pipelines {
+		if spec.Name == "" {
+			klog.Warningf("Ignoring routing pipeline without name for model %q", spec.Model)
+			continue
+		}
+		specs[spec.Name] = append(specs[spec.Name], spec)
+	}
+
+	for name, pipelineSpecs := range specs {
+		if err := validatePipelineSpecs(pipelineSpecs); err != nil {
+			klog.ErrorS(err, "Ignoring invalid routing pipeline", "name", name)
+			continue
+		}
+		algorithm := types.RoutingAlgorithm(name)
+		rm.routerMu.RLock()
+		_, registered := rm.routerConstructor[algorithm]
+		_, provided := rm.routerFactory[algorithm]
+		rm.routerMu.RUnlock()
+		if registered || provided {
+			klog.Errorf("Ignoring routing pipeline %s, the routing strategy already exists", name)
+			continue
+		}
+
+		rm.Register(algorithm, func() (types.Router, error) {
+			c, err := cache.Get()
+			if err != nil {
+				return nil, err
+			}
+			return newPipelineRouter(c, name, pipelineSpecs)
+		})
+	}
+}
+
+// validatePipelineSpecs checks the pipelines of a routing strategy before the router is constructed, plugin args are
+// checked by the plugins on construction.
+func validatePipelineSpecs(specs []PipelineSpec) error {
+	models := map[string]struct{}{}
+	for _, spec := range specs {
+		if _, exists := models[spec.Model]; exists {
+			return fmt.Errorf("duplicated pipeline for model %q", spec.Model)
+		}
+		models[spec.Model] = struct{}{}
+		for _, pluginSpec := range spec.Filters {
+			if _, ok := filterPlugins[pluginSpec.Name]; !ok {
+				return fmt.Errorf("unknown filter plugin %s", pluginSpec.Name)
+			}
+			switch pluginSpec.OnEmpty {
+			case "", FilterFallbackSkip, FilterFallbackFail:
+			default:
+				return fmt.Errorf("unknown onEmpty action %q of filter plugin %s", pluginSpec.OnEmpty, pluginSpec.Name)
+			}
+		}
+		for _, pluginSpec := range spec.Scorers {
+			if _, ok := scorePlugins[pluginSpec.Name]; !ok {
+				return fmt.Errorf("unknown score plugin %s", pluginSpec.Name)
+			}
+			if pluginSpec.Weight != nil && *pluginSpec.Weight < 0 {
+				return fmt.Errorf("negative weight %v of score plugin %s", *pluginSpec.Weight, pluginSpec.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// pipelineRouter routes the requests of a model through the pipeline of the model, or the default pipeline.
+type pipelineRouter struct {
+	name      string
+	pipelines map[string]*pipeline // model -> pipeline, "" for the default pipeline
+}
+
+// pipeline filters the pods, then routes to the pod with the highest weighted score, ties are broken randomly.
+type pipeline struct {
+	name    string
+	filters []namedPlugin[FilterPlugin]
+	scorers []namedPlugin[ScorePlugin]
+}
+
+type namedPlugin[T any] struct {
+	name    string
+	plugin  T
+	weight  float64
+	onEmpty FilterFallback
+}
+
+func newPipelineRouter(c cache.Cache, name string, specs []PipelineSpec) (*pipelineRouter, error) {
+	if err := validatePipelineSpecs(specs); err != nil {
+		return nil, fmt.Errorf("invalid routing pipeline %s: %w", name, err)
+	}
+
+	r := &pipelineRouter{name: name, pipelines: make(map[string]*pipeline, len(specs))}
+	for _, spec := range specs {
+		p, err := newPipeline(c, spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid routing pipeline %s for model %q: %w", name, spec.Model, err)
+		}
+		r.pipelines[spec.Model] = p
+	}
+	return r, nil
+}
+
+func newPipeline(c cache.Cache, spec PipelineSpec) (*pipeline, error) {
+	p := &pipeline{name: spec.Name}
+	for _, pluginSpec := range spec.Filters {
+		plugin, err := filterPlugins[pluginSpec.Name](c, pluginSpec.Args)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter plugin %s: %w", pluginSpec.Name, err)
+		}
+		onEmpty := pluginSpec.OnEmpty
+		if onEmpty == "" {
+			onEmpty = FilterFallbackSkip
+		}
+		p.filters = append(p.filters, namedPlugin[FilterPlugin]{name: pluginSpec.Name, plugin: plugin, onEmpty: onEmpty})
+	}
+	for _, pluginSpec := range spec.Scorers {
+		weight := 1.0
+		if pluginSpec.Weight != nil {
+			weight = *pluginSpec.Weight
+		}
+		plugin, err := scorePlugins[pluginSpec.Name](c, pluginSpec.Args)
+		if err != nil {
+			return nil, fmt.Errorf("invalid score plugin %s: %w", pluginSpec.Name, err)
+		}
+		p.scorers = append(p.scorers, namedPlugin[ScorePlugin]{name: pluginSpec.Name, plugin: plugin, weight: weight})
+	}
+	return p, nil
+}
+
+func (r *pipelineRouter) Route(ctx *types.RoutingContext, readyPodList types.PodList) (string, error) {
+	p, ok := r.pipelines[ctx.Model]
+	if !ok {
+		p, ok = r.pipelines[""]
+	}
+
+	var targetPod *v1.Pod
+	if ok {
+		var err error
+		if targetPod, err = p.selectPod(ctx, readyPodList.All()); err != nil {
+			return "", err
+		}
+	} else {
+		klog.Warningf("No routing pipeline %s for model %s, requestID: %s", r.name, ctx.Model, ctx.RequestID)
+	}
+	if targetPod == nil {
+		var err error
+		targetPod, err = SelectRandomPodAsFallback(ctx, readyPodList.All(), rand.Intn)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		p.reserve(ctx, targetPod)
+	}
+
+	ctx.SetTargetPod(targetPod)
+	return ctx.TargetAddress(), nil
+}
+
+// selectPod returns the pod of the highest score, nil if there is no pod, or an error if a filter removing all pods
+// fails the request.
+func (p *pipeline) selectPod(ctx *types.RoutingContext, pods []*v1.Pod) (*v1.Pod, error) {
+	for _, filter := range p.filters {
+		filtered := filter.plugin.Filter(ctx, pods)
+		if len(filtered) == 0 && len(pods) > 0 {
+			pipelineFilterFallbackCounter.WithLabelValues(p.name, ctx.Model, filter.name, string(filter.onEmpty)).Inc()
+			if filter.onEmpty == FilterFallbackFail {
+				return nil, fmt.Errorf("filter %s of routing pipeline %s removed all pods", filter.name, p.name)
+			}
+			// Keep serving the request rather than failing it, as the router does not queue requests.
+			klog.InfoS("filter removed all pods, skipping it", "requestID", ctx.RequestID, "pipeline", p.name, "filter", filter.name)
+			continue
+		}
+		pods = filtered
+	}
+	if len(pods) <= 1 {
+		if len(pods) == 0 {
+			return nil, nil
+		}
+		return pods[0], nil
+	}
+
+	totalScores := make([]float64, len(pods))
+	for _, scorer := range p.scorers {
+		scores := scorer.plugin.Score(ctx, pods)
+		normalizeScores(scores)
+		for i := range totalScores {
+			totalScores[i] += scorer.weight * scores[i]
+		}
+	}
+
+	var candidates []*v1.Pod
+	maxScore := 0.0
+	for i, score := range totalScores {
+		if len(candidates) == 0 || score > maxScore {
+			candidates, maxScore = []*v1.Pod{pods[i]}, score
+		} else if score == maxScore {
+			candidates = append(candidates, pods[i])
+		}
+	}
+	targetPod := candidates[rand.Intn(len(candidates))]
+	klog.V(4).InfoS("pipeline routing", "requestID", ctx.RequestID, "pod", targetPod.Name, "score", maxScore, "candidates", len(candidates))
+	return targetPod, nil
+}
+
+func (p *pipeline) reserve(ctx *types.RoutingContext, pod *v1.Pod) {
+	for _, filter := range p.filters {
+		if reserve, ok := filter.plugin.(ReservePlugin); ok {
+			reserve.Reserve(ctx, pod)
+		}
+	}
+	for _, scorer := range p.scorers {
+		if reserve, ok := scorer.plugin.(ReservePlugin); ok {
+			reserve.Reserve(ctx, pod)
+		}
+	}
+}
+
+// normalizeScores scales the scores to [0, 1] by min-max normalization, equal scores are all 0.
+func normalizeScores(scores []float64) {
+	if len(scores) == 0 {
+		return
+	}
+	minScore, maxScore := scores[0], scores[0]
+	for _, score := range scores {
+		minScore, maxScore = min(minScore, score), max(maxScore, score)
+	}
+	for i := range scores {
+		if maxScore > minScore {
+			scores[i] = (scores[i] - minScore) / (maxScore - minScore)
+		} else {
+			scores[i] = 0
+		}
+	}
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routingalgorithms
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/cache"
+	"github.com/vllm-project/aibrix/pkg/metrics"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils/prefixcacheindexer"
+	"github.com/vllm-project/aibrix/pkg/utils/tokenizer"
+)
+
+const defaultMaxKVCacheUsage = 0.9
+
+func init() {
+	RegisterFilterPlugin("kv-cache", newKVCacheFilter)
+	RegisterFilterPlugin("running-requests", newRunningRequestsFilter)
+	RegisterFilterPlugin("lora-resident", newLoraResidentFilter)
+
+	RegisterScorePlugin("prefix-match", newPrefixMatchScorer)
+	RegisterScorePlugin("least-request", newLeastRequestScorer)
+	RegisterScorePlugin("least-kv-cache", newLeastKVCacheScorer)
+	RegisterScorePlugin("least-latency", newLeastLatencyScorer)
+	RegisterScorePlugin("lora-resident", newLoraResidentScorer)
+}
+
+// decodePluginArgs decodes the args of a plugin, unknown args are rejected.
+func decodePluginArgs(args json.RawMessage, v any) error {
+	if len(args) == 0 || string(args) == "null" {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(args))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// kvCacheFilter keeps the pods with a GPU KV cache usage up to maxUsage, pods without metrics are kept.
+type kvCacheFilter struct {
+	cache    cache.Cache
+	maxUsage float64
+}
+
+func newKVCacheFilter(c cache.Cache, rawArgs json.RawMessage) (FilterPlugin, error) {
+	args := struct {
+		MaxUsage float64 `json:"maxUsage"`
+	}{MaxUsage: defaultMaxKVCacheUsage}
+	if err := decodePluginArgs(rawArgs, &args); err != nil {
+		return nil, err
+	}
+	if args.MaxUsage <= 0 || args.MaxUsage > 1 {
+		return nil, fmt.Errorf("maxUsage must be in (0, 1], got %v", args.MaxUsage)
+	}
+	return &kvCacheFilter{cache: c, maxUsage: args.MaxUsage}, nil
+}
+
+func (f *kvCacheFilter) Filter(ctx *types.RoutingContext, pods []*v1.Pod) []*v1.Pod {
+	filtered := make([]*v1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if getPodMetricValue(f.cache, pod, ctx.Model, metrics.GPUCacheUsagePerc) <= f.maxUsage {
+			filtered = append(filtered, pod)
+		}
+	}
+	return filtered
+}
+
+// runningRequestsFilter keeps the pods running less than max requests.
+type runningRequestsFilter struct {
+	cache cache.Cache
+	max   int
+}
+
+func newRunningRequestsFilter(c cache.Cache, rawArgs json.RawMessage) (FilterPlugin, error) {
+	args := struct {
+		Max int `json:"max"`
+	}{}
+	if err := decodePluginArgs(rawArgs, &args); err != nil {
+		return nil, err
+	}
+	if args.Max <= 0 {
+		return nil, fmt.Errorf("max must be positive, got %d", args.Max)
+	}
+	return &runningRequestsFilter{cache: c, max: args.Max}, nil
+}
+
+func (f *runningRequestsFilter) Filter(ctx *types.RoutingContext, pods []*v1.Pod) []*v1.Pod {
+	requestCounts := getRequestCounts(f.cache, pods)
+	filtered := make([]*v1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if requestCounts[pod.Name] < f.max {
+			filtered = append(filtered, pod)
+		}
+	}
+	return filtered
+}
+
+// loraResidentPlugin keeps, or scores 1, the pods having the requested model loaded as a LoRA adapter.
+type loraResidentPlugin struct {
+	cache cache.Cache
+}
+
+func newLoraResidentFilter(c cache.Cache, rawArgs json.RawMessage) (FilterPlugin, error) {
+	return &loraResidentPlugin{cache: c}, decodePluginArgs(rawArgs, &struct{}{})
+}
+
+func newLoraResidentScorer(c cache.Cache, rawArgs json.RawMessage) (ScorePlugin, error) {
+	return &loraResidentPlugin{cache: c}, decodePluginArgs(rawArgs, &struct{}{})
+}
+
+func (p *loraResidentPlugin) Filter(ctx *types.RoutingContext, pods []*v1.Pod) []*v1.Pod {
+	filtered := make([]*v1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if loraAdapterResident(p.cache, pod, ctx.Model) {
+			filtered = append(filtered, pod)
+		}
+	}
+	return filtered
+}
+
+func (p *loraResidentPlugin) Score(ctx *types.RoutingContext, pods []*v1.Pod) []float64 {
+	scores := make([]float64, len(pods))
+	for i, pod := range pods {
+		if loraAdapterResident(p.cache, pod, ctx.Model) {
+			scores[i] = 1
+		}
+	}
+	return scores
+}
+
+// loraAdapterResident returns true if the engine of the pod reports the adapter as running or waiting.
+func loraAdapterResident(c cache.MetricCache, pod *v1.Pod, adapter string) bool {
+	return getLoraPodState(c, pod).residency(adapter) > 0
+}
+
+// prefixMatchScorer scores the pods by the percentage of the prompt prefix they have cached.
+type prefixMatchScorer struct {
+	tokenizer          tokenizer.Tokenizer
+	tokenizerPool      TokenizerPoolInterface // nil when not using tokenizers per model
+	prefixCacheIndexer *prefixcacheindexer.PrefixHashTable
+}
+
+func newPrefixMatchScorer(c cache.Cache, rawArgs json.RawMessage) (ScorePlugin, error) {
+	if err := decodePluginArgs(rawArgs, &struct{}{}); err != nil {
+		return nil, err
+	}
+	tokenizerObj, localTokenizerDir, err := newLocalTokenizer()
+	if err != nil {
+		return nil, err
+	}
+	scorer := &prefixMatchScorer{
+		tokenizer:          tokenizerObj,
+		prefixCacheIndexer: prefixcacheindexer.NewPrefixHashTable(),
+	}
+	if localTokenizerDir != "" {
+		scorer.tokenizerPool = NewTokenizerPool(TokenizerPoolConfig{
+			DefaultTokenizer:  tokenizerObj,
+			LocalTokenizerDir: localTokenizerDir,
+		}, c)
+	}
+	return scorer, nil
+}
+
+func (s *prefixMatchScorer) tokenize(ctx *types.RoutingContext) ([]byte, error) {
+	tokenizerToUse := s.tokenizer
+	if s.tokenizerPool != nil {
+		tokenizerToUse = s.tokenizerPool.GetTokenizer(ctx.Model, nil)
+	}
+	return tokenizerToUse.TokenizeInputText(ctx.Message)
+}
+
+func (s *prefixMatchScorer) Score(ctx *types.RoutingContext, pods []*v1.Pod) []float64 {
+	scores := make([]float64, len(pods))
+	tokens, err := s.tokenize(ctx)
+	if err != nil {
+		klog.ErrorS(err, "failed to tokenize prompt for prefix match", "requestID", ctx.RequestID)
+		return scores
+	}
+
+	readyPodsMap := make(map[string]struct{}, len(pods))
+	for _, pod := range pods {
+		readyPodsMap[pod.Name] = struct{}{}
+	}
+	matchedPods, _ := s.prefixCacheIndexer.MatchPrefix(tokens, ctx.Model, readyPodsMap)
+	for i, pod := range pods {
+		scores[i] = float64(matchedPods[pod.Name])
+	}
+	return scores
+}
+
+// Reserve records the prompt prefix as cached by the pod.
+func (s *prefixMatchScorer) Reserve(ctx *types.RoutingContext, pod *v1.Pod) {
+	tokens, err := s.tokenize(ctx)
+	if err != nil {
+		return
+	}
+	if prefixHashes := s.prefixCacheIndexer.GetPrefixHashes(tokens); len(prefixHashes) > 0 {
+		s.prefixCacheIndexer.AddPrefix(prefixHashes, ctx.Model, pod.Name)
+	}
+}
+
+// leastRequestScorer prefers the pods with fewer running requests.
+type leastRequestScorer struct {
+	cache cache.Cache
+}
+
+func newLeastRequestScorer(c cache.Cache, rawArgs json.RawMessage) (ScorePlugin, error) {
+	return &leastRequestScorer{cache: c}, decodePluginArgs(rawArgs, &struct{}{})
+}
+
+func (s *leastRequestScorer) Score(ctx *types.RoutingContext, pods []*v1.Pod) []float64 {
+	requestCounts := getRequestCounts(s.cache, pods)
+	scores := make([]float64, len(pods))
+	for i, pod := range pods {
+		scores[i] = -float64(requestCounts[pod.Name])
+	}
+	return scores
+}
+
+// leastKVCacheScorer prefers the pods with a lower GPU KV cache usage.
+type leastKVCacheScorer struct {
+	cache cache.Cache
+}
+
+func newLeastKVCacheScorer(c cache.Cache, rawArgs json.RawMessage) (ScorePlugin, error) {
+	return &leastKVCacheScorer{cache: c}, decodePluginArgs(rawArgs, &struct{}{})
+}
+
+func (s *leastKVCacheScorer) Score(ctx *types.RoutingContext, pods []*v1.Pod) []float64 {
+	scores := make([]float64, len(pods))
+	for i, pod := range pods {
+		scores[i] = -getPodMetricValue(s.cache, pod, ctx.Model, metrics.GPUCacheUsagePerc)
+	}
+	return scores
+}
+
+// leastLatencyScorer prefers the pods with a lower expected latency, pods without metrics score the lowest.
+type leastLatencyScorer struct {
+	cache cache.Cache
+}
+
+func newLeastLatencyScorer(c cache.Cache, rawArgs json.RawMessage) (ScorePlugin, error) {
+	return &leastLatencyScorer{cache: c}, decodePluginArgs(rawArgs, &struct{}{})
+}
+
+func (s *leastLatencyScorer) Score(ctx *types.RoutingContext, pods []*v1.Pod) []float64 {
+	expectedLatencies := getExpectedLatencies(s.cache, ctx.Model, pods)
+	maxLatency := 0.0
+	for _, latency := range expectedLatencies {
+		maxLatency = max(maxLatency, latency)
+	}
+	scores := make([]float64, len(pods))
+	for i, pod := range pods {
+		latency, ok := expectedLatencies[pod.Name]
+		if !ok {
+			latency = maxLatency
+		}
+		scores[i] = -latency
+	}
+	return scores
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routingalgorithms
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vllm-project/aibrix/pkg/metrics"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+func TestLoadPipelineConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pipelines.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+pipelines:
+- name: smart
+  filters:
+  - name: kv-cache
+    args:
+      maxUsage: 0.8
+  scorers:
+  - name: prefix-match
+    weight: 2
+  - name: least-request
+- name: smart
+  model: llama
+  scorers:
+  - name: least-latency
+- name: random
+  scorers:
+  - name: least-request
+- name: invalid
+  scorers:
+  - name: unknown
+`), 0644))
+
+	config, err := LoadPipelineConfig(path)
+	require.NoError(t, err)
+	require.Len(t, config.Pipelines, 4)
+	assert.Equal(t, "llama", config.Pipelines[1].Model)
+	assert.Equal(t, 2.0, *config.Pipelines[0].Scorers[0].Weight)
+	assert.Nil(t, config.Pipelines[0].Scorers[1].Weight)
+	assert.JSONEq(t, `{"maxUsage":0.8}`, string(config.Pipelines[0].Filters[0].Args))
+
+	// Invalid pipelines and pipelines named after an existing routing strategy are ignored
+	rm := NewRouterManager()
+	rm.RegisterProvider(RouterRandom, RandomRouterProviderFunc)
+	rm.registerPipelines(config)
+	assert.Contains(t, rm.routerConstructor, types.RoutingAlgorithm("smart"))
+	assert.NotContains(t, rm.routerConstructor, RouterRandom)
+	assert.NotContains(t, rm.routerConstructor, types.RoutingAlgorithm("invalid"))
+
+	_, err = LoadPipelineConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestPipelineRouter(t *testing.T) {
+	pods := newP2CTestPods(3)
+	fakeCache := &fakeMetricCache{
+		podMetrics: map[string]map[string]float64{
+			"p0": {metrics.RealtimeNumRequestsRunning: 6},
+			"p1": {metrics.RealtimeNumRequestsRunning: 4},
+		},
+		podModelMetrics: map[string]map[string]float64{
+			"p0": {metrics.GPUCacheUsagePerc: 0.95},
+			"p1": {metrics.GPUCacheUsagePerc: 0.5},
+			"p2": {metrics.GPUCacheUsagePerc: 0.2},
+		},
+		podLabelMetrics: map[string]map[string]string{
+			"p0": {metrics.RunningLoraAdapters: "adapter"},
+			"p1": {metrics.RunningLoraAdapters: "other,adapter"},
+		},
+	}
+	weight := func(w float64) *float64 { return &w }
+	r, err := newPipelineRouter(fakeCache, "smart", []PipelineSpec{
+		{
+			Name:    "smart",
+			Filters: []PluginSpec{{Name: "kv-cache"}},
+			Scorers: []PluginSpec{{Name: "lora-resident", Weight: weight(2)}, {Name: "least-request"}},
+		},
+		{
+			Name:    "smart",
+			Model:   "base",
+			Filters: []PluginSpec{{Name: "running-requests", Args: []byte(`{"max":1}`)}},
+			Scorers: []PluginSpec{{Name: "least-kv-cache"}},
+		},
+	})
+	require.NoError(t, err)
+
+	route := func(model string) string {
+		ctx := types.NewRoutingContext(context.Background(), "smart", model, "message", "request", "user")
+		defer ctx.Delete()
+		_, err := r.Route(ctx, &utils.PodArray{Pods: pods})
+		require.NoError(t, err)
+		return ctx.TargetPod().Name
+	}
+
+	// p0 is filtered out for its KV cache usage, the adapter residency of p1 outweighs the load of p1
+	assert.Equal(t, "p1", route("adapter"))
+	// Without adapter residency, p2 runs the fewest requests
+	assert.Equal(t, "p2", route("unknown"))
+	// Only p2 runs less than 1 request
+	assert.Equal(t, "p2", route("base"))
+}
+
+func TestPipelineFilterFallback(t *testing.T) {
+	pods := newP2CTestPods(2)
+	fakeCache := &fakeMetricCache{
+		podMetrics: map[string]map[string]float64{
+			"p0": {metrics.RealtimeNumRequestsRunning: 2},
+			"p1": {metrics.RealtimeNumRequestsRunning: 1},
+		},
+	}
+	r, err := newPipelineRouter(fakeCache, "strict", []PipelineSpec{{
+		Name:    "strict",
+		Model:   "m1",
+		Filters: []PluginSpec{{Name: "running-requests", Args: []byte(`{"max":1}`)}},
+		Scorers: []PluginSpec{{Name: "least-request"}},
+	}})
+	require.NoError(t, err)
+
+	// All pods are filtered out, the filter is skipped
+	ctx := types.NewRoutingContext(context.Background(), "strict", "m1", "message", "request", "user")
+	defer ctx.Delete()
+	_, err = r.Route(ctx, &utils.PodArray{Pods: pods})
+	require.NoError(t, err)
+	assert.Equal(t, "p1", ctx.TargetPod().Name)
+
+	// Models without pipeline are routed randomly
+	other := types.NewRoutingContext(context.Background(), "strict", "m2", "message", "request", "user")
+	defer other.Delete()
+	_, err = r.Route(other, &utils.PodArray{Pods: pods})
+	require.NoError(t, err)
+	assert.NotNil(t, other.TargetPod())
+
+	// The request fails when the filter is configured to
+	r, err = newPipelineRouter(fakeCache, "strict", []PipelineSpec{{
+		Name:    "strict",
+		Filters: []PluginSpec{{Name: "running-requests", Args: []byte(`{"max":1}`), OnEmpty: FilterFallbackFail}},
+	}})
+	require.NoError(t, err)
+	failed := types.NewRoutingContext(context.Background(), "strict", "m1", "message", "request", "user")
+	defer failed.Delete()
+	_, err = r.Route(failed, &utils.PodArray{Pods: pods})
+	assert.Error(t, err)
+}
+
+func TestNewPipelineRouterErrors(t *testing.T) {
+	negative := -1.0
+	tests := map[string][]PipelineSpec{
+		"unknown filter":  {{Name: "p", Filters: []PluginSpec{{Name: "unknown"}}}},
+		"unknown scorer":  {{Name: "p", Scorers: []PluginSpec{{Name: "unknown"}}}},
+		"unknown args":    {{Name: "p", Scorers: []PluginSpec{{Name: "least-request", Args: []byte(`{"foo":1}`)}}}},
+		"invalid args":    {{Name: "p", Filters: []PluginSpec{{Name: "kv-cache", Args: []byte(`{"maxUsage":2}`)}}}},
+		"negative weight": {{Name: "p", Scorers: []PluginSpec{{Name: "least-request", Weight: &negative}}}},
+		"unknown onEmpty": {{Name: "p", Filters: []PluginSpec{{Name: "kv-cache", OnEmpty: "retry"}}}},
+		"duplicated":      {{Name: "p", Model: "m"}, {Name: "p", Model: "m"}},
+	}
+	for name, specs := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newPipelineRouter(&fakeMetricCache{}, "p", specs)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNormalizeScores(t *testing.T) {
+	scores := []float64{-4, 0, -2}
+	normalizeScores(scores)
+	assert.Equal(t, []float64{0, 1, 0.5}, scores)
+
+	scores = []float64{3, 3}
+	normalizeScores(scores)
+	assert.Equal(t, []float64{0, 0}, scores)
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"os"
 	"sort"
 	"strconv"
 	"sync"
@@ -45,15 +46,24 @@ const (
 
 	// tokenizerTypeTiktoken is the tiktoken tokenizer type
 	tokenizerTypeTiktoken = "tiktoken"
+	// tokenizerTypeHuggingFace is the tokenizer type loading Hugging Face tokenizer.json files from AIBRIX_PREFIX_CACHE_TOKENIZER_PATH
+	tokenizerTypeHuggingFace = "huggingface"
 )
 
 var (
 	RouterPrefixCache                  types.RoutingAlgorithm = "prefix-cache"
 	tokenizerType                                             = utils.LoadEnv("AIBRIX_PREFIX_CACHE_TOKENIZER_TYPE", "character")
+	tokenizerPath                                             = utils.LoadEnv("AIBRIX_PREFIX_CACHE_TOKENIZER_PATH", "")
 	podRunningRequestImbalanceAbsCount int                    = utils.LoadEnvInt("AIBRIX_PREFIX_CACHE_POD_RUNNING_REQUEST_IMBALANCE_ABS_COUNT", defaultPodRunningRequestImbalanceAbsCount)
 	standardDeviationFactor            int                    = utils.LoadEnvInt("AIBRIX_PREFIX_CACHE_STANDARD_DEVIATION_FACTOR", defaultStandardDeviationFactor)
 )
 
+// Router parameters of prefix-cache, overriding the environment variables for a model, see types.RoutingContext.Parameters.
+const (
+	PrefixCacheParamImbalanceAbsCount       = "imbalance-abs-count"
+	PrefixCacheParamStandardDeviationFactor = "standard-deviation-factor"
+)
+
 // PrefixCacheMetrics holds all prefix cache metrics
 type PrefixCacheMetrics struct {
 	prefixCacheRoutingDecisions *prometheus.CounterVec
@@ -226,12 +236,18 @@ func NewPrefixCacheRouter() (types.Router, error) {
 		return nil, err
 	}
 
-	// Configure TokenizerPool if remote tokenizer is needed
-	if useRemoteTokenizer {
+	localTokenizer, localTokenizerDir, err := newLocalTokenizer()
+	if err != nil {
+		klog.ErrorS(err, "fail to create local tokenizer in prefix cache router", "tokenizer_type", tokenizerType)
+		return nil, err
+	}
+
+	// Configure TokenizerPool if remote tokenizer or per-model local tokenizers are needed
+	if useRemoteTokenizer || localTokenizerDir != "" {
 		// Load pool configuration from environment
 		// Only KV Event Sync constants are defined in pkg/constants
 		poolConfig := TokenizerPoolConfig{
-			EnableVLLMRemote:     true, // We're using it, so enable it
+			EnableVLLMRemote:     useRemoteTokenizer,
 			EndpointTemplate:     utils.LoadEnv("AIBRIX_VLLM_TOKENIZER_ENDPOINT_TEMPLATE", "http://%s:8000"),
 			HealthCheckPeriod:    utils.LoadEnvDuration("AIBRIX_TOKENIZER_HEALTH_CHECK_PERIOD", 30) * time.Second,
 			TokenizerTTL:         utils.LoadEnvDuration("AIBRIX_TOKENIZER_TTL", 300) * time.Second,
@@ -239,16 +255,11 @@ func NewPrefixCacheRouter() (types.Router, error) {
 			DefaultTokenizer:     nil, // Will be set below
 			Timeout:              utils.LoadEnvDuration("AIBRIX_TOKENIZER_REQUEST_TIMEOUT", 5) * time.Second,
 			ModelServiceMap:      make(map[string]string),
+			LocalTokenizerDir:    localTokenizerDir,
 		}
 
-		// Create default tokenizer based on configured type
-		var defaultTokenizer tokenizer.Tokenizer
-		if tokenizerType == tokenizerTypeTiktoken {
-			defaultTokenizer = tokenizer.NewTiktokenTokenizer()
-		} else {
-			defaultTokenizer = tokenizer.NewCharacterTokenizer()
-		}
-		poolConfig.DefaultTokenizer = defaultTokenizer
+		// Use the local tokenizer of the configured type as default
+		poolConfig.DefaultTokenizer = localTokenizer
 
 		// Create the pool
 		pool := NewTokenizerPool(poolConfig, c)
@@ -258,14 +269,10 @@ func NewPrefixCacheRouter() (types.Router, error) {
 		// All tokenization should go through pool in route methods
 		tokenizerObj = &panicTokenizer{}
 
-		klog.Info("TokenizerPool initialized with remote tokenizer support")
+		klog.InfoS("TokenizerPool initialized", "remote_tokenizer", useRemoteTokenizer, "local_tokenizer_dir", localTokenizerDir)
 	} else {
 		// Fallback to local tokenizer (existing behavior when disabled)
-		if tokenizerType == tokenizerTypeTiktoken {
-			tokenizerObj = tokenizer.NewTiktokenTokenizer()
-		} else {
-			tokenizerObj = tokenizer.NewCharacterTokenizer()
-		}
+		tokenizerObj = localTokenizer
 	}
 
 	// Log final configuration
@@ -321,6 +328,28 @@ func NewPrefixCacheRouter() (types.Router, error) {
 	return router, nil
 }
 
+// newLocalTokenizer creates the local tokenizer of the configured type. For the huggingface type, the tokenizer path
+// is either a tokenizer.json file, or a directory of tokenizer files per model which is returned along with the
+// character tokenizer used for models without a tokenizer file.
+func newLocalTokenizer() (tokenizer.Tokenizer, string, error) {
+	switch tokenizerType {
+	case tokenizerTypeTiktoken:
+		return tokenizer.NewTiktokenTokenizer(), "", nil
+	case tokenizerTypeHuggingFace:
+		info, err := os.Stat(tokenizerPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid huggingface tokenizer path %q: %w", tokenizerPath, err)
+		}
+		if info.IsDir() {
+			return tokenizer.NewCharacterTokenizer(), tokenizerPath, nil
+		}
+		tok, err := tokenizer.NewTokenizer(tokenizerTypeHuggingFace, tokenizer.HuggingFaceTokenizerConfig{Path: tokenizerPath})
+		return tok, "", err
+	default:
+		return tokenizer.NewCharacterTokenizer(), "", nil
+	}
+}
+
 // getTokenizerForRequest returns the appropriate tokenizer for the current request.
 // This method encapsulates the conditional logic for choosing between the pool
 // and the local tokenizer, ensuring model-aware tokenization when available.
@@ -373,7 +402,8 @@ func (p prefixCacheRouter) routeOriginal(ctx *types.RoutingContext, readyPodList
 	}
 
 	var isLoadImbalanced bool
-	targetPod, isLoadImbalanced = getTargetPodOnLoadImbalance(p.cache, readyPods)
+	targetPod, isLoadImbalanced = getTargetPodOnLoadImbalance(p.cache, readyPods,
+		ctx.ParamInt(PrefixCacheParamImbalanceAbsCount, podRunningRequestImbalanceAbsCount))
 	if isLoadImbalanced {
 		prefixHashes = p.prefixCacheIndexer.GetPrefixHashes(tokens)
 		if targetPod != nil {
@@ -392,7 +422,8 @@ func (p prefixCacheRouter) routeOriginal(ctx *types.RoutingContext, readyPodList
 		klog.InfoS("prefix_hashes", "request_id", ctx.RequestID, "prefix_hashes", prefixHashes)
 
 		if len(matchedPods) > 0 {
-			targetPod = getTargetPodFromMatchedPods(p.cache, readyPods, matchedPods)
+			targetPod = getTargetPodFromMatchedPods(p.cache, readyPods, matchedPods,
+				ctx.ParamInt(PrefixCacheParamStandardDeviationFactor, standardDeviationFactor))
 			if targetPod != nil {
 				klog.InfoS("prefix_cache_matched_pods",
 					"request_id", ctx.RequestID,
@@ -489,7 +520,8 @@ func (k *kvSyncPrefixCacheRouter) Route(ctx *types.RoutingContext, readyPodList
 
 	// Check for load imbalance first
 	var isLoadImbalanced bool
-	targetPod, isLoadImbalanced = getTargetPodOnLoadImbalance(k.cache, readyPods)
+	targetPod, isLoadImbalanced = getTargetPodOnLoadImbalance(k.cache, readyPods,
+		ctx.ParamInt(PrefixCacheParamImbalanceAbsCount, podRunningRequestImbalanceAbsCount))
 
 	if isLoadImbalanced {
 		// Handle load imbalance case
@@ -530,7 +562,8 @@ func (k *kvSyncPrefixCacheRouter) Route(ctx *types.RoutingContext, readyPodList
 			"ready_pods", readyPodList.Len())
 
 		if len(matchedPods) > 0 {
-			targetPod = getTargetPodFromMatchedPodsWithKeys(k.cache, readyPods, matchedPods)
+			targetPod = getTargetPodFromMatchedPodsWithKeys(k.cache, readyPods, matchedPods,
+				ctx.ParamInt(PrefixCacheParamStandardDeviationFactor, standardDeviationFactor))
 			if targetPod != nil {
 				klog.InfoS("prefix_cache_matched_pods",
 					"request_id", ctx.RequestID,
@@ -588,7 +621,7 @@ func (k *kvSyncPrefixCacheRouter) Route(ctx *types.RoutingContext, readyPodList
 }
 
 // getTargetPodFromMatchedPodsWithKeys is similar to getTargetPodFromMatchedPods but uses pod keys
-func getTargetPodFromMatchedPodsWithKeys(cache cache.Cache, readyPods []*v1.Pod, matchedPods map[string]int) *v1.Pod {
+func getTargetPodFromMatchedPodsWithKeys(cache cache.Cache, readyPods []*v1.Pod, matchedPods map[string]int, stdDevFactor int) *v1.Pod {
 	var targetPodKey string
 	requestCount := []float64{}
 
@@ -625,7 +658,7 @@ func getTargetPodFromMatchedPodsWithKeys(cache cache.Cache, readyPods []*v1.Pod,
 	// select targetpod with highest %prefixmatch and request_count within stddev
 	for _, podkey := range podkeys {
 		reqCnt := float64(podRequestCount[podkey])
-		if reqCnt <= meanRequestCount+float64(standardDeviationFactor)*stdDevRequestCount {
+		if reqCnt <= meanRequestCount+float64(stdDevFactor)*stdDevRequestCount {
 			targetPodKey = podkey
 			break
 		}
@@ -634,7 +667,7 @@ func getTargetPodFromMatchedPodsWithKeys(cache cache.Cache, readyPods []*v1.Pod,
 	return podKeyToPod[targetPodKey]
 }
 
-func getTargetPodFromMatchedPods(cache cache.Cache, readyPods []*v1.Pod, matchedPods map[string]int) *v1.Pod {
+func getTargetPodFromMatchedPods(cache cache.Cache, readyPods []*v1.Pod, matchedPods map[string]int, stdDevFactor int) *v1.Pod {
 	var targetPodName string
 	requestCount := []float64{}
 
@@ -664,7 +697,7 @@ func getTargetPodFromMatchedPods(cache cache.Cache, readyPods []*v1.Pod, matched
 	// select targetpod with highest %prefixmatch and request_count within stddev
 	for _, podname := range podnames {
 		reqCnt := float64(podRequestCount[podname])
-		if reqCnt <= meanRequestCount+float64(standardDeviationFactor)*stdDevRequestCount {
+		if reqCnt <= meanRequestCount+float64(stdDevFactor)*stdDevRequestCount {
 			targetPodName = podname
 			break
 		}
@@ -674,8 +707,8 @@ func getTargetPodFromMatchedPods(cache cache.Cache, readyPods []*v1.Pod, matched
 }
 
 // getTargetPodOnLoadImbalance evaluates if the load is imbalanced based on the abs difference between
-// pods with min and max outstanding request counts
-func getTargetPodOnLoadImbalance(cache cache.Cache, readyPods []*v1.Pod) (*v1.Pod, bool) {
+// pods with min and max outstanding request counts, compared to imbalanceAbsCount
+func getTargetPodOnLoadImbalance(cache cache.Cache, readyPods []*v1.Pod, imbalanceAbsCount int) (*v1.Pod, bool) {
 	var imbalance bool
 	var targetPod *v1.Pod
 	targetPods := []string{}
@@ -704,7 +737,7 @@ func getTargetPodOnLoadImbalance(cache cache.Cache, readyPods []*v1.Pod) (*v1.Po
 		}
 	}
 
-	if maxValue-minValue > podRunningRequestImbalanceAbsCount && len(targetPods) > 0 {
+	if maxValue-minValue > imbalanceAbsCount && len(targetPods) > 0 {
 		targetPod, _ = utils.FilterPodByName(targetPods[rand.Intn(len(targetPods))], readyPods)
 		imbalance = true
 	}
@@ -227,7 +227,7 @@ func Test_ValidatePrePrefixMatchLoadBalance(t *testing.T) {
 			"p3": {metrics.RealtimeNumRequestsRunning: &metrics.SimpleMetricValue{Value: 3}},
 			"p4": {metrics.RealtimeNumRequestsRunning: &metrics.SimpleMetricValue{Value: 9}},
 		})
-	targetPod, imbalance := getTargetPodOnLoadImbalance(c, readyPods)
+	targetPod, imbalance := getTargetPodOnLoadImbalance(c, readyPods, podRunningRequestImbalanceAbsCount)
 	assert.False(t, imbalance, "pod running request count is less than equal to default abs value of 8")
 	assert.Nil(t, targetPod)
 
@@ -241,9 +241,14 @@ func Test_ValidatePrePrefixMatchLoadBalance(t *testing.T) {
 			"p3": {metrics.RealtimeNumRequestsRunning: &metrics.SimpleMetricValue{Value: 8}},
 			"p4": {metrics.RealtimeNumRequestsRunning: &metrics.SimpleMetricValue{Value: 16}},
 		})
-	targetPod, imbalance = getTargetPodOnLoadImbalance(c, readyPods)
+	targetPod, imbalance = getTargetPodOnLoadImbalance(c, readyPods, podRunningRequestImbalanceAbsCount)
 	assert.True(t, imbalance, "pod running request count is more than default abs value of 8")
 	assert.True(t, slices.Contains([]string{"p1", "p2"}, targetPod.Name))
+
+	// the threshold is raised by a router parameter
+	targetPod, imbalance = getTargetPodOnLoadImbalance(c, readyPods, 16)
+	assert.False(t, imbalance, "pod running request count is less than equal to abs value of 16")
+	assert.Nil(t, targetPod)
 }
 
 func Test_ValidatePostPrefixMatchLoadBalance(t *testing.T) {
@@ -345,7 +350,7 @@ func Test_ValidatePostPrefixMatchLoadBalance(t *testing.T) {
 		},
 	}
 	for _, test := range testcases {
-		targetPod := getTargetPodFromMatchedPods(test.c, readyPods, test.matchedPods)
+		targetPod := getTargetPodFromMatchedPods(test.c, readyPods, test.matchedPods, standardDeviationFactor)
 		if len(test.targetPods) == 0 {
 			assert.Nil(t, targetPod, test.name)
 		} else {
@@ -353,3 +358,32 @@ func Test_ValidatePostPrefixMatchLoadBalance(t *testing.T) {
 		}
 	}
 }
+
+func Test_NewLocalTokenizer(t *testing.T) {
+	defer func(typ, path string) { tokenizerType, tokenizerPath = typ, path }(tokenizerType, tokenizerPath)
+	fixture := "../../../utils/tokenizer/testdata/sentencepiece_bpe.json"
+
+	tokenizerType, tokenizerPath = tokenizerTypeHuggingFace, fixture
+	tok, dir, err := newLocalTokenizer()
+	assert.NoError(t, err)
+	assert.Empty(t, dir)
+	tokens, err := tok.TokenizeInputText("hello world")
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 3*4)
+
+	// A directory of tokenizers per model, with the character tokenizer as default
+	tokenizerPath = t.TempDir()
+	tok, dir, err = newLocalTokenizer()
+	assert.NoError(t, err)
+	assert.Equal(t, tokenizerPath, dir)
+	assert.Equal(t, tokenizer.NewCharacterTokenizer(), tok)
+
+	tokenizerPath = ""
+	_, _, err = newLocalTokenizer()
+	assert.Error(t, err)
+
+	tokenizerType = tokenizerTypeTiktoken
+	tok, _, err = newLocalTokenizer()
+	assert.NoError(t, err)
+	assert.Equal(t, tokenizer.NewTiktokenTokenizer(), tok)
+}
@@ -17,11 +17,13 @@ limitations under the License.
 package routingalgorithms
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/vllm-project/aibrix/pkg/cache"
 	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
 	"k8s.io/klog/v2"
 )
 
@@ -51,6 +53,8 @@ type queueRouter struct {
 	chRouteTrigger chan types.PodList
 }
 
+var _ types.QueueRouter = &queueRouter{}
+
 func NewQueueRouter(backend types.Router, queue types.RouterQueue[*types.RoutingContext]) (types.QueueRouter, error) {
 	c, err := cache.Get()
 	if err != nil {
@@ -88,6 +92,13 @@ func (r *queueRouter) Route(ctx *types.RoutingContext, pods types.PodList) (stri
 
 	r.tryRoute(pods) // Simply trigger a possible dequeue
 
+	// Trigger a dequeue on the deadline so that the expired request can be dropped, even if no more request arrives.
+	// Pods are listed again then, as the pods of the request may have changed.
+	if remaining, ok := ctx.Remaining(time.Now()); ok {
+		timer := time.AfterFunc(remaining, func() { r.tryRouteModel(ctx.Model) })
+		defer timer.Stop()
+	}
+
 	targetPod := ctx.TargetPod() // Will wait
 	if targetPod != nil {
 		klog.V(4).Infof("targetPod for routing: %s(%s)", targetPod.Name, targetPod.Status.PodIP)
@@ -100,6 +111,14 @@ func (r *queueRouter) Len() int {
 	return r.queue.Len()
 }
 
+// UserLen returns the number of queued requests of the user, zero if the queue does not keep requests by user.
+func (r *queueRouter) UserLen(user string) int {
+	if q, ok := r.queue.(types.QueueRouter); ok {
+		return q.UserLen(user)
+	}
+	return 0
+}
+
 func (r *queueRouter) tryRoute(pods types.PodList) {
 	select {
 	case r.chRouteTrigger <- pods:
@@ -108,39 +127,99 @@ func (r *queueRouter) tryRoute(pods types.PodList) {
 	}
 }
 
+// tryRouteModel triggers a possible dequeue with the current routable pods of the model.
+func (r *queueRouter) tryRouteModel(model string) {
+	pods, err := r.cache.ListPodsByModel(model)
+	if err != nil {
+		klog.Errorf("failed to list pods of model %s for queued requests: %v", model, err)
+		return
+	}
+	r.tryRoute(&utils.PodArray{Pods: utils.FilterRoutablePods(pods.All())})
+}
+
 func (r *queueRouter) serve() {
 	for {
-		pods := <-r.chRouteTrigger
-
-		for {
-			ctx, err := r.queue.Peek(time.Now(), pods)
-			if err != nil && err != types.ErrQueueEmpty {
-				klog.Errorf("error on peek request queue: %v", err)
-				break
-			} else if ctx == nil {
-				// Nothing to route, this happens if the queue is not empty, but no pod is available to be routed.
-				// A pod can be unavailable if:
-				// 1. The pod is not ready.
-				// 2. The pod has reached its max capacity.
-				break
-			}
-
-			_, err = r.router.Route(ctx, pods)
-			if err != nil {
-				// Necessary if Router has not set the error. No harm to set twice.
-				ctx.SetError(err)
-			} else {
-				// Add request count here to make real-time metrics update and read serial.
-				// Noted, AddRequestCount should implement the idempotence.
-				r.cache.AddRequestCount(ctx, ctx.RequestID, ctx.Model)
-			}
-			// req.SetTargetPod() should have called in Route()
-			dequeued, err := r.queue.Dequeue(time.Now())
-			if err != nil {
-				klog.Errorf("error on dequeue request queue: %v", err)
-			} else if dequeued != ctx {
-				klog.Error("unexpected request dequeued")
-			}
+		r.routeQueued(<-r.chRouteTrigger, time.Now)
+	}
+}
+
+// routeQueued routes the queued requests in the time of the clock until the queue is empty, or no pod is available
+// for the next request.
+func (r *queueRouter) routeQueued(pods types.PodList, clock func() time.Time) {
+	for {
+		now := clock()
+		ctx, err := r.queue.Peek(now, pods)
+		if err != nil && err != types.ErrQueueEmpty {
+			klog.Errorf("error on peek request queue: %v", err)
+			break
+		} else if ctx == nil {
+			// Nothing to route, this happens if the queue is not empty, but no pod is available to be routed.
+			// A pod can be unavailable if:
+			// 1. The pod is not ready.
+			// 2. The pod has reached its max capacity.
+			break
+		}
+
+		if !ctx.HasRouted() && ctx.Expired(now) {
+			// Drop the expired request without dispatching.
+			klog.V(4).Infof("request %s expired in queue, dropped", ctx.RequestID)
+			ctx.SetError(context.DeadlineExceeded)
+		} else if _, err = r.router.Route(ctx, pods); err != nil {
+			// Necessary if Router has not set the error. No harm to set twice.
+			ctx.SetError(err)
+		} else {
+			// Add request count here to make real-time metrics update and read serial.
+			// Noted, AddRequestCount should implement the idempotence.
+			r.cache.AddRequestCount(ctx, ctx.RequestID, ctx.Model)
+		}
+		// req.SetTargetPod() should have called in Route()
+		dequeued, err := r.queue.Dequeue(clock())
+		if err != nil {
+			klog.Errorf("error on dequeue request queue: %v", err)
+		} else if dequeued != ctx {
+			klog.Error("unexpected request dequeued")
 		}
 	}
 }
+
+// ManualQueueRouter is a queue router without serving goroutine, routing the queued requests in the time of a clock
+// when asked by the caller, e.g. to replay a trace in simulated time. Route enqueues the request and returns
+// immediately, the request is routed by a following RouteQueued call, see RoutingContext.HasRouted and
+// RoutingContext.HasError. ManualQueueRouter is not safe for concurrent use.
+type ManualQueueRouter struct {
+	*queueRouter
+	now func() time.Time // Clock of the queue.
+}
+
+var _ types.QueueRouter = &ManualQueueRouter{}
+
+// NewManualQueueRouter creates a ManualQueueRouter of the backend Router and the queue in the time of the clock.
+func NewManualQueueRouter(backend types.Router, queue types.RouterQueue[*types.RoutingContext], now func() time.Time) (*ManualQueueRouter, error) {
+	c, err := cache.Get()
+	if err != nil {
+		return nil, err
+	}
+	return &ManualQueueRouter{
+		queueRouter: &queueRouter{
+			router: backend,
+			queue:  queue,
+			cache:  c,
+		},
+		now: now,
+	}, nil
+}
+
+// Route enqueues the request without routing it.
+func (r *ManualQueueRouter) Route(ctx *types.RoutingContext, _ types.PodList) (string, error) {
+	if ctx == nil {
+		return "", fmt.Errorf("no request to enqueue")
+	}
+
+	r.cache.AddRequestCount(ctx, ctx.RequestID, ctx.Model)
+	return "", r.queue.Enqueue(ctx, r.now())
+}
+
+// RouteQueued routes the queued requests to the pods until the queue is empty, or no pod is available for the next request.
+func (r *ManualQueueRouter) RouteQueued(pods types.PodList) {
+	r.routeQueued(pods, r.now)
+}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
 	"k8s.io/klog/v2"
 )
 
@@ -148,5 +149,12 @@ func (rm *RouterManager) Init() {
 	rm.routerDoneInit()
 }
 func Init() {
+	if path := utils.LoadEnv(EnvRoutingPipelineConfig, ""); path != "" {
+		if config, err := LoadPipelineConfig(path); err != nil {
+			klog.ErrorS(err, "failed to load routing pipelines", "path", path)
+		} else {
+			defaultRM.registerPipelines(config)
+		}
+	}
 	defaultRM.Init()
 }
@@ -0,0 +1,209 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routingalgorithms
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/cache"
+	"github.com/vllm-project/aibrix/pkg/metrics"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+const (
+	RouterSessionAffinity types.RoutingAlgorithm = "session-affinity"
+
+	defaultSessionAffinityHeader       = "x-session-id"
+	defaultSessionAffinityLoadFactor   = 1.25
+	defaultSessionAffinityVirtualNodes = 100
+)
+
+var (
+	sessionAffinityHeader       = utils.LoadEnv("AIBRIX_SESSION_AFFINITY_HEADER", defaultSessionAffinityHeader)
+	sessionAffinityLoadFactor   = utils.LoadEnvFloat("AIBRIX_SESSION_AFFINITY_LOAD_FACTOR", defaultSessionAffinityLoadFactor)
+	sessionAffinityVirtualNodes = utils.LoadEnvInt("AIBRIX_SESSION_AFFINITY_VIRTUAL_NODES", defaultSessionAffinityVirtualNodes)
+)
+
+func init() {
+	Register(RouterSessionAffinity, NewSessionAffinityRouter)
+}
+
+// sessionAffinityRouter routes the requests of a session to the same pod by consistent hashing with bounded load:
+// a pod is skipped for the next one on the ring if it would run more than loadFactor times the average load.
+type sessionAffinityRouter struct {
+	cache        cache.Cache
+	header       string
+	loadFactor   float64
+	virtualNodes int
+
+	rings utils.SyncMap[string, *hashRing] // Ring of each model, rebuilt when the pod set of the model changes.
+}
+
+// hashRing is the consistent hash ring of a pod set.
+type hashRing struct {
+	podKeys []string   // Keys of the pods on the ring, sorted.
+	nodes   []ringNode // Virtual nodes of the pods, sorted by hash.
+}
+
+// ringNode is a virtual node of a pod on the hash ring.
+type ringNode struct {
+	hash uint64
+	pod  int // Index of the pod in podKeys.
+}
+
+func NewSessionAffinityRouter() (types.Router, error) {
+	c, err := cache.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	loadFactor := sessionAffinityLoadFactor
+	if loadFactor < 1 {
+		klog.Warningf("invalid session affinity load factor %v, using default %v", loadFactor, defaultSessionAffinityLoadFactor)
+		loadFactor = defaultSessionAffinityLoadFactor
+	}
+	return &sessionAffinityRouter{
+		cache:        c,
+		header:       strings.ToLower(sessionAffinityHeader),
+		loadFactor:   loadFactor,
+		virtualNodes: max(sessionAffinityVirtualNodes, 1),
+	}, nil
+}
+
+// Route routes the request to the pod of its session, requests without session go to the pod with the least requests.
+func (r *sessionAffinityRouter) Route(ctx *types.RoutingContext, readyPodList types.PodList) (string, error) {
+	readyPods := readyPodList.All()
+
+	var targetPod *v1.Pod
+	if key := r.sessionKey(ctx); key != "" {
+		targetPod = r.selectSessionPod(ctx.Model, key, readyPods, getRequestCounts(r.cache, readyPods))
+	} else {
+		klog.V(4).InfoS("no session key found, routing to the pod with the least requests", "requestID", ctx.RequestID, "header", r.header)
+		targetPod = selectTargetPodWithLeastRequestCount(r.cache, readyPods)
+	}
+
+	// Use fallback if no valid metrics
+	if targetPod == nil {
+		var err error
+		targetPod, err = SelectRandomPodAsFallback(ctx, readyPods, rand.Intn)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	ctx.SetTargetPod(targetPod)
+	return ctx.TargetAddress(), nil
+}
+
+func (r *sessionAffinityRouter) SubscribedMetrics() []string {
+	return []string{
+		metrics.RealtimeNumRequestsRunning,
+	}
+}
+
+// sessionKey returns the session header of the request, or the user field of the request body.
+func (r *sessionAffinityRouter) sessionKey(ctx *types.RoutingContext) string {
+	for key, value := range ctx.ReqHeaders {
+		if strings.ToLower(key) == r.header && value != "" {
+			return value
+		}
+	}
+	if len(ctx.ReqBody) == 0 {
+		return ""
+	}
+	var body struct {
+		User string `json:"user"`
+	}
+	if err := json.Unmarshal(ctx.ReqBody, &body); err != nil {
+		klog.V(4).InfoS("failed to read user from request body", "requestID", ctx.RequestID, "error", err)
+		return ""
+	}
+	return body.User
+}
+
+// selectSessionPod walks the hash ring from the session key and returns the first pod below the load bound,
+// ceil(loadFactor * average load including the request).
+func (r *sessionAffinityRouter) selectSessionPod(model string, key string, readyPods []*v1.Pod, requestCounts map[string]int) *v1.Pod {
+	if len(readyPods) == 0 {
+		return nil
+	}
+
+	// Pods in the order of the ring keys, as ready pods are not listed in a stable order.
+	pods := make(map[string]*v1.Pod, len(readyPods))
+	podKeys := make([]string, 0, len(readyPods))
+	for _, pod := range readyPods {
+		podKey := utils.GeneratePodKey(pod.Namespace, pod.Name)
+		if _, ok := pods[podKey]; !ok {
+			podKeys = append(podKeys, podKey)
+		}
+		pods[podKey] = pod
+	}
+	sort.Strings(podKeys)
+	ring := r.hashRing(model, podKeys)
+
+	totalRequests := 0
+	for _, pod := range pods {
+		totalRequests += requestCounts[pod.Name]
+	}
+	capacity := int(math.Ceil(r.loadFactor * float64(totalRequests+1) / float64(len(pods))))
+
+	hash := xxhash.Sum64String(key)
+	start := sort.Search(len(ring.nodes), func(i int) bool { return ring.nodes[i].hash >= hash })
+	visited := make([]bool, len(ring.podKeys))
+	for i := 0; i < len(ring.nodes); i++ {
+		node := ring.nodes[(start+i)%len(ring.nodes)]
+		if visited[node.pod] {
+			continue
+		}
+		visited[node.pod] = true
+
+		pod := pods[ring.podKeys[node.pod]]
+		if requestCounts[pod.Name] < capacity {
+			return pod
+		}
+		klog.V(4).InfoS("session pod is overloaded, trying the next pod", "pod", pod.Name, "requests", requestCounts[pod.Name], "capacity", capacity)
+	}
+	return nil
+}
+
+// hashRing returns the hash ring of the sorted pod keys of a model, the ring is only rebuilt when the pod set of the model changes.
+func (r *sessionAffinityRouter) hashRing(model string, podKeys []string) *hashRing {
+	if ring, ok := r.rings.Load(model); ok && slices.Equal(ring.podKeys, podKeys) {
+		return ring
+	}
+
+	ring := &hashRing{podKeys: podKeys, nodes: make([]ringNode, 0, len(podKeys)*r.virtualNodes)}
+	for i, podKey := range podKeys {
+		for v := 0; v < r.virtualNodes; v++ {
+			ring.nodes = append(ring.nodes, ringNode{hash: xxhash.Sum64String(podKey + "#" + strconv.Itoa(v)), pod: i})
+		}
+	}
+	sort.Slice(ring.nodes, func(i, j int) bool { return ring.nodes[i].hash < ring.nodes[j].hash })
+	r.rings.Store(model, ring)
+	return ring
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routingalgorithms
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vllm-project/aibrix/pkg/metrics"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+func newSessionAffinityTestPods(n int) []*v1.Pod {
+	pods := make([]*v1.Pod, n)
+	for i := range pods {
+		pods[i] = &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("p%d", i), Namespace: "default"},
+			Status:     v1.PodStatus{PodIP: fmt.Sprintf("10.0.0.%d", i)},
+		}
+	}
+	return pods
+}
+
+func TestSessionAffinityRouter_Sticky(t *testing.T) {
+	r := sessionAffinityRouter{cache: &fakeMetricCache{}, header: "x-session-id", loadFactor: 1.25, virtualNodes: 100}
+	pods := newSessionAffinityTestPods(4)
+
+	sessions := map[string]*v1.Pod{}
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("session-%d", i)
+		pod := r.selectSessionPod("model", key, pods, nil)
+		assert.Equal(t, pod, r.selectSessionPod("model", key, pods, nil), "session %s is not sticky", key)
+		sessions[key] = pod
+	}
+
+	// Removing a pod only moves the sessions of the removed pod
+	remaining := pods[1:]
+	for key, pod := range sessions {
+		if pod != pods[0] {
+			assert.Equal(t, pod, r.selectSessionPod("model", key, remaining, nil), "session %s moved", key)
+		}
+	}
+}
+
+func TestSessionAffinityRouter_RingCache(t *testing.T) {
+	r := sessionAffinityRouter{cache: &fakeMetricCache{}, header: "x-session-id", loadFactor: 1.25, virtualNodes: 100}
+	pods := newSessionAffinityTestPods(3)
+	ringOf := func(model string) *hashRing {
+		ring, _ := r.rings.Load(model)
+		return ring
+	}
+
+	pod := r.selectSessionPod("model", "session", pods, nil)
+	ring := ringOf("model")
+	assert.Len(t, ring.nodes, 300)
+
+	// The ring is reused for the same pod set in any order
+	reversed := []*v1.Pod{pods[2], pods[1], pods[0]}
+	assert.Equal(t, pod, r.selectSessionPod("model", "session", reversed, nil))
+	assert.Same(t, ring, ringOf("model"))
+
+	// and kept while other models with other pod sets are routed
+	r.selectSessionPod("other-model", "session", pods[:1], nil)
+	assert.Same(t, ring, ringOf("model"))
+	assert.Len(t, ringOf("other-model").nodes, 100)
+	r.selectSessionPod("model", "session", pods, nil)
+	assert.Same(t, ring, ringOf("model"))
+
+	// and rebuilt when the pod set of the model changes
+	r.selectSessionPod("model", "session", pods[:2], nil)
+	assert.NotSame(t, ring, ringOf("model"))
+	assert.Len(t, ringOf("model").nodes, 200)
+}
+
+func TestSessionAffinityRouter_BoundedLoad(t *testing.T) {
+	r := sessionAffinityRouter{cache: &fakeMetricCache{}, header: "x-session-id", loadFactor: 1.25, virtualNodes: 100}
+	pods := newSessionAffinityTestPods(3)
+
+	sticky := r.selectSessionPod("model", "session", pods, nil)
+	// 12 running requests in total, the load bound is ceil(1.25 * 13 / 3) = 6
+	counts := map[string]int{}
+	for _, pod := range pods {
+		counts[pod.Name] = 3
+	}
+	counts[sticky.Name] = 6
+	pod := r.selectSessionPod("model", "session", pods, counts)
+	assert.NotEqual(t, sticky, pod)
+
+	// 10 running requests in total, the load bound is ceil(1.25 * 11 / 3) = 5
+	counts[sticky.Name] = 4
+	assert.Equal(t, sticky, r.selectSessionPod("model", "session", pods, counts))
+}
+
+func TestSessionAffinityRouter_Route(t *testing.T) {
+	pods := newSessionAffinityTestPods(3)
+	r := sessionAffinityRouter{
+		cache: &fakeMetricCache{podMetrics: map[string]map[string]float64{
+			"p0": {metrics.RealtimeNumRequestsRunning: 2},
+			"p1": {metrics.RealtimeNumRequestsRunning: 0},
+			"p2": {metrics.RealtimeNumRequestsRunning: 2},
+		}},
+		header:       "x-session-id",
+		loadFactor:   2,
+		virtualNodes: 100,
+	}
+	route := func(headers map[string]string, body string) string {
+		ctx := types.NewRoutingContext(context.Background(), RouterSessionAffinity, "m1", "message", "request", "")
+		defer ctx.Delete()
+		ctx.ReqHeaders = headers
+		ctx.ReqBody = []byte(body)
+		_, err := r.Route(ctx, &utils.PodArray{Pods: pods})
+		assert.NoError(t, err)
+		return ctx.TargetPod().Name
+	}
+
+	expected := r.selectSessionPod("model", "session-a", pods, nil).Name
+	assert.Equal(t, expected, route(map[string]string{"X-Session-Id": "session-a"}, ""))
+	assert.Equal(t, expected, route(nil, `{"model":"m1","user":"session-a"}`))
+	// The header takes precedence over the user field
+	assert.Equal(t, expected, route(map[string]string{"x-session-id": "session-a"}, `{"user":"session-b"}`))
+
+	// Requests without session key go to the pod with the least requests
+	assert.Equal(t, "p1", route(nil, `{"model":"m1"}`))
+	assert.Equal(t, "p1", route(nil, "invalid json"))
+}
@@ -16,9 +16,13 @@ limitations under the License.
 package routingalgorithms
 
 import (
+	"time"
+
 	"github.com/vllm-project/aibrix/pkg/cache"
 	"github.com/vllm-project/aibrix/pkg/plugins/gateway/queue"
 	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+	"k8s.io/klog/v2"
 )
 
 const (
@@ -44,8 +48,24 @@ var (
 
 		return c.GetRouter(ctx)
 	}
+
+	// Priority classes of queued requests in the format of "name=priority,...", empty to disable priority queueing.
+	priorityClasses       = utils.LoadEnv("AIBRIX_PRIORITY_CLASSES", "")
+	priorityDefaultClass  = utils.LoadEnv("AIBRIX_PRIORITY_DEFAULT_CLASS", "")
+	priorityAgingInterval = utils.LoadEnvDuration("AIBRIX_PRIORITY_AGING_INTERVAL", defaultPriorityAgingInterval)
+	// Priority classes parsed once, invalid classes fail the creation of the slo routers.
+	parsedPriorityClasses, priorityClassesErr = parsePriorityClasses(priorityClasses)
+
+	// Dequeue requests of similar features in the order of their SLO deadlines instead of arrival order.
+	sloQueueEDF = utils.LoadEnvBool("AIBRIX_SLO_QUEUE_EDF", false)
+	// Action taken on queued requests that can no longer meet their SLO in EDF mode: none, shed or demote.
+	sloQueueMissPolicy = utils.LoadEnv("AIBRIX_SLO_QUEUE_MISS_POLICY", string(queue.SLOMissPolicyNone))
+	// Queue requests per user and dequeue users by deficit round robin weighted by their user records.
+	userFairQueue = utils.LoadEnvBool("AIBRIX_USER_FAIR_QUEUE", false)
 )
 
+const defaultPriorityAgingInterval = 10 * time.Second
+
 func init() {
 	RegisterProvider(RouterSLO, routerSLOProvider)
 	RegisterProvider(RouterSLOPackLoad, routerSLOProvider)
@@ -53,21 +73,47 @@ func init() {
 	RegisterProvider(RouterSLOLeastLoadPulling, routerSLOProvider)
 }
 
+// SLORouterQueue is the queue of SLORouter, either a SLOQueue or a PriorityQueue of SLOQueues.
+type SLORouterQueue interface {
+	types.RouterQueue[*types.RoutingContext]
+	types.Router
+	LastError() error
+}
+
 // SLORouter is a router that add FallbackRouter mechanism to the queue.
 type SLORouter struct {
 	FallbackRouter
-	*queue.SLOQueue
+	SLORouterQueue
 }
 
 func (r *SLORouter) Route(ctx *types.RoutingContext, pods types.PodList) (string, error) {
 	// Ctx is not routed if no profiles is found during Peek.
-	if !ctx.HasRouted() && r.SLOQueue.LastError() == nil {
+	if !ctx.HasRouted() && r.SLORouterQueue.LastError() == nil {
 		return r.FallbackRouter.Route(ctx, pods)
 	}
-	return r.SLOQueue.Route(ctx, pods)
+	return r.SLORouterQueue.Route(ctx, pods)
 }
 
 func NewSLORouter(modelName string) (types.QueueRouter, error) {
+	router, err := newSLORouter(modelName)
+	if err != nil {
+		return nil, err
+	}
+	return NewQueueRouter(router, router.SLORouterQueue)
+}
+
+// NewManualSLORouter creates the slo router of the model as a ManualQueueRouter in the time of the clock, e.g. to
+// replay a trace in simulated time.
+func NewManualSLORouter(modelName string, now func() time.Time) (*ManualQueueRouter, error) {
+	router, err := newSLORouter(modelName)
+	if err != nil {
+		return nil, err
+	}
+	return NewManualQueueRouter(router, router.SLORouterQueue, now)
+}
+
+// newSLORouter creates the SLORouter of the model, with least-request as fallback.
+func newSLORouter(modelName string) (*SLORouter, error) {
 	loadProvider, err := cache.NewPendingLoadProvider()
 	if err != nil {
 		return nil, err
@@ -82,13 +128,63 @@ func NewSLORouter(modelName string) (types.QueueRouter, error) {
 	rm.Register(RouterSLOLeastLoad, func() (types.Router, error) { return NewLeastLoadRouter(loadProvider) })
 	rm.Init()
 
-	sloQueue, err := queue.NewSLOQueue(rm.Select, modelName)
+	sloQueue, err := newSLORouterQueue(rm.Select, modelName)
 	if err != nil {
 		return nil, err
 	}
-	router := &SLORouter{SLOQueue: sloQueue}
+	router := &SLORouter{SLORouterQueue: sloQueue}
 	if err := SetFallback(router, RouterLeastRequest); err != nil {
 		return nil, err
 	}
-	return NewQueueRouter(router, sloQueue)
+	return router, nil
+}
+
+// parsePriorityClasses parses AIBRIX_PRIORITY_CLASSES, the error is logged once here.
+func parsePriorityClasses(spec string) ([]queue.PriorityClass, error) {
+	classes, err := queue.ParsePriorityClasses(spec)
+	if err != nil {
+		klog.Errorf("invalid AIBRIX_PRIORITY_CLASSES %q, the slo routers can not be created and priorities are ignored: %v", spec, err)
+	}
+	return classes, err
+}
+
+// CapPriorityClass caps the priority class requested by header at AIBRIX_PRIORITY_DEFAULT_CLASS, see
+// queue.CapPriorityClass. The class is dropped if AIBRIX_PRIORITY_CLASSES is invalid.
+func CapPriorityClass(class string) string {
+	if priorityClassesErr != nil {
+		return ""
+	}
+	return queue.CapPriorityClass(parsedPriorityClasses, priorityDefaultClass, class)
+}
+
+// newSLORouterQueue creates a SLOQueue, or a FairQueue of SLOQueues by user if fair queuing is enabled. If priority
+// classes are configured, a PriorityQueue with such a queue per class is created.
+func newSLORouterQueue(provider types.RouterProviderFunc, modelName string) (SLORouterQueue, error) {
+	if priorityClassesErr != nil {
+		return nil, priorityClassesErr
+	}
+	classes := parsedPriorityClasses
+	sloQueueOptions := queue.SLOQueueOptions{
+		EDF:        sloQueueEDF,
+		MissPolicy: queue.SLOMissPolicy(sloQueueMissPolicy),
+	}
+	newQueue := func() (SLORouterQueue, error) {
+		if !userFairQueue {
+			return queue.NewSLOQueueWithOptions(provider, modelName, sloQueueOptions)
+		}
+		return queue.NewFairQueue(modelName, func() (types.RouterQueue[*types.RoutingContext], error) {
+			return queue.NewSLOQueueWithOptions(provider, modelName, sloQueueOptions)
+		})
+	}
+	if len(classes) == 0 {
+		return newQueue()
+	}
+
+	return queue.NewPriorityQueue(modelName, queue.PriorityQueueOptions{
+		Classes:       classes,
+		DefaultClass:  priorityDefaultClass,
+		AgingInterval: priorityAgingInterval,
+	}, func() (types.RouterQueue[*types.RoutingContext], error) {
+		return newQueue()
+	})
 }
@@ -288,6 +288,50 @@ var _ = Describe("SLO", func() {
 		Expect(lastReq.TargetPod()).To(BeIdenticalTo(firstReq.TargetPod()))
 	})
 
+	It("Queue router should drop expired requests", func() {
+		pods, _ := store.ListPodsByModel(model)
+
+		makeOneRequest := func(id int, deadline time.Duration) (*types.RoutingContext, error) {
+			req, cancel := newReqWithTimeout(model, "message", fmt.Sprintf("request_id_%d", id), time.Second)
+			req.Algorithm = RouterSLOPackLoad // Override routing algorithm
+			if deadline > 0 {
+				req.SetTimeout(deadline)
+			}
+			defer cancel()
+			_, err := route(store, req, pods)
+			return req, err
+		}
+
+		pendingLoadOf := func(req *types.RoutingContext) float64 {
+			time.Sleep(1 * time.Millisecond) // There is a small gap between route and metric update due to operated by different goroutines.
+			pendingLoad, err := store.GetMetricValueByPod(req.TargetPod().Name, req.TargetPod().Namespace, metrics.RealtimeNormalizedPendings)
+			Expect(err).To(BeNil())
+			return pendingLoad.GetSimpleValue()
+		}
+
+		// Fill pods just enough.
+		firstReq, err := makeOneRequest(1, 0)
+		Expect(err).To(BeNil())
+		filledPods := []*v1.Pod{firstReq.TargetPod()}
+		unitPendingLoad := pendingLoadOf(firstReq)
+		lastPendingLoad := unitPendingLoad
+		for id := 2; len(filledPods) < pods.Len() || lastPendingLoad+unitPendingLoad < 1.0; id++ {
+			req, err := makeOneRequest(id, 0)
+			Expect(err).To(BeNil())
+			if req.TargetPod() != filledPods[len(filledPods)-1] {
+				filledPods = append(filledPods, req.TargetPod())
+			}
+			lastPendingLoad = pendingLoadOf(req)
+		}
+
+		// The request can not be routed before its deadline.
+		start := time.Now()
+		req, err := makeOneRequest(0, 20*time.Millisecond)
+		Expect(err).To(BeIdenticalTo(context.DeadlineExceeded))
+		Expect(req.HasRouted()).To(BeFalse())
+		Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+	})
+
 	It("Should cache.RequestTrace counts one and one only", func() {
 		store = cache.InitWithRequestTrace(store)
 		pods, _ := store.ListPodsByModel(model)
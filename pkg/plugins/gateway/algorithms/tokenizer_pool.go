@@ -19,6 +19,8 @@ package routingalgorithms
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -45,6 +47,7 @@ type TokenizerPoolConfig struct {
 	DefaultTokenizer     tokenizer.Tokenizer // Default when remote fails
 	ModelServiceMap      map[string]string   // Model -> Service endpoint mapping
 	Timeout              time.Duration       // Request timeout
+	LocalTokenizerDir    string              // Directory of Hugging Face tokenizer files per model, used before the default
 }
 
 // tokenizerEntry represents a cached tokenizer with metadata
@@ -65,6 +68,9 @@ type TokenizerPool struct {
 	metrics           *TokenizerPoolMetrics // Can be nil when feature disabled
 	metricsRegistered bool
 	stopCh            chan struct{}
+
+	localMu         sync.RWMutex
+	localTokenizers map[string]tokenizer.Tokenizer // model -> local tokenizer, nil if the model has no tokenizer file
 }
 
 // TokenizerPoolMetrics contains Prometheus metrics for the pool
@@ -145,10 +151,11 @@ func (m *TokenizerPoolMetrics) unregister() {
 // NewTokenizerPool creates a new TokenizerPool instance
 func NewTokenizerPool(config TokenizerPoolConfig, cache cache.Cache) *TokenizerPool {
 	pool := &TokenizerPool{
-		tokenizers: make(map[string]*tokenizerEntry),
-		config:     config,
-		cache:      cache,
-		stopCh:     make(chan struct{}),
+		tokenizers:      make(map[string]*tokenizerEntry),
+		config:          config,
+		cache:           cache,
+		stopCh:          make(chan struct{}),
+		localTokenizers: make(map[string]tokenizer.Tokenizer),
 	}
 
 	// Only create and register metrics if feature is enabled
@@ -229,9 +236,9 @@ func (p *TokenizerPool) GetTokenizer(model string, pods []*v1.Pod) tokenizer.Tok
 		p.observeTokenizerLatency(model, time.Since(startTime))
 	}()
 
-	// If remote tokenizer is disabled, return default immediately
+	// If remote tokenizer is disabled, return the local tokenizer immediately
 	if !p.config.EnableVLLMRemote {
-		return p.config.DefaultTokenizer
+		return p.getLocalTokenizer(model)
 	}
 
 	// Acquire write lock directly to avoid race condition
@@ -268,7 +275,7 @@ func (p *TokenizerPool) createOrUpdateTokenizer(model string, pods []*v1.Pod) to
 	if len(p.tokenizers) >= p.config.MaxTokenizersPerPool {
 		p.mu.Unlock()
 		klog.Warningf("TokenizerPool reached max size %d, using default tokenizer", p.config.MaxTokenizersPerPool)
-		return p.config.DefaultTokenizer
+		return p.getLocalTokenizer(model)
 	}
 
 	// Find endpoint for model
@@ -277,7 +284,7 @@ func (p *TokenizerPool) createOrUpdateTokenizer(model string, pods []*v1.Pod) to
 		p.mu.Unlock()
 		klog.V(4).Infof("No vLLM endpoint found for model %s, using default tokenizer", model)
 		p.incTokenizerCreationFailures()
-		return p.config.DefaultTokenizer
+		return p.getLocalTokenizer(model)
 	}
 
 	// Release lock before creating tokenizer and health check
@@ -298,7 +305,7 @@ func (p *TokenizerPool) createOrUpdateTokenizer(model string, pods []*v1.Pod) to
 	if err != nil {
 		klog.Warningf("Failed to create vLLM tokenizer for model %s: %v", model, err)
 		p.incTokenizerCreationFailures()
-		return p.config.DefaultTokenizer
+		return p.getLocalTokenizer(model)
 	}
 
 	// Verify health (outside of lock)
@@ -309,7 +316,7 @@ func (p *TokenizerPool) createOrUpdateTokenizer(model string, pods []*v1.Pod) to
 		if !remoteTok.IsHealthy(ctx) {
 			klog.Warningf("Created tokenizer for model %s is not healthy", model)
 			p.incTokenizerCreationFailures()
-			return p.config.DefaultTokenizer
+			return p.getLocalTokenizer(model)
 		}
 	}
 
@@ -345,6 +352,57 @@ func (p *TokenizerPool) createOrUpdateTokenizer(model string, pods []*v1.Pod) to
 	return tok
 }
 
+// getLocalTokenizer returns the tokenizer of the model from the local tokenizer directory, or the default
+// tokenizer if the model has no tokenizer file. Tokenizer files are loaded once, on first use.
+func (p *TokenizerPool) getLocalTokenizer(model string) tokenizer.Tokenizer {
+	if p.config.LocalTokenizerDir == "" || model == "" {
+		return p.config.DefaultTokenizer
+	}
+
+	p.localMu.RLock()
+	tok, exists := p.localTokenizers[model]
+	p.localMu.RUnlock()
+	if !exists {
+		tok = p.loadLocalTokenizer(model)
+		p.localMu.Lock()
+		p.localTokenizers[model] = tok
+		p.localMu.Unlock()
+	}
+
+	if tok == nil {
+		return p.config.DefaultTokenizer
+	}
+	return tok
+}
+
+// loadLocalTokenizer loads the tokenizer of the model from <dir>/<model>.json, as mounted from a ConfigMap,
+// or from <dir>/<model>/tokenizer.json, as in a Hugging Face model repository.
+func (p *TokenizerPool) loadLocalTokenizer(model string) tokenizer.Tokenizer {
+	if !filepath.IsLocal(model) {
+		klog.Warningf("Invalid model name %s for local tokenizer, using default tokenizer", model)
+		return nil
+	}
+
+	for _, path := range []string{
+		filepath.Join(p.config.LocalTokenizerDir, model+".json"),
+		filepath.Join(p.config.LocalTokenizerDir, model, "tokenizer.json"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		tok, err := tokenizer.NewHuggingFaceTokenizer(tokenizer.HuggingFaceTokenizerConfig{Path: path})
+		if err != nil {
+			klog.Warningf("Failed to load local tokenizer for model %s from %s: %v", model, path, err)
+			return nil
+		}
+		klog.V(3).Infof("Loaded local tokenizer for model %s from %s", model, path)
+		return tok
+	}
+
+	klog.V(4).Infof("No local tokenizer found for model %s, using default tokenizer", model)
+	return nil
+}
+
 // findVLLMEndpointForModel finds the vLLM endpoint for a specific model
 func (p *TokenizerPool) findVLLMEndpointForModel(model string, pods []*v1.Pod) string {
 	// Priority order for endpoint discovery:
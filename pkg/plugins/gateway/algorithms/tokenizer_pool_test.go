@@ -19,6 +19,8 @@ package routingalgorithms
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -504,6 +506,45 @@ func TestTokenizerPoolMaxSize(t *testing.T) {
 	pool.mu.RUnlock()
 }
 
+func TestTokenizerPoolLocalTokenizers(t *testing.T) {
+	resetPrometheusRegistry()
+
+	defaultTokenizer := &mockTokenizer{}
+	data, err := os.ReadFile("../../../utils/tokenizer/testdata/sentencepiece_bpe.json")
+	assert.NoError(t, err)
+
+	// Tokenizer files mounted from a ConfigMap and in a model repository layout
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "llama2-7b.json"), data, 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "meta-llama", "Llama-2-7b"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "meta-llama", "Llama-2-7b", "tokenizer.json"), data, 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644))
+
+	pool := NewTokenizerPool(TokenizerPoolConfig{
+		DefaultTokenizer:  defaultTokenizer,
+		LocalTokenizerDir: dir,
+	}, createTestCache())
+	defer func() {
+		if err := pool.Close(); err != nil {
+			t.Errorf("Failed to close pool: %v", err)
+		}
+	}()
+
+	for _, model := range []string{"llama2-7b", "meta-llama/Llama-2-7b"} {
+		tok := pool.GetTokenizer(model, nil)
+		assert.NotEqual(t, defaultTokenizer, tok, model)
+		tokens, err := tok.TokenizeInputText("hello world")
+		assert.NoError(t, err)
+		assert.Len(t, tokens, 3*4, model) // <s> hello world
+		// Loaded once
+		assert.Same(t, tok, pool.GetTokenizer(model, nil))
+	}
+
+	for _, model := range []string{"unknown", "broken", "../llama2-7b", ""} {
+		assert.Equal(t, defaultTokenizer, pool.GetTokenizer(model, nil), model)
+	}
+}
+
 func TestTokenizerPoolConcurrency(t *testing.T) {
 	resetPrometheusRegistry()
 
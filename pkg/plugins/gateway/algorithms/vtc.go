@@ -23,4 +23,8 @@ import (
 func init() {
 	// Register the VTC Basic router
 	Register(vtc.RouterVTCBasic, vtc.NewVTCBasicRouter)
+	// Register the fair VTC variants
+	Register(vtc.RouterVTCFair, vtc.NewVTCFairRouter)
+	Register(vtc.RouterVTCMaxFair, vtc.NewVTCMaxFairRouter)
+	Register(vtc.RouterVTCPred50, vtc.NewVTCPred50Router)
 }
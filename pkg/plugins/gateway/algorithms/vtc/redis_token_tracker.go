@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultRedisTokenTrackerKeyPrefix = "aibrix:vtc"
+
+// pruneBucketsLua removes the buckets of a user older than the cutoff, and returns the total of the remaining buckets.
+const pruneBucketsLua = `
+local function prune(bucketsKey, cutoff)
+  local buckets = redis.call('HGETALL', bucketsKey)
+  local total = 0
+  for i = 1, #buckets, 2 do
+    if tonumber(buckets[i]) < cutoff then
+      redis.call('HDEL', bucketsKey, buckets[i])
+    else
+      total = total + tonumber(buckets[i + 1])
+    end
+  end
+  return total
+end
+
+local function setTotal(totalsKey, user, total)
+  if total > 0 then
+    redis.call('ZADD', totalsKey, total, user)
+  else
+    redis.call('ZREM', totalsKey, user)
+  end
+end
+`
+
+// updateTokenCountScript adds tokens to the current bucket of a user.
+// KEYS: buckets of the user, totals of all users. ARGV: user, cutoff, bucket, tokens, ttl in milliseconds.
+var updateTokenCountScript = redis.NewScript(pruneBucketsLua + `
+local total = prune(KEYS[1], tonumber(ARGV[2]))
+local tokens = tonumber(ARGV[4])
+if tokens > 0 then
+  redis.call('HINCRBYFLOAT', KEYS[1], ARGV[3], ARGV[4])
+  total = total + tokens
+end
+redis.call('PEXPIRE', KEYS[1], ARGV[5])
+setTotal(KEYS[2], ARGV[1], total)
+return tostring(total)
+`)
+
+// getTokenCountScript returns the total of a user within the window.
+// KEYS: buckets of the user, totals of all users. ARGV: user, cutoff.
+var getTokenCountScript = redis.NewScript(pruneBucketsLua + `
+local total = prune(KEYS[1], tonumber(ARGV[2]))
+setTotal(KEYS[2], ARGV[1], total)
+return tostring(total)
+`)
+
+// getBoundaryTokenCountScript returns the min or max total of all users within the window. Users whose buckets
+// expired since their last update are pruned until the boundary user is up to date.
+// KEYS: totals of all users. ARGV: cutoff, prefix of the buckets keys, "min" or "max".
+var getBoundaryTokenCountScript = redis.NewScript(pruneBucketsLua + `
+local cutoff = tonumber(ARGV[1])
+for _ = 1, 64 do
+  local boundary
+  if ARGV[3] == 'min' then
+    boundary = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+  else
+    boundary = redis.call('ZREVRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+  end
+  if #boundary == 0 then
+    return false
+  end
+  local total = prune(ARGV[2] .. boundary[1], cutoff)
+  if total == tonumber(boundary[2]) then
+    return boundary[2]
+  end
+  setTotal(KEYS[1], boundary[1], total)
+end
+return false
+`)
+
+// RedisSlidingWindowTokenTracker tracks tokens per user in a sliding window stored in redis, so that the
+// token counts are shared across gateway plugin instances. Updates are atomic lua scripts.
+// The buckets of a user are stored in a hash, and the totals of all users in a sorted set used for min/max.
+// All keys are accessed from the scripts, so a single redis instance is required, not a redis cluster.
+type RedisSlidingWindowTokenTracker struct {
+	client     *redis.Client
+	keyPrefix  string
+	windowSize time.Duration
+	bucketUnit TimeUnit
+	config     *VTCConfig
+	now        func() time.Time
+}
+
+// RedisTokenTrackerOption is a function that configures a redis token tracker
+type RedisTokenTrackerOption func(*RedisSlidingWindowTokenTracker)
+
+// WithRedisWindow sets the window of the tracker to size units.
+func WithRedisWindow(size int, unit TimeUnit) RedisTokenTrackerOption {
+	return func(t *RedisSlidingWindowTokenTracker) {
+		t.bucketUnit = unit
+		t.windowSize = time.Duration(size) * timeUnitDuration[unit]
+	}
+}
+
+// WithRedisKeyPrefix sets the prefix of the redis keys of the tracker.
+func WithRedisKeyPrefix(prefix string) RedisTokenTrackerOption {
+	return func(t *RedisSlidingWindowTokenTracker) {
+		t.keyPrefix = prefix
+	}
+}
+
+// NewRedisSlidingWindowTokenTracker creates a new redis token tracker, the window defaults to the one of the in-memory tracker.
+func NewRedisSlidingWindowTokenTracker(client *redis.Client, config *VTCConfig, opts ...RedisTokenTrackerOption) TokenTracker {
+	unit := Minutes
+	switch timeUnitStr {
+	case "seconds":
+		unit = Seconds
+	case "milliseconds":
+		unit = Milliseconds
+	}
+
+	tracker := &RedisSlidingWindowTokenTracker{
+		client:     client,
+		keyPrefix:  defaultRedisTokenTrackerKeyPrefix,
+		windowSize: time.Duration(windowSizeOf(config)) * timeUnitDuration[unit],
+		bucketUnit: unit,
+		config:     config,
+		now:        time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(tracker)
+	}
+
+	return tracker
+}
+
+func (t *RedisSlidingWindowTokenTracker) bucketsKeyPrefix() string {
+	return t.keyPrefix + ":buckets:"
+}
+
+func (t *RedisSlidingWindowTokenTracker) bucketsKey(user string) string {
+	return t.bucketsKeyPrefix() + user
+}
+
+func (t *RedisSlidingWindowTokenTracker) totalsKey() string {
+	return t.keyPrefix + ":totals"
+}
+
+func (t *RedisSlidingWindowTokenTracker) getCutoffTimestamp() int64 {
+	return t.bucketUnit.toTimestamp(t.now().Add(-t.windowSize))
+}
+
+func (t *RedisSlidingWindowTokenTracker) GetTokenCount(ctx context.Context, user string) (float64, error) {
+	if user == "" {
+		return 0, nil
+	}
+
+	res, err := getTokenCountScript.Run(ctx, t.client, []string{t.bucketsKey(user), t.totalsKey()},
+		user, t.getCutoffTimestamp()).Text()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get token count of user %s: %w", user, err)
+	}
+	return strconv.ParseFloat(res, 64)
+}
+
+func (t *RedisSlidingWindowTokenTracker) GetMinTokenCount(ctx context.Context) (float64, error) {
+	return t.getBoundaryTokenCount(ctx, "min", tokenTrackerMinTokens)
+}
+
+func (t *RedisSlidingWindowTokenTracker) GetMaxTokenCount(ctx context.Context) (float64, error) {
+	return t.getBoundaryTokenCount(ctx, "max", tokenTrackerMaxTokens)
+}
+
+// getBoundaryTokenCount returns the min or max total of the active users, or the default if no user is active.
+func (t *RedisSlidingWindowTokenTracker) getBoundaryTokenCount(ctx context.Context, boundary string, defaultValue float64) (float64, error) {
+	res, err := getBoundaryTokenCountScript.Run(ctx, t.client, []string{t.totalsKey()},
+		t.getCutoffTimestamp(), t.bucketsKeyPrefix(), boundary).Text()
+	if errors.Is(err, redis.Nil) {
+		return defaultValue, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s token count: %w", boundary, err)
+	}
+	return strconv.ParseFloat(res, 64)
+}
+
+func (t *RedisSlidingWindowTokenTracker) UpdateTokenCount(ctx context.Context, user string, inputTokens, outputTokens float64) error {
+	if user == "" {
+		return fmt.Errorf("user ID cannot be empty")
+	}
+
+	// Clamp negative tokens to zero
+	inputTokens = max(0, inputTokens)
+	outputTokens = max(0, outputTokens)
+	newTokens := inputTokens*t.config.InputTokenWeight + outputTokens*t.config.OutputTokenWeight
+
+	now := t.now()
+	// Keep the buckets one unit longer than the window, so that the oldest bucket in the window is never expired.
+	ttl := t.windowSize + timeUnitDuration[t.bucketUnit]
+	err := updateTokenCountScript.Run(ctx, t.client, []string{t.bucketsKey(user), t.totalsKey()},
+		user, t.getCutoffTimestamp(), t.bucketUnit.toTimestamp(now), strconv.FormatFloat(newTokens, 'f', -1, 64), ttl.Milliseconds()).Err()
+	if err != nil {
+		return fmt.Errorf("failed to update token count of user %s: %w", user, err)
+	}
+	return nil
+}
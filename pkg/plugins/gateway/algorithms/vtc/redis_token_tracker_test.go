@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRedisTokenTracker(t *testing.T, config *VTCConfig, now *time.Time) (*miniredis.Miniredis, *RedisSlidingWindowTokenTracker, *RedisSlidingWindowTokenTracker) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	// Two trackers sharing the same redis, like two gateway plugin instances.
+	newTracker := func() *RedisSlidingWindowTokenTracker {
+		tracker := NewRedisSlidingWindowTokenTracker(client, config, WithRedisWindow(10, Seconds)).(*RedisSlidingWindowTokenTracker)
+		tracker.now = func() time.Time { return *now }
+		return tracker
+	}
+	return mr, newTracker(), newTracker()
+}
+
+func TestRedisSlidingWindowTokenTracker(t *testing.T) {
+	config := VTCConfig{InputTokenWeight: 1, OutputTokenWeight: 2}
+	now := time.Unix(1000, 0)
+	_, tracker1, tracker2 := newTestRedisTokenTracker(t, &config, &now)
+	ctx := context.Background()
+
+	tokens, err := tracker1.GetTokenCount(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), tokens)
+	assert.Error(t, tracker1.UpdateTokenCount(ctx, "", 1, 1))
+
+	// Counts are shared across trackers.
+	assert.NoError(t, tracker1.UpdateTokenCount(ctx, "user1", 10, 15)) // 10*1 + 15*2 = 40
+	assert.NoError(t, tracker2.UpdateTokenCount(ctx, "user1", 0.5, -5))
+	tokens, err = tracker2.GetTokenCount(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, 40.5, tokens)
+
+	now = now.Add(5 * time.Second)
+	assert.NoError(t, tracker2.UpdateTokenCount(ctx, "user1", 10, 0))
+	tokens, err = tracker1.GetTokenCount(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, 50.5, tokens)
+
+	// The first buckets slide out of the window.
+	now = now.Add(6 * time.Second)
+	tokens, err = tracker1.GetTokenCount(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(10), tokens)
+
+	now = now.Add(10 * time.Second)
+	tokens, err = tracker1.GetTokenCount(ctx, "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(0), tokens)
+}
+
+func TestRedisSlidingWindowTokenTracker_MinMax(t *testing.T) {
+	config := VTCConfig{InputTokenWeight: 1, OutputTokenWeight: 1}
+	now := time.Unix(1000, 0)
+	mr, tracker1, tracker2 := newTestRedisTokenTracker(t, &config, &now)
+	ctx := context.Background()
+
+	// Defaults without active users.
+	minTokens, err := tracker1.GetMinTokenCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, tokenTrackerMinTokens, minTokens)
+	maxTokens, err := tracker1.GetMaxTokenCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, tokenTrackerMaxTokens, maxTokens)
+
+	assert.NoError(t, tracker1.UpdateTokenCount(ctx, "user1", 100, 0))
+	now = now.Add(5 * time.Second)
+	assert.NoError(t, tracker2.UpdateTokenCount(ctx, "user2", 20, 0))
+	assert.NoError(t, tracker2.UpdateTokenCount(ctx, "user3", 50, 0))
+
+	minTokens, err = tracker2.GetMinTokenCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(20), minTokens)
+	maxTokens, err = tracker1.GetMaxTokenCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(100), maxTokens)
+
+	// The tokens of user1 expire without any update of user1, the max is recomputed.
+	now = now.Add(6 * time.Second)
+	maxTokens, err = tracker1.GetMaxTokenCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(50), maxTokens)
+
+	// Buckets expired by redis are handled as well.
+	mr.FastForward(time.Minute)
+	now = now.Add(time.Minute)
+	minTokens, err = tracker1.GetMinTokenCount(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, tokenTrackerMinTokens, minTokens)
+	assert.Empty(t, mr.Keys())
+}
+
+func TestNewTokenTracker(t *testing.T) {
+	config := DefaultVTCConfig()
+	tracker, err := NewTokenTracker(&config)
+	assert.NoError(t, err)
+	assert.IsType(t, &InMemorySlidingWindowTokenTracker{}, tracker)
+
+	config.TokenTracker = RedisTokenTracker
+	SetRedisClient(nil)
+	_, err = NewTokenTracker(&config)
+	assert.Error(t, err, "redis client is required")
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	SetRedisClient(client)
+	defer SetRedisClient(nil)
+	tracker, err = NewTokenTracker(&config)
+	assert.NoError(t, err)
+	assert.Same(t, client, tracker.(*RedisSlidingWindowTokenTracker).client)
+
+	config.TokenTracker = "unknown"
+	_, err = NewTokenTracker(&config)
+	assert.Error(t, err)
+}
@@ -102,7 +102,7 @@ type TokenTrackerOption func(*InMemorySlidingWindowTokenTracker)
 // updateWindowSize recalculates the window size based on time unit
 func (t *InMemorySlidingWindowTokenTracker) updateWindowSize() {
 	// Set window size based on configured size and time unit
-	t.windowSize = time.Duration(tokenTrackerWindowSize) * timeUnitDuration[t.bucketUnit]
+	t.windowSize = time.Duration(windowSizeOf(t.config)) * timeUnitDuration[t.bucketUnit]
 }
 
 func WithWindowSize(size int) TokenTrackerOption {
@@ -120,7 +120,6 @@ func WithTimeUnit(unit TimeUnit) TokenTrackerOption {
 	}
 }
 
-// TODO: add redis token tracker so that state is shared across plugin instances
 // NewInMemorySlidingWindowTokenTracker creates a new token tracker with configurable options
 func NewInMemorySlidingWindowTokenTracker(config *VTCConfig, opts ...TokenTrackerOption) TokenTracker {
 	defaultUnit := Minutes
@@ -140,7 +139,7 @@ func NewInMemorySlidingWindowTokenTracker(config *VTCConfig, opts ...TokenTracke
 		minTrackedToken: math.MaxFloat64, // Start high so first positive value becomes min
 		maxTrackedToken: 0.0,             // Start with zero as default max
 		config:          config,
-		windowSize:      time.Duration(tokenTrackerWindowSize) * timeUnitDuration[defaultUnit], // Initialize window size directly
+		windowSize:      time.Duration(windowSizeOf(config)) * timeUnitDuration[defaultUnit], // Initialize window size directly
 	}
 
 	for _, opt := range opts {
@@ -53,12 +53,21 @@ var (
 	utilizationWeight = utils.LoadEnvFloat(VTC_UTILIZATION_WEIGHT, defaultUtilizationWeight)
 )
 
+// Router parameters of the VTC variants, overriding the environment variables for a model, see types.RoutingContext.Parameters.
+const (
+	VTCParamWindowSize        = "window-size"
+	VTCParamMaxPodLoad        = "max-pod-load"
+	VTCParamFairnessWeight    = "fairness-weight"
+	VTCParamUtilizationWeight = "utilization-weight"
+)
+
 // BasicVTCRouter implements the VTC routing algorithm
 type BasicVTCRouter struct {
 	cache          cache.MetricCache
 	tokenTracker   TokenTracker
 	tokenEstimator TokenEstimator
 	config         *VTCConfig
+	windowTrackers windowTokenTrackers
 }
 
 // NewBasicVTCRouter creates a new BasicVTCRouter with the provided token tracker and estimator
@@ -91,10 +100,15 @@ func (r *BasicVTCRouter) Route(ctx *types.RoutingContext, readyPodList types.Pod
 		return ctx.TargetAddress(), nil
 	}
 
+	tokenTracker := r.tokenTrackerFor(ctx)
+	maxPodLoad := ctx.ParamFloat(VTCParamMaxPodLoad, maxPodLoad)
+	fairnessWeight := ctx.ParamFloat(VTCParamFairnessWeight, fairnessWeight)
+	utilizationWeight := ctx.ParamFloat(VTCParamUtilizationWeight, utilizationWeight)
+
 	inputTokens := r.tokenEstimator.EstimateInputTokens(ctx.Message)
 	outputTokens := r.tokenEstimator.EstimateOutputTokens(ctx.Message)
 
-	userTokens, err := r.tokenTracker.GetTokenCount(ctx.Context, *user)
+	userTokens, err := tokenTracker.GetTokenCount(ctx.Context, *user)
 	if err != nil {
 		klog.ErrorS(err, "failed to get user token count, falling back to zero", "user", *user)
 		userTokens = 0
@@ -115,13 +129,13 @@ func (r *BasicVTCRouter) Route(ctx *types.RoutingContext, readyPodList types.Pod
 	// By adapting bucket sizes and normalizing scores, the algorithm remains robust as system load and user activity fluctuate.
 
 	// Get the min and max token counts for adaptive bucket sizing
-	minTokens, err := r.tokenTracker.GetMinTokenCount(ctx.Context)
+	minTokens, err := tokenTracker.GetMinTokenCount(ctx.Context)
 	if err != nil {
 		klog.ErrorS(err, "failed to get minimum token count, using default value")
 		minTokens = tokenTrackerMinTokens // Use the configured default minimum token count
 	}
 
-	maxTokens, err := r.tokenTracker.GetMaxTokenCount(ctx.Context)
+	maxTokens, err := tokenTracker.GetMaxTokenCount(ctx.Context)
 	if err != nil {
 		klog.ErrorS(err, "failed to get maximum token count, using default value")
 		maxTokens = tokenTrackerMaxTokens // Use the configured default maximum token count
@@ -208,7 +222,7 @@ func (r *BasicVTCRouter) Route(ctx *types.RoutingContext, readyPodList types.Pod
 	}
 
 	if *user != "" {
-		err := r.tokenTracker.UpdateTokenCount(ctx.Context, *user, inputTokens, outputTokens)
+		err := tokenTracker.UpdateTokenCount(ctx.Context, *user, inputTokens, outputTokens)
 		if err != nil {
 			klog.ErrorS(err, "failed to update user token count", "user", *user)
 		}
@@ -218,6 +232,11 @@ func (r *BasicVTCRouter) Route(ctx *types.RoutingContext, readyPodList types.Pod
 	return ctx.TargetAddress(), nil
 }
 
+// tokenTrackerFor returns the token tracker of the window size set by the router parameters of the request.
+func (r *BasicVTCRouter) tokenTrackerFor(ctx *types.RoutingContext) TokenTracker {
+	return r.windowTrackers.trackerFor(ctx, r.tokenTracker, r.config)
+}
+
 func (r *BasicVTCRouter) SubscribedMetrics() []string {
 	return []string{
 		metrics.NumRequestsRunning,
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strconv"
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -406,6 +407,50 @@ func TestVTCBasicRouterStrengths(t *testing.T) {
 	})
 }
 
+func TestVTCRouterWindowParameter(t *testing.T) {
+	config := &VTCConfig{InputTokenWeight: 1.0, OutputTokenWeight: 1.0, Variant: RouterVTCBasic}
+	defaultTracker := NewInMemorySlidingWindowTokenTracker(config)
+	router := &BasicVTCRouter{
+		cache:          NewSimpleCache(),
+		tokenTracker:   defaultTracker,
+		tokenEstimator: NewSimpleTokenEstimator(),
+		config:         config,
+	}
+
+	newCtx := func(params map[string]string) *types.RoutingContext {
+		routingCtx := types.NewRoutingContext(context.Background(), RouterVTCBasic, "model1", "test message", "request", "user1")
+		routingCtx.Parameters = params
+		return routingCtx
+	}
+
+	assert.Same(t, defaultTracker, router.tokenTrackerFor(newCtx(nil)))
+	assert.Same(t, defaultTracker, router.tokenTrackerFor(newCtx(map[string]string{VTCParamWindowSize: "invalid"})))
+
+	// Requests with the same window share a tracker, separate from the default one
+	tracker := router.tokenTrackerFor(newCtx(map[string]string{VTCParamWindowSize: "10"}))
+	assert.NotSame(t, defaultTracker, tracker)
+	assert.Same(t, tracker, router.tokenTrackerFor(newCtx(map[string]string{VTCParamWindowSize: "10"})))
+	assert.Equal(t, 10*timeUnitDuration[Minutes], tracker.(*InMemorySlidingWindowTokenTracker).windowSize)
+
+	// Tokens are tracked by the tracker of the window
+	_, err := router.Route(newCtx(map[string]string{VTCParamWindowSize: "10"}), NewSimplePodList(createTestPods(2)))
+	assert.NoError(t, err)
+	tokens, err := tracker.GetTokenCount(context.Background(), "user1")
+	assert.NoError(t, err)
+	assert.Greater(t, tokens, 0.0)
+	tokens, err = defaultTracker.GetTokenCount(context.Background(), "user1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, tokens)
+
+	// Window trackers are bounded, further windows use the default tracker
+	for size := 11; size < 11+maxWindowTrackers; size++ {
+		router.tokenTrackerFor(newCtx(map[string]string{VTCParamWindowSize: strconv.Itoa(size)}))
+	}
+	assert.Len(t, router.windowTrackers.trackers, maxWindowTrackers)
+	assert.Same(t, tracker, router.tokenTrackerFor(newCtx(map[string]string{VTCParamWindowSize: "10"})))
+	assert.Same(t, defaultTracker, router.tokenTrackerFor(newCtx(map[string]string{VTCParamWindowSize: "100"})))
+}
+
 func TestWeightCombinations(t *testing.T) {
 	// Use Milliseconds and set weights for testing
 	t.Setenv("AIBRIX_ROUTER_VTC_TOKEN_TRACKER_TIME_UNIT", "milliseconds")
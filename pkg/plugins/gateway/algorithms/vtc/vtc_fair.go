@@ -0,0 +1,242 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtc
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/cache"
+	"github.com/vllm-project/aibrix/pkg/metrics"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+// predictionSamples is the number of output length predictions vtc-pred-50 takes the median of.
+// The output predictor samples the output length distribution of the model, so the median of a few
+// samples approximates its 50th percentile.
+const predictionSamples = 5
+
+// FairVTCRouter implements the vtc-fair, vtc-max-fair and vtc-pred-50 variants of the VTC routing algorithm.
+//
+// Pods are ranked by load, and the share of a user is the position of its token count between the least and the
+// most served users. Users of smaller shares, i.e. the underserved users, are routed to the less loaded pods:
+//   - vtc-fair scores pods by fairnessWeight * |pod rank - user share| + utilizationWeight * utilization.
+//   - vtc-max-fair never routes a user to a pod less loaded than its share, so the least served user always
+//     gets the least loaded pod.
+//   - vtc-pred-50 scores pods as vtc-fair, but charges the median output length predicted by the model's
+//     output predictor instead of the estimated one.
+//
+// The paper schedules the request queue of a single server by the least served user. The rank and share scoring,
+// and pred-50 as the median of predictionSamples Predict() samples, adapt it to pod selection: these variants are
+// not the reference algorithms.
+type FairVTCRouter struct {
+	cache          cache.MetricCache
+	predictors     types.OutputPredictorProvider
+	tokenTracker   TokenTracker
+	tokenEstimator TokenEstimator
+	config         *VTCConfig
+	windowTrackers windowTokenTrackers
+}
+
+// NewFairVTCRouter creates a new FairVTCRouter of the variant of the config with the provided token tracker and estimator
+func NewFairVTCRouter(tokenTracker TokenTracker, tokenEstimator TokenEstimator, config *VTCConfig) (*FairVTCRouter, error) {
+	switch config.Variant {
+	case RouterVTCFair, RouterVTCMaxFair, RouterVTCPred50:
+	default:
+		return nil, fmt.Errorf("unsupported vtc variant: %s", config.Variant)
+	}
+
+	c, err := cache.Get()
+	if err != nil {
+		klog.ErrorS(err, "fail to get cache store in vtc router", "variant", config.Variant)
+		return nil, err
+	}
+
+	return &FairVTCRouter{
+		cache:          c,
+		predictors:     c,
+		tokenTracker:   tokenTracker,
+		tokenEstimator: tokenEstimator,
+		config:         config,
+	}, nil
+}
+
+// Route implements the fair variants of the VTC routing algorithm
+func (r *FairVTCRouter) Route(ctx *types.RoutingContext, readyPodList types.PodList) (string, error) {
+	readyPods := readyPodList.All()
+	user := ctx.User
+	if user == nil || *user == "" {
+		klog.V(4).InfoS("VTC routing not possible without user, falling back to random pod selection", "variant", r.config.Variant)
+		randomPod, err := utils.SelectRandomPod(readyPods, rand.Intn)
+		if err != nil {
+			return "", fmt.Errorf("fallback to random pod selection failed: %w", err)
+		}
+		ctx.SetTargetPod(randomPod)
+		return ctx.TargetAddress(), nil
+	}
+	if len(readyPods) == 0 {
+		return "", fmt.Errorf("no pods to forward request")
+	}
+
+	tokenTracker := r.windowTrackers.trackerFor(ctx, r.tokenTracker, r.config)
+	maxPodLoad := ctx.ParamFloat(VTCParamMaxPodLoad, maxPodLoad)
+	fairnessWeight := ctx.ParamFloat(VTCParamFairnessWeight, fairnessWeight)
+	utilizationWeight := ctx.ParamFloat(VTCParamUtilizationWeight, utilizationWeight)
+
+	inputTokens := r.tokenEstimator.EstimateInputTokens(ctx.Message)
+	outputTokens := r.estimateOutputTokens(ctx, inputTokens)
+
+	share := userShare(ctx, tokenTracker, *user)
+	pods := r.rankPodsByUtilization(ctx, readyPods, maxPodLoad)
+	targetRank := share * float64(len(pods)-1)
+
+	var targetPod *v1.Pod
+	if r.config.Variant == RouterVTCMaxFair {
+		targetPod = selectMaxFairPod(pods, int(math.Floor(targetRank)))
+	} else {
+		targetPod = selectFairPod(pods, targetRank, fairnessWeight, utilizationWeight)
+	}
+
+	klog.V(4).InfoS("VTC fair pod selection",
+		"variant", r.config.Variant,
+		"user", *user,
+		"share", share,
+		"targetRank", targetRank,
+		"pod", targetPod.Name,
+		"inputTokens", inputTokens,
+		"outputTokens", outputTokens)
+
+	if err := tokenTracker.UpdateTokenCount(ctx.Context, *user, inputTokens, outputTokens); err != nil {
+		klog.ErrorS(err, "failed to update user token count", "user", *user)
+	}
+
+	ctx.SetTargetPod(targetPod)
+	return ctx.TargetAddress(), nil
+}
+
+// userShare returns the position of the token count of the user between the least and the most served users, in [0, 1].
+func userShare(ctx *types.RoutingContext, tokenTracker TokenTracker, user string) float64 {
+	userTokens, err := tokenTracker.GetTokenCount(ctx.Context, user)
+	if err != nil {
+		klog.ErrorS(err, "failed to get user token count, falling back to zero", "user", user)
+		userTokens = 0
+	}
+	minTokens, err := tokenTracker.GetMinTokenCount(ctx.Context)
+	if err != nil {
+		klog.ErrorS(err, "failed to get minimum token count, using default value")
+		minTokens = tokenTrackerMinTokens
+	}
+	maxTokens, err := tokenTracker.GetMaxTokenCount(ctx.Context)
+	if err != nil {
+		klog.ErrorS(err, "failed to get maximum token count, using default value")
+		maxTokens = tokenTrackerMaxTokens
+	}
+
+	if maxTokens <= minTokens {
+		return 0
+	}
+	return math.Max(0, math.Min((userTokens-minTokens)/(maxTokens-minTokens), 1))
+}
+
+// estimateOutputTokens returns the output tokens charged to the user for the request.
+func (r *FairVTCRouter) estimateOutputTokens(ctx *types.RoutingContext, inputTokens float64) float64 {
+	if r.config.Variant != RouterVTCPred50 || r.predictors == nil {
+		return r.tokenEstimator.EstimateOutputTokens(ctx.Message)
+	}
+	predictor, err := r.predictors.GetOutputPredictor(ctx.Model)
+	if err != nil || predictor == nil {
+		klog.V(4).InfoS("output predictor not available, using estimated output tokens", "model", ctx.Model, "error", err)
+		return r.tokenEstimator.EstimateOutputTokens(ctx.Message)
+	}
+
+	predictions := make([]int, predictionSamples)
+	for i := range predictions {
+		predictions[i] = predictor.Predict(int(inputTokens))
+	}
+	sort.Ints(predictions)
+	return float64(predictions[predictionSamples/2])
+}
+
+// rankedPod is a pod with its utilization, in [0, 1].
+type rankedPod struct {
+	pod         *v1.Pod
+	utilization float64
+}
+
+// rankPodsByUtilization returns the pods ordered by utilization ascendingly.
+func (r *FairVTCRouter) rankPodsByUtilization(ctx *types.RoutingContext, readyPods []*v1.Pod, maxPodLoad float64) []rankedPod {
+	pods := make([]rankedPod, len(readyPods))
+	for i, pod := range readyPods {
+		var podLoad float64
+		if r.cache != nil {
+			reqCount, err := r.cache.GetMetricValueByPodModel(pod.Name, pod.Namespace, ctx.Model, metrics.NumRequestsRunning)
+			if err != nil {
+				klog.V(4).InfoS("failed to get pod metrics, using default value", "pod", pod.Name, "error", err)
+			} else if reqCount != nil {
+				podLoad = reqCount.GetSimpleValue()
+			}
+		}
+		pods[i] = rankedPod{pod: pod, utilization: math.Min(podLoad/maxPodLoad, 1.0)}
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[i].utilization < pods[j].utilization
+	})
+	return pods
+}
+
+// selectFairPod returns the pod of the lowest fairness and utilization score, picking randomly among ties.
+func selectFairPod(pods []rankedPod, targetRank, fairnessWeight, utilizationWeight float64) *v1.Pod {
+	maxRank := math.Max(float64(len(pods)-1), 1)
+	var candidates []*v1.Pod
+	minScore := math.MaxFloat64
+	for rank, pod := range pods {
+		fairnessScore := math.Abs(float64(rank)-targetRank) / maxRank
+		score := fairnessWeight*fairnessScore + utilizationWeight*pod.utilization
+		if score < minScore {
+			minScore = score
+			candidates = []*v1.Pod{pod.pod}
+		} else if score == minScore {
+			candidates = append(candidates, pod.pod)
+		}
+	}
+	return candidates[rand.Intn(len(candidates))]
+}
+
+// selectMaxFairPod returns the least loaded pod ranked no lower than the target rank, picking randomly among
+// the pods of the same utilization.
+func selectMaxFairPod(pods []rankedPod, targetRank int) *v1.Pod {
+	targetRank = max(0, min(targetRank, len(pods)-1))
+	var candidates []*v1.Pod
+	for _, pod := range pods {
+		if pod.utilization == pods[targetRank].utilization {
+			candidates = append(candidates, pod.pod)
+		}
+	}
+	return candidates[rand.Intn(len(candidates))]
+}
+
+func (r *FairVTCRouter) SubscribedMetrics() []string {
+	return []string{
+		metrics.NumRequestsRunning,
+	}
+}
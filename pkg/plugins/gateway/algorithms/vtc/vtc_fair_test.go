@@ -0,0 +1,251 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vtc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/vllm-project/aibrix/pkg/metrics"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+// sequenceOutputPredictor predicts the output lengths in sequence.
+type sequenceOutputPredictor struct {
+	predictions []int
+	next        int
+}
+
+func (p *sequenceOutputPredictor) AddTrace(inputTokens, outputTokens int, cnt int32) {}
+
+func (p *sequenceOutputPredictor) Predict(promptLen int) int {
+	prediction := p.predictions[p.next%len(p.predictions)]
+	p.next++
+	return prediction
+}
+
+type fakeOutputPredictorProvider struct {
+	predictor types.OutputPredictor
+}
+
+func (p *fakeOutputPredictorProvider) GetOutputPredictor(modelName string) (types.OutputPredictor, error) {
+	if p.predictor == nil {
+		return nil, fmt.Errorf("model does not exist in the cache: %s", modelName)
+	}
+	return p.predictor, nil
+}
+
+func newFairTestRouter(variant types.RoutingAlgorithm, cache *SimpleCache) (*FairVTCRouter, TokenTracker) {
+	config := &VTCConfig{
+		InputTokenWeight:  1.0,
+		OutputTokenWeight: 1.0,
+		Variant:           variant,
+	}
+	tracker := NewInMemorySlidingWindowTokenTracker(config)
+	return &FairVTCRouter{
+		cache:          cache,
+		predictors:     &fakeOutputPredictorProvider{},
+		tokenTracker:   tracker,
+		tokenEstimator: NewSimpleTokenEstimator(),
+		config:         config,
+	}, tracker
+}
+
+func createFairTestPods(count int) []*v1.Pod {
+	pods := createTestPods(count)
+	for _, pod := range pods {
+		pod.Namespace = "default"
+	}
+	return pods
+}
+
+func routeFairTestRequest(t *testing.T, router *FairVTCRouter, pods []*v1.Pod, user, message string) *v1.Pod {
+	ctx := types.NewRoutingContext(context.Background(), router.config.Variant, "model1", message, "request", user)
+	defer ctx.Delete()
+	_, err := router.Route(ctx, NewSimplePodList(pods))
+	require.NoError(t, err)
+	return ctx.TargetPod()
+}
+
+func TestNewFairVTCRouter_UnsupportedVariant(t *testing.T) {
+	_, err := NewFairVTCRouter(nil, nil, &VTCConfig{Variant: RouterVTCBasic})
+	assert.Error(t, err)
+}
+
+func TestFairVTCRouter_RoutesUnderservedUsersToLessLoadedPods(t *testing.T) {
+	pods := createFairTestPods(3)
+	cache := NewSimpleCache()
+	for i, load := range []float64{90, 10, 50} {
+		cache.SetPodMetric(utils.GeneratePodKey("default", pods[i].Name), "model1", metrics.NumRequestsRunning, load)
+	}
+
+	for _, variant := range []types.RoutingAlgorithm{RouterVTCFair, RouterVTCMaxFair, RouterVTCPred50} {
+		t.Run(string(variant), func(t *testing.T) {
+			router, tracker := newFairTestRouter(variant, cache)
+			ctx := context.Background()
+			require.NoError(t, tracker.UpdateTokenCount(ctx, "light", 100, 0))
+			require.NoError(t, tracker.UpdateTokenCount(ctx, "heavy", 10000, 0))
+
+			assert.Equal(t, "pod2", routeFairTestRequest(t, router, pods, "light", "test").Name,
+				"the least served user gets the least loaded pod")
+			assert.Equal(t, "pod1", routeFairTestRequest(t, router, pods, "heavy", "test").Name,
+				"the most served user gets the most loaded pod")
+			assert.Equal(t, "pod2", routeFairTestRequest(t, router, pods, "new", "test").Name,
+				"new users are underserved")
+		})
+	}
+}
+
+func TestFairVTCRouter_MaxFair(t *testing.T) {
+	pods := createFairTestPods(4)
+	cache := NewSimpleCache()
+	for i, load := range []float64{0, 5, 90, 95} {
+		cache.SetPodMetric(utils.GeneratePodKey("default", pods[i].Name), "model1", metrics.NumRequestsRunning, load)
+	}
+
+	fairRouter, fairTracker := newFairTestRouter(RouterVTCFair, cache)
+	maxFairRouter, maxFairTracker := newFairTestRouter(RouterVTCMaxFair, cache)
+	for _, tracker := range []TokenTracker{fairTracker, maxFairTracker} {
+		require.NoError(t, tracker.UpdateTokenCount(context.Background(), "light", 100, 0))
+		require.NoError(t, tracker.UpdateTokenCount(context.Background(), "medium", 6800, 0))
+		require.NoError(t, tracker.UpdateTokenCount(context.Background(), "heavy", 10000, 0))
+	}
+
+	// The share of the medium user ranks the third pod, vtc-fair trades fairness for the much less loaded second
+	// pod, while vtc-max-fair never routes it to a pod less loaded than its share.
+	assert.Equal(t, "pod2", routeFairTestRequest(t, fairRouter, pods, "medium", "test").Name)
+	assert.Equal(t, "pod3", routeFairTestRequest(t, maxFairRouter, pods, "medium", "test").Name)
+}
+
+func TestFairVTCRouter_Pred50(t *testing.T) {
+	pods := createFairTestPods(2)
+	router, tracker := newFairTestRouter(RouterVTCPred50, NewSimpleCache())
+	router.predictors = &fakeOutputPredictorProvider{
+		predictor: &sequenceOutputPredictor{predictions: []int{10, 1000, 20, 30, 5}},
+	}
+
+	routeFairTestRequest(t, router, pods, "user", "test message")
+	tokens, err := tracker.GetTokenCount(context.Background(), "user")
+	assert.NoError(t, err)
+	// Input ceil(12/4)=3 + median predicted output 20
+	assert.Equal(t, 23.0, tokens)
+
+	// Falls back to the token estimator without output predictor
+	router.predictors = &fakeOutputPredictorProvider{}
+	routeFairTestRequest(t, router, pods, "other", "test message")
+	tokens, err = tracker.GetTokenCount(context.Background(), "other")
+	assert.NoError(t, err)
+	// Input ceil(12/4)=3 + estimated output ceil(3*1.5)=5
+	assert.Equal(t, 8.0, tokens)
+}
+
+func TestFairVTCRouter_NoUser(t *testing.T) {
+	pods := createFairTestPods(3)
+	router, tracker := newFairTestRouter(RouterVTCFair, NewSimpleCache())
+
+	ctx := types.NewRoutingContext(context.Background(), RouterVTCFair, "model1", "test", "request", "")
+	defer ctx.Delete()
+	addr, err := router.Route(ctx, NewSimplePodList(pods))
+	assert.NoError(t, err)
+	assert.Contains(t, []string{"192.168.1.1:8000", "192.168.1.2:8000", "192.168.1.3:8000"}, addr)
+
+	maxTokens, err := tracker.GetMaxTokenCount(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, tokenTrackerMaxTokens, maxTokens, "requests without user are not tracked")
+}
+
+// TestFairVTCRouter_SyntheticWorkload routes a heavy user and two light users over overloaded pods, and checks that
+// the light users are served by less loaded pods than the heavy user.
+func TestFairVTCRouter_SyntheticWorkload(t *testing.T) {
+	const (
+		numPods       = 4
+		rounds        = 50
+		heavyPerRound = 3
+		completion    = 1 // Requests completed by each pod per round
+	)
+	heavyMessage := strings.Repeat("x", 400)
+	lightMessage := strings.Repeat("x", 40)
+
+	for _, variant := range []types.RoutingAlgorithm{RouterVTCFair, RouterVTCMaxFair, RouterVTCPred50} {
+		t.Run(string(variant), func(t *testing.T) {
+			pods := createFairTestPods(numPods)
+			cache := NewSimpleCache()
+			router, _ := newFairTestRouter(variant, cache)
+
+			load := make(map[string]float64, numPods)
+			setLoad := func(pod *v1.Pod, value float64) {
+				load[pod.Name] = value
+				cache.SetPodMetric(utils.GeneratePodKey("default", pod.Name), "model1", metrics.NumRequestsRunning, value)
+			}
+			servedLoad := map[string]float64{}
+			served := map[string]int{}
+			route := func(user, message string) {
+				pod := routeFairTestRequest(t, router, pods, user, message)
+				servedLoad[user] += load[pod.Name]
+				served[user]++
+				setLoad(pod, load[pod.Name]+1)
+			}
+
+			for round := 0; round < rounds; round++ {
+				for i := 0; i < heavyPerRound; i++ {
+					route("heavy", heavyMessage)
+				}
+				route("light1", lightMessage)
+				route("light2", lightMessage)
+				for _, pod := range pods {
+					setLoad(pod, max(load[pod.Name]-completion, 0))
+				}
+			}
+
+			heavyLoad := servedLoad["heavy"] / float64(served["heavy"])
+			for _, user := range []string{"light1", "light2"} {
+				lightLoad := servedLoad[user] / float64(served[user])
+				t.Logf("average load of serving pods: %s %.2f, heavy %.2f", user, lightLoad, heavyLoad)
+				assert.Less(t, lightLoad, heavyLoad)
+			}
+		})
+	}
+}
+
+func TestFairVTCRouter_WindowParameter(t *testing.T) {
+	pods := createFairTestPods(2)
+	router, defaultTracker := newFairTestRouter(RouterVTCFair, NewSimpleCache())
+
+	ctx := types.NewRoutingContext(context.Background(), RouterVTCFair, "model1", "test message", "request", "user1")
+	defer ctx.Delete()
+	ctx.Parameters = map[string]string{VTCParamWindowSize: "10"}
+	_, err := router.Route(ctx, NewSimplePodList(pods))
+	require.NoError(t, err)
+
+	// Tokens are tracked by the tracker of the window
+	require.Len(t, router.windowTrackers.trackers, 1)
+	tracker := router.windowTrackers.trackers[10]
+	assert.Equal(t, 10*timeUnitDuration[Minutes], tracker.(*InMemorySlidingWindowTokenTracker).windowSize)
+	tokens, err := tracker.GetTokenCount(context.Background(), "user1")
+	require.NoError(t, err)
+	assert.Greater(t, tokens, 0.0)
+	tokens, err = defaultTracker.GetTokenCount(context.Background(), "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, tokens)
+}
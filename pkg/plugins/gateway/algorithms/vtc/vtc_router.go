@@ -19,13 +19,46 @@ package vtc
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/redis/go-redis/v9"
+	"k8s.io/klog/v2"
 
 	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+const (
+	RouterVTCBasic   types.RoutingAlgorithm = "vtc-basic"
+	RouterVTCFair    types.RoutingAlgorithm = "vtc-fair"
+	RouterVTCMaxFair types.RoutingAlgorithm = "vtc-max-fair"
+	RouterVTCPred50  types.RoutingAlgorithm = "vtc-pred-50"
+)
+
+// TokenTrackerType selects where the token counts of users are tracked
+type TokenTrackerType string
+
+const (
+	// InMemoryTokenTracker tracks tokens in the memory of each gateway plugin instance
+	InMemoryTokenTracker TokenTrackerType = "in-memory"
+	// RedisTokenTracker tracks tokens in redis, shared by all gateway plugin instances
+	RedisTokenTracker TokenTrackerType = "redis"
 )
 
-const RouterVTCBasic types.RoutingAlgorithm = "vtc-basic"
+const VTC_TOKEN_TRACKER = "AIBRIX_ROUTER_VTC_TOKEN_TRACKER"
 
-// TODO: add other variants - "vtc-fair", "vtc-max-fair", "vtc-pred-50"
+var tokenTrackerType = TokenTrackerType(utils.LoadEnv(VTC_TOKEN_TRACKER, string(InMemoryTokenTracker)))
+
+// redisClient is the client of the redis token trackers, shared with the gateway plugin.
+var redisClient atomic.Pointer[redis.Client]
+
+// SetRedisClient sets the redis client shared by the redis token trackers, it must be called before the routers are
+// initialized.
+func SetRedisClient(client *redis.Client) {
+	redisClient.Store(client)
+}
 
 // TokenTracker tracks token usage per user
 type TokenTracker interface {
@@ -49,6 +82,61 @@ type VTCConfig struct {
 	Variant           types.RoutingAlgorithm
 	InputTokenWeight  float64
 	OutputTokenWeight float64
+	TokenTracker      TokenTrackerType
+	// WindowSize is the token tracker window in the configured time units, 0 uses AIBRIX_ROUTER_VTC_TOKEN_TRACKER_WINDOW_SIZE.
+	WindowSize int
+}
+
+// windowSizeOf returns the token tracker window size of the config in time units.
+func windowSizeOf(config *VTCConfig) int {
+	if config != nil && config.WindowSize > 0 {
+		return config.WindowSize
+	}
+	return tokenTrackerWindowSize
+}
+
+// maxWindowTrackers bounds the number of window sizes set by router parameters that get their own token tracker,
+// requests of further window sizes use the default window.
+const maxWindowTrackers = 8
+
+// windowTokenTrackers are the token trackers of the window sizes set by the VTCParamWindowSize router parameter,
+// created on demand and bounded by maxWindowTrackers. Like the default tracker, a tracker is shared by the models
+// routed with its window size.
+type windowTokenTrackers struct {
+	mu       sync.Mutex
+	trackers map[int]TokenTracker // window size -> TokenTracker
+}
+
+// trackerFor returns the token tracker of the window size set by the router parameters of the request, or
+// defaultTracker of the config window.
+func (w *windowTokenTrackers) trackerFor(ctx *types.RoutingContext, defaultTracker TokenTracker, config *VTCConfig) TokenTracker {
+	size := ctx.ParamInt(VTCParamWindowSize, 0)
+	if size <= 0 || config == nil || size == windowSizeOf(config) {
+		return defaultTracker
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if tracker, ok := w.trackers[size]; ok {
+		return tracker
+	}
+	if len(w.trackers) >= maxWindowTrackers {
+		klog.V(4).InfoS("too many token tracker windows, using the default window", "windowSize", size, "max", maxWindowTrackers)
+		return defaultTracker
+	}
+
+	windowConfig := *config
+	windowConfig.WindowSize = size
+	tracker, err := NewTokenTracker(&windowConfig)
+	if err != nil {
+		klog.ErrorS(err, "failed to create token tracker, using the default window", "windowSize", size)
+		return defaultTracker
+	}
+	if w.trackers == nil {
+		w.trackers = make(map[int]TokenTracker)
+	}
+	w.trackers[size] = tracker
+	return tracker
 }
 
 func DefaultVTCConfig() VTCConfig {
@@ -57,6 +145,28 @@ func DefaultVTCConfig() VTCConfig {
 		Variant:           RouterVTCBasic,
 		InputTokenWeight:  inputTokenWeight,
 		OutputTokenWeight: outputTokenWeight,
+		TokenTracker:      tokenTrackerType,
+	}
+}
+
+// NewTokenTracker creates the token tracker selected by the config
+func NewTokenTracker(config *VTCConfig) (TokenTracker, error) {
+	switch config.TokenTracker {
+	case InMemoryTokenTracker, "":
+		return NewInMemorySlidingWindowTokenTracker(config), nil
+	case RedisTokenTracker:
+		var opts []RedisTokenTrackerOption
+		if config.WindowSize > 0 {
+			// Totals depend on the window, trackers of other windows must not share the keys.
+			opts = append(opts, WithRedisKeyPrefix(fmt.Sprintf("%s:window-%d", defaultRedisTokenTrackerKeyPrefix, config.WindowSize)))
+		}
+		client := redisClient.Load()
+		if client == nil {
+			return nil, fmt.Errorf("redis client is not set for the %s token tracker", RedisTokenTracker)
+		}
+		return NewRedisSlidingWindowTokenTracker(client, config, opts...), nil
+	default:
+		return nil, fmt.Errorf("unsupported token tracker: %s", config.TokenTracker)
 	}
 }
 
@@ -64,6 +174,35 @@ func NewVTCBasicRouter() (types.Router, error) {
 	config := DefaultVTCConfig()
 	configPtr := &config
 	var tokenEstimator TokenEstimator = NewSimpleTokenEstimator()
-	var tokenTracker TokenTracker = NewInMemorySlidingWindowTokenTracker(configPtr)
+	tokenTracker, err := NewTokenTracker(configPtr)
+	if err != nil {
+		return nil, err
+	}
 	return NewBasicVTCRouter(tokenTracker, tokenEstimator, configPtr)
 }
+
+func NewVTCFairRouter() (types.Router, error) {
+	return newFairVTCRouter(RouterVTCFair)
+}
+
+func NewVTCMaxFairRouter() (types.Router, error) {
+	return newFairVTCRouter(RouterVTCMaxFair)
+}
+
+func NewVTCPred50Router() (types.Router, error) {
+	return newFairVTCRouter(RouterVTCPred50)
+}
+
+func newFairVTCRouter(variant types.RoutingAlgorithm) (types.Router, error) {
+	config := DefaultVTCConfig()
+	config.Variant = variant
+	configPtr := &config
+
+	var tokenEstimator TokenEstimator = NewSimpleTokenEstimator()
+	tokenTracker, err := NewTokenTracker(configPtr)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFairVTCRouter(tokenTracker, tokenEstimator, configPtr)
+}
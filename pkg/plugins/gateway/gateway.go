@@ -21,7 +21,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -34,9 +36,15 @@ import (
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
 	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/vllm-project/aibrix/pkg/cache"
+	"github.com/vllm-project/aibrix/pkg/client/clientset/versioned"
 	"github.com/vllm-project/aibrix/pkg/metrics"
+	"github.com/vllm-project/aibrix/pkg/plugins/gateway/activator"
 	routing "github.com/vllm-project/aibrix/pkg/plugins/gateway/algorithms"
+	"github.com/vllm-project/aibrix/pkg/plugins/gateway/algorithms/vtc"
+	"github.com/vllm-project/aibrix/pkg/plugins/gateway/outlier"
 	"github.com/vllm-project/aibrix/pkg/plugins/gateway/ratelimiter"
+	"github.com/vllm-project/aibrix/pkg/plugins/gateway/responsecache"
+	"github.com/vllm-project/aibrix/pkg/plugins/gateway/routingpolicy"
 	"github.com/vllm-project/aibrix/pkg/types"
 	"github.com/vllm-project/aibrix/pkg/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -48,34 +56,88 @@ const (
 	defaultAIBrixNamespace = "aibrix-system"
 )
 
+var (
+	rateLimiterType        = utils.LoadEnv("AIBRIX_GATEWAY_RATE_LIMITER", ratelimiter.FixedWindow)
+	rateLimiterWindow      = utils.LoadEnvDuration("AIBRIX_GATEWAY_RATE_LIMITER_WINDOW", time.Minute)
+	rateLimiterBurstFactor = utils.LoadEnvFloat("AIBRIX_GATEWAY_RATE_LIMITER_BURST_FACTOR", 1.0)
+)
+
 type Server struct {
-	redisClient         *redis.Client
-	ratelimiter         ratelimiter.RateLimiter
-	client              kubernetes.Interface
-	gatewayClient       gatewayapi.Interface
-	requestCountTracker map[string]int
-	cache               cache.Cache
-	metricsServer       *metrics.Server
+	redisClient          *redis.Client
+	ratelimiter          ratelimiter.RateLimiter
+	client               kubernetes.Interface
+	gatewayClient        gatewayapi.Interface
+	requestCountTracker  map[string]int
+	cache                cache.Cache
+	metricsServer        *metrics.Server
+	activator            *activator.Activator
+	outlierDetector      *outlier.Detector
+	routingPolicies      *routingpolicy.Store
+	responseCache        *responsecache.Cache
+	pendingResponses     sync.Map // request id -> *pendingResponse
+	streamUsageInjection bool     // Ask engines for the usage of streaming chat completions, see injectStreamUsage.
+	usageInjected        sync.Map // request id -> *streamUsageFilter, set if the gateway asked the engine for stream usage
+	stopCh               chan struct{}
 }
 
-func NewServer(redisClient *redis.Client, client kubernetes.Interface, gatewayClient gatewayapi.Interface) *Server {
+func NewServer(redisClient *redis.Client, client kubernetes.Interface, gatewayClient gatewayapi.Interface, aibrixClient versioned.Interface) *Server {
 	c, err := cache.Get()
 	if err != nil {
 		panic(err)
 	}
-	r := ratelimiter.NewRedisAccountRateLimiter("aibrix", redisClient, 1*time.Minute)
+	r, err := ratelimiter.NewRateLimiter(rateLimiterType, "aibrix", redisClient, rateLimiterWindow, rateLimiterBurstFactor)
+	if err != nil {
+		panic(err)
+	}
 
-	// Initialize the routers
+	// Initialize the routers, token trackers of the vtc routers share the redis client.
+	vtc.SetRedisClient(redisClient)
 	routing.Init()
 
+	stopCh := make(chan struct{})
+	var a *activator.Activator
+	if activatorEnabled {
+		a = activator.NewActivator(c, activator.NewPodAutoscalerSignaler(aibrixClient, stopCh), activator.Options{
+			QueueSize: activatorQueueSize,
+			Timeout:   activatorTimeout,
+		})
+		klog.InfoS("activator enabled", "queueSize", activatorQueueSize, "timeout", activatorTimeout)
+	}
+	var d *outlier.Detector
+	if outlierDetectionEnabled {
+		d = outlier.NewDetector(outlierOptions)
+		klog.InfoS("outlier detection enabled", "options", outlierOptions)
+	}
+	var policies *routingpolicy.Store
+	if routingPolicyEnabled && aibrixClient != nil {
+		policies = routingpolicy.NewStore()
+		if err := policies.Start(aibrixClient, stopCh); err != nil {
+			panic(err)
+		}
+		klog.InfoS("routing policies enabled")
+	}
+	var rc *responsecache.Cache
+	if responseCacheStore != "" {
+		if rc, err = responsecache.NewCache(responseCacheStore, redisClient, responseCacheOptions); err != nil {
+			panic(err)
+		}
+		klog.InfoS("response cache enabled", "store", responseCacheStore, "options", responseCacheOptions)
+	}
+
 	return &Server{
-		redisClient:         redisClient,
-		ratelimiter:         r,
-		client:              client,
-		gatewayClient:       gatewayClient,
-		requestCountTracker: map[string]int{},
-		cache:               c,
-		metricsServer:       nil,
+		redisClient:          redisClient,
+		ratelimiter:          r,
+		client:               client,
+		gatewayClient:        gatewayClient,
+		requestCountTracker:  map[string]int{},
+		cache:                c,
+		metricsServer:        nil,
+		activator:            a,
+		outlierDetector:      d,
+		routingPolicies:      policies,
+		responseCache:        rc,
+		streamUsageInjection: streamUsageInjectionEnabled,
+		stopCh:               stopCh,
 	}
 }
 
@@ -86,12 +148,15 @@ func (s *Server) Process(srv extProcPb.ExternalProcessor_ProcessServer) error {
 	var model string
 	var routerCtx *types.RoutingContext
 	var stream, isRespError bool
+	var retry retryState
 	ctx := srv.Context()
 	requestID := uuid.New().String()
 	completed := false
 	resp := &extProcPb.ProcessingResponse{}
 
 	klog.InfoS("processing request", "requestID", requestID)
+	defer s.discardCachedResponse(requestID)
+	defer s.forgetStreamUsage(requestID)
 
 	for {
 		select {
@@ -123,6 +188,10 @@ func (s *Server) Process(srv extProcPb.ExternalProcessor_ProcessServer) error {
 			resp, model, routerCtx, stream, traceTerm = s.HandleRequestBody(ctx, requestID, req, user)
 
 		case *extProcPb.ProcessingRequest_ResponseHeaders:
+			if code := getResponseStatusCode(req); code != 200 && s.canRetry(routerCtx, &retry, model, code, false) {
+				resp, completed = s.HandleRetry(ctx, requestID, user, rpm, model, stream, traceTerm, &retry, code, nil, false)
+				break
+			}
 			resp, isRespError, respErrorCode = s.HandleResponseHeaders(ctx, requestID, model, req)
 			if isRespError && respErrorCode == 500 {
 				// for error code 500, ProcessingRequest_ResponseBody is not invoked
@@ -135,11 +204,19 @@ func (s *Server) Process(srv extProcPb.ExternalProcessor_ProcessServer) error {
 			}
 
 		case *extProcPb.ProcessingRequest_ResponseBody:
-			if isRespError {
-				resp = s.responseErrorProcessing(ctx, resp, respErrorCode, model, requestID,
-					string(req.Request.(*extProcPb.ProcessingRequest_ResponseBody).ResponseBody.GetBody()))
+			body := req.Request.(*extProcPb.ProcessingRequest_ResponseBody).ResponseBody.GetBody()
+			if retry.replayed {
+				// The response has been replaced by a retry, drop the rest of the failed one.
+				resp = clearResponseBody()
+			} else if stream && !isRespError && !completed && hasStreamError(body) &&
+				s.canRetry(routerCtx, &retry, model, http.StatusInternalServerError, true) {
+				resp, completed = s.HandleRetry(ctx, requestID, user, rpm, model, stream, traceTerm, &retry,
+					http.StatusInternalServerError, body, true)
+			} else if isRespError {
+				resp = s.responseErrorProcessing(ctx, resp, respErrorCode, model, requestID, string(body))
 			} else {
 				resp, completed = s.HandleResponseBody(ctx, requestID, req, user, rpm, model, stream, traceTerm, completed)
+				retry.relayed = true
 			}
 		default:
 			klog.Infof("Unknown Request type %+v\n", v)
@@ -173,12 +250,20 @@ func (s *Server) selectTargetPod(ctx *types.RoutingContext, pods types.PodList)
 	if len(readyPods) == 0 {
 		return "", fmt.Errorf("no ready pods for routing")
 	}
+	if s.outlierDetector != nil {
+		readyPods = s.outlierDetector.Filter(readyPods)
+	}
 	if len(readyPods) == 1 {
 		ctx.SetTargetPod(readyPods[0])
+		s.onPodRouted(ctx)
 		return ctx.TargetAddress(), nil
 	}
 
-	return router.Route(ctx, &utils.PodArray{Pods: readyPods})
+	targetPodIP, err := router.Route(ctx, &utils.PodArray{Pods: readyPods})
+	if err == nil {
+		s.onPodRouted(ctx)
+	}
+	return targetPodIP, err
 }
 
 // validateHTTPRouteStatus checks if httproute object exists and validates its conditions are true
@@ -227,6 +312,9 @@ func (s *Server) StartMetricsServer(addr string) error {
 }
 
 func (s *Server) Shutdown() {
+	if s.stopCh != nil {
+		close(s.stopCh)
+	}
 	if s.metricsServer != nil {
 		if err := s.metricsServer.Stop(); err != nil {
 			klog.ErrorS(err, "Error stopping metrics server")
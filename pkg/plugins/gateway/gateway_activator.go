@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/plugins/gateway/activator"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+var (
+	// activatorEnabled holds the requests of models scaled to zero until they are scaled up.
+	activatorEnabled   = utils.LoadEnvBool(EnvActivatorEnabled, false)
+	activatorQueueSize = utils.LoadEnvInt("AIBRIX_GATEWAY_ACTIVATOR_QUEUE_SIZE", activator.DefaultQueueSize)
+	activatorTimeout   = utils.LoadEnvDuration("AIBRIX_GATEWAY_ACTIVATOR_TIMEOUT", activator.DefaultTimeout)
+)
+
+// needsActivation returns true if the model has no routable pod and can be scaled from zero.
+func (s *Server) needsActivation(model string) bool {
+	if s.activator == nil {
+		return false
+	}
+	podsArr, err := s.cache.ListPodsByModel(model)
+	if err == nil && podsArr != nil && utils.CountRoutablePods(podsArr.All()) > 0 {
+		return false
+	}
+	return s.activator.Activatable(model)
+}
+
+// activate holds the request until the model has a routable pod, an error response is returned if the
+// activation queue of the model is full or if the model is not scaled up in time.
+func (s *Server) activate(ctx context.Context, requestID, model string) *extProcPb.ProcessingResponse {
+	start := time.Now()
+	klog.InfoS("waiting for model to scale from zero", "requestID", requestID, "model", model, "waiting", s.activator.Waiting(model))
+
+	err := s.activator.Activate(ctx, model)
+	if err == nil {
+		klog.InfoS("model scaled from zero", "requestID", requestID, "model", model, "activationDuration", time.Since(start))
+		return nil
+	}
+
+	klog.ErrorS(err, "model activation failed", "requestID", requestID, "model", model, "activationDuration", time.Since(start))
+	code := envoyTypePb.StatusCode_ServiceUnavailable
+	if errors.Is(err, activator.ErrActivationTimeout) {
+		code = envoyTypePb.StatusCode_GatewayTimeout
+	}
+	return buildErrorResponse(code, err.Error(), HeaderErrorActivation, "true")
+}
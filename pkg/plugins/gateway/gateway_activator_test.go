@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/vllm-project/aibrix/pkg/plugins/gateway/activator"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+type testSignaler struct{}
+
+func (testSignaler) Activatable(model string) bool            { return model == "zero-model" }
+func (testSignaler) Signal(_ context.Context, _ string) error { return nil }
+
+func Test_activate(t *testing.T) {
+	mockCache := new(MockCache)
+	mockCache.On("ListPodsByModel", "zero-model").Return(&utils.PodArray{Pods: []*v1.Pod{}}, nil)
+	mockCache.On("ListPodsByModel", "other-model").Return(&utils.PodArray{Pods: []*v1.Pod{}}, nil)
+	s := &Server{cache: mockCache}
+
+	// Without activator, requests are never held.
+	assert.False(t, s.needsActivation("zero-model"))
+
+	s.activator = activator.NewActivator(mockCache, testSignaler{}, activator.Options{Timeout: 50 * time.Millisecond, PollInterval: 10 * time.Millisecond})
+	assert.True(t, s.needsActivation("zero-model"))
+	assert.False(t, s.needsActivation("other-model"))
+
+	resp := s.activate(context.Background(), "r1", "zero-model")
+	immediate := resp.GetImmediateResponse()
+	assert.Equal(t, envoyTypePb.StatusCode_GatewayTimeout, immediate.GetStatus().GetCode())
+	assert.Equal(t, HeaderErrorActivation, immediate.GetHeaders().GetSetHeaders()[0].GetHeader().GetKey())
+	assert.Contains(t, immediate.GetBody(), "timed out waiting for model to scale from zero")
+}
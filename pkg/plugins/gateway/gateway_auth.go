@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+var (
+	// apiKeyAuthEnabled requires every request to carry an api key linked to a user.
+	apiKeyAuthEnabled = utils.LoadEnvBool(EnvAPIKeyAuthEnabled, false)
+	// userHeaderEnabled trusts the raw user header, any client can then impersonate any user.
+	userHeaderEnabled = utils.LoadEnvBool(EnvUserHeaderEnabled, false)
+)
+
+// authenticate resolves the user of the request. With api key auth enabled, the user is resolved from
+// the bearer token, and requests without a valid key are rejected with 401. The user header is only
+// used when explicitly enabled. An empty user disables rate limiting for the request.
+func (s *Server) authenticate(ctx context.Context, requestID, authorization, username string) (utils.User, *extProcPb.ProcessingResponse) {
+	if apiKeyAuthEnabled {
+		if token, ok := utils.ParseBearerToken(authorization); ok {
+			user, err := utils.GetUserByAPIKey(ctx, token, s.redisClient)
+			if err == nil {
+				return user, nil
+			}
+			if errors.Is(err, utils.ErrAPIKeyNotFound) || errors.Is(err, utils.ErrAPIKeyExpired) || errors.Is(err, utils.ErrAPIKeyRevoked) {
+				klog.InfoS("rejected api key", "requestID", requestID, "reason", err.Error())
+				return utils.User{}, generateAuthErrorResponse("Incorrect API key provided: "+redactAPIKey(token)+".", "invalid_api_key")
+			}
+			klog.ErrorS(err, "unable to process api key", "requestID", requestID)
+			return utils.User{}, generateErrorResponse(
+				envoyTypePb.StatusCode_InternalServerError,
+				[]*configPb.HeaderValueOption{{Header: &configPb.HeaderValue{
+					Key: HeaderErrorUser, RawValue: []byte("true"),
+				}}},
+				err.Error())
+		}
+
+		if !userHeaderEnabled || username == "" {
+			return utils.User{}, generateAuthErrorResponse("You didn't provide an API key. You need to provide your API key "+
+				"in an Authorization header using Bearer auth (i.e. Authorization: Bearer YOUR_KEY).", "")
+		}
+	}
+
+	if username == "" {
+		return utils.User{}, nil
+	}
+	if !userHeaderEnabled {
+		klog.V(4).InfoS("ignoring user header, it is not enabled", "requestID", requestID, "username", username)
+		return utils.User{}, nil
+	}
+
+	user, err := utils.GetUser(ctx, utils.User{Name: username}, s.redisClient)
+	if err != nil {
+		klog.ErrorS(err, "unable to process user info", "requestID", requestID, "username", username)
+		return utils.User{}, generateErrorResponse(
+			envoyTypePb.StatusCode_InternalServerError,
+			[]*configPb.HeaderValueOption{{Header: &configPb.HeaderValue{
+				Key: HeaderErrorUser, RawValue: []byte("true"),
+			}}},
+			err.Error())
+	}
+	return user, nil
+}
+
+// generateAuthErrorResponse returns a 401 response with the error body of the OpenAI API.
+func generateAuthErrorResponse(message, code string) *extProcPb.ProcessingResponse {
+	errBody := map[string]interface{}{
+		"message": message,
+		"type":    "invalid_request_error",
+		"param":   nil,
+		"code":    nil,
+	}
+	if code != "" {
+		errBody["code"] = code
+	}
+	body, _ := json.Marshal(map[string]interface{}{"error": errBody})
+
+	return &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_ImmediateResponse{
+			ImmediateResponse: &extProcPb.ImmediateResponse{
+				Status: &envoyTypePb.HttpStatus{
+					Code: envoyTypePb.StatusCode_Unauthorized,
+				},
+				Headers: &extProcPb.HeaderMutation{
+					SetHeaders: []*configPb.HeaderValueOption{
+						{Header: &configPb.HeaderValue{Key: HeaderErrorAuthentication, RawValue: []byte("true")}},
+						{Header: &configPb.HeaderValue{Key: "Content-Type", Value: "application/json"}},
+					},
+				},
+				Body: string(body),
+			},
+		},
+	}
+}
+
+// redactAPIKey keeps only the head and tail of the key, like the OpenAI API does.
+func redactAPIKey(key string) string {
+	if len(key) <= 8 {
+		return "****"
+	}
+	return key[:3] + "****" + key[len(key)-4:]
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+func Test_authenticate(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+	server := &Server{redisClient: client}
+
+	alice := utils.User{Name: "alice", Rpm: 10, Tpm: 100}
+	bob := utils.User{Name: "bob", Rpm: 20, Tpm: 200}
+	assert.NoError(t, utils.SetUser(ctx, alice, client))
+	assert.NoError(t, utils.SetUser(ctx, bob, client))
+	assert.NoError(t, utils.SetAPIKey(ctx, utils.APIKey{Hash: utils.HashAPIKey("sk-alice"), User: "alice"}, client))
+	assert.NoError(t, utils.SetAPIKey(ctx, utils.APIKey{Hash: utils.HashAPIKey("sk-revoked"), User: "alice"}, client))
+	assert.NoError(t, utils.RevokeAPIKey(ctx, utils.HashAPIKey("sk-revoked"), client))
+	expiresAt := time.Now().Add(-time.Minute)
+	expired, _ := json.Marshal(utils.APIKey{Hash: utils.HashAPIKey("sk-expired"), User: "alice", ExpiresAt: &expiresAt})
+	assert.NoError(t, client.Set(ctx, "aibrix-apikeys/"+utils.HashAPIKey("sk-expired"), expired, 0).Err())
+
+	tests := []struct {
+		name          string
+		apiKeyAuth    bool
+		userHeader    bool
+		authorization string
+		username      string
+		expectUser    string
+		expectCode    string // raw JSON of the error code
+	}{
+		{
+			name:       "user header is ignored by default",
+			username:   "bob",
+			expectUser: "",
+		},
+		{
+			name:       "user header is trusted when enabled",
+			userHeader: true,
+			username:   "bob",
+			expectUser: "bob",
+		},
+		{
+			name:          "bearer token is ignored when api key auth is disabled",
+			authorization: "Bearer any_key",
+			expectUser:    "",
+		},
+		{
+			name:          "api key resolves the user",
+			apiKeyAuth:    true,
+			authorization: "Bearer sk-alice",
+			username:      "bob",
+			expectUser:    "alice",
+		},
+		{
+			name:          "unknown api key is rejected",
+			apiKeyAuth:    true,
+			authorization: "Bearer sk-unknown",
+			expectCode:    `"invalid_api_key"`,
+		},
+		{
+			name:          "revoked api key is rejected",
+			apiKeyAuth:    true,
+			authorization: "Bearer sk-revoked",
+			expectCode:    `"invalid_api_key"`,
+		},
+		{
+			name:          "expired api key is rejected",
+			apiKeyAuth:    true,
+			authorization: "Bearer sk-expired",
+			expectCode:    `"invalid_api_key"`,
+		},
+		{
+			name:       "missing api key is rejected",
+			apiKeyAuth: true,
+			username:   "bob",
+			expectCode: "null",
+		},
+		{
+			name:       "user header is used without api key when enabled",
+			apiKeyAuth: true,
+			userHeader: true,
+			username:   "bob",
+			expectUser: "bob",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyAuthEnabled, userHeaderEnabled = tt.apiKeyAuth, tt.userHeader
+			defer func() { apiKeyAuthEnabled, userHeaderEnabled = false, false }()
+
+			user, resp := server.authenticate(ctx, "r1", tt.authorization, tt.username)
+			if tt.expectCode == "" {
+				assert.Nil(t, resp)
+				assert.Equal(t, tt.expectUser, user.Name)
+				return
+			}
+
+			immediate := resp.GetImmediateResponse()
+			assert.Equal(t, envoyTypePb.StatusCode_Unauthorized, immediate.GetStatus().GetCode())
+			var body map[string]map[string]json.RawMessage
+			assert.NoError(t, json.Unmarshal([]byte(immediate.GetBody()), &body))
+			assert.Equal(t, `"invalid_request_error"`, string(body["error"]["type"]))
+			assert.Equal(t, tt.expectCode, string(body["error"]["code"]))
+			assert.NotContains(t, immediate.GetBody(), "sk-unknown")
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/vllm-project/aibrix/pkg/types"
+)
+
+// parseRequestTimeout parses the x-request-timeout-ms header, the timeout must be a positive number of milliseconds.
+func parseRequestTimeout(value string) (time.Duration, error) {
+	ms, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || ms <= 0 {
+		return 0, fmt.Errorf("invalid request timeout %q, expected a positive number of milliseconds", value)
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// remainingTimeoutMs returns the time left before the deadline of the request in milliseconds, rounded up so that
+// the engine never receives a zero budget, and false if the request has no deadline.
+func remainingTimeoutMs(routingCtx *types.RoutingContext, currentTime time.Time) (string, bool) {
+	remaining, ok := routingCtx.Remaining(currentTime)
+	if !ok {
+		return "", false
+	}
+	ms := (remaining + time.Millisecond - 1) / time.Millisecond
+	if ms < 1 {
+		ms = 1
+	}
+	return strconv.FormatInt(int64(ms), 10), true
+}
+
+// buildRequestTimeoutHeaders forwards the remaining budget of the request to the engine.
+func buildRequestTimeoutHeaders(headers []*configPb.HeaderValueOption, routingCtx *types.RoutingContext) []*configPb.HeaderValueOption {
+	if remaining, ok := remainingTimeoutMs(routingCtx, time.Now()); ok {
+		headers = buildEnvoyProxyHeaders(headers, HeaderRequestTimeout, remaining)
+	}
+	return headers
+}
+
+func requestTimeoutResponse(routingCtx *types.RoutingContext) *extProcPb.ProcessingResponse {
+	return buildErrorResponse(envoyTypePb.StatusCode_GatewayTimeout,
+		fmt.Sprintf("request %s exceeded its deadline before dispatching", routingCtx.RequestID),
+		HeaderErrorRequestTimeout, "true", HeaderRequestID, routingCtx.RequestID)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vllm-project/aibrix/pkg/types"
+)
+
+func TestParseRequestTimeout(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{value: "1500", expected: 1500 * time.Millisecond},
+		{value: " 20 ", expected: 20 * time.Millisecond},
+		{value: "0", wantErr: true},
+		{value: "-1", wantErr: true},
+		{value: "1s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			timeout, err := parseRequestTimeout(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, timeout)
+		})
+	}
+}
+
+func TestRemainingTimeoutMs(t *testing.T) {
+	routingCtx := types.NewRoutingContext(context.Background(), "", "model", "", "request", "")
+	defer routingCtx.Delete()
+
+	_, ok := remainingTimeoutMs(routingCtx, routingCtx.RequestTime)
+	assert.False(t, ok)
+	assert.Empty(t, buildRequestTimeoutHeaders(nil, routingCtx))
+
+	routingCtx.SetTimeout(time.Second)
+	remaining, ok := remainingTimeoutMs(routingCtx, routingCtx.RequestTime.Add(300*time.Millisecond))
+	assert.True(t, ok)
+	assert.Equal(t, "700", remaining)
+
+	// Partial milliseconds are rounded up, and the engine never receives a zero budget.
+	remaining, _ = remainingTimeoutMs(routingCtx, routingCtx.RequestTime.Add(999500*time.Microsecond))
+	assert.Equal(t, "1", remaining)
+	remaining, _ = remainingTimeoutMs(routingCtx, routingCtx.RequestTime.Add(2*time.Second))
+	assert.Equal(t, "1", remaining)
+
+	headers := buildRequestTimeoutHeaders(nil, routingCtx)
+	assert.Len(t, headers, 1)
+	assert.Equal(t, HeaderRequestTimeout, headers[0].Header.Key)
+}
+
+func TestRequestTimeoutResponse(t *testing.T) {
+	routingCtx := types.NewRoutingContext(context.Background(), "", "model", "", "request", "")
+	defer routingCtx.Delete()
+
+	resp := requestTimeoutResponse(routingCtx)
+	immediate := resp.Response.(*extProcPb.ProcessingResponse_ImmediateResponse).ImmediateResponse
+	assert.Equal(t, envoyTypePb.StatusCode_GatewayTimeout, immediate.Status.Code)
+	assert.Equal(t, HeaderErrorRequestTimeout, immediate.Headers.SetHeaders[0].Header.Key)
+}
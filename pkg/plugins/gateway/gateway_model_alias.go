@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"encoding/json"
+	"math/rand"
+
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"k8s.io/klog/v2"
+
+	modelv1alpha1 "github.com/vllm-project/aibrix/api/model/v1alpha1"
+	"github.com/vllm-project/aibrix/pkg/types"
+)
+
+// resolveModelAlias sends a request to a virtual model name to one of the backends of its ModelAlias, chosen
+// by weight. The model field of the request body is rewritten so that the backend serves the request, and the
+// request is routed and counted as a request to the backend. Aliases are not resolved recursively.
+func (s *Server) resolveModelAlias(routingCtx *types.RoutingContext) *extProcPb.ProcessingResponse {
+	backends, ok := s.cache.GetModelAlias(routingCtx.Model)
+	if !ok {
+		return nil
+	}
+	model := selectModelAliasBackend(backends, rand.Intn)
+	if model == "" {
+		klog.ErrorS(nil, "model alias has no backend with a positive weight", "requestID", routingCtx.RequestID, "alias", routingCtx.Model)
+		return buildErrorResponse(envoyTypePb.StatusCode_ServiceUnavailable, "model alias has no backend", HeaderErrorNoModelBackends, routingCtx.Model)
+	}
+
+	body, err := rewriteRequestModel(routingCtx.ReqBody, model)
+	if err != nil {
+		klog.ErrorS(err, "failed to rewrite the model of the request body", "requestID", routingCtx.RequestID, "alias", routingCtx.Model, "model", model)
+		return buildErrorResponse(envoyTypePb.StatusCode_BadRequest, "error processing request body", HeaderErrorRequestBodyProcessing, "true")
+	}
+	klog.V(4).InfoS("resolved model alias", "requestID", routingCtx.RequestID, "alias", routingCtx.Model, "model", model)
+
+	routingCtx.ModelAlias = routingCtx.Model
+	routingCtx.Model = model
+	routingCtx.ReqBody = body
+	return nil
+}
+
+// selectModelAliasBackend returns a backend model with a probability proportional to its weight, intn returns
+// a random number in [0, n). It returns an empty string if no backend has a positive weight.
+func selectModelAliasBackend(backends []modelv1alpha1.ModelAliasBackend, intn func(n int) int) string {
+	total := 0
+	for _, backend := range backends {
+		total += int(max(backend.Weight, 0))
+	}
+	if total == 0 {
+		return ""
+	}
+
+	n := intn(total)
+	for _, backend := range backends {
+		if backend.Weight <= 0 {
+			continue
+		}
+		if n < int(backend.Weight) {
+			return backend.ModelName
+		}
+		n -= int(backend.Weight)
+	}
+	return ""
+}
+
+// rewriteRequestModel replaces the model field of the request body, keeping the other fields as is.
+func rewriteRequestModel(body []byte, model string) ([]byte, error) {
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(body, &jsonMap); err != nil {
+		return nil, err
+	}
+	value, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+	jsonMap["model"] = value
+	return json.Marshal(jsonMap)
+}
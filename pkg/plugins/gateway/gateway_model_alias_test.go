@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	modelv1alpha1 "github.com/vllm-project/aibrix/api/model/v1alpha1"
+	routing "github.com/vllm-project/aibrix/pkg/plugins/gateway/algorithms"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+func TestSelectModelAliasBackend(t *testing.T) {
+	backends := []modelv1alpha1.ModelAliasBackend{
+		{ModelName: "stable", Weight: 90},
+		{ModelName: "disabled", Weight: 0},
+		{ModelName: "candidate", Weight: 10},
+	}
+	counts := map[string]int{}
+	for n := 0; n < 100; n++ {
+		counts[selectModelAliasBackend(backends, func(total int) int {
+			assert.Equal(t, 100, total)
+			return n
+		})]++
+	}
+	assert.Equal(t, map[string]int{"stable": 90, "candidate": 10}, counts)
+
+	noWeight := []modelv1alpha1.ModelAliasBackend{{ModelName: "disabled", Weight: 0}}
+	assert.Empty(t, selectModelAliasBackend(noWeight, func(int) int { return 0 }))
+}
+
+func TestRewriteRequestModel(t *testing.T) {
+	body, err := rewriteRequestModel([]byte(`{"model":"chat-default","messages":[{"role":"user","content":"hi"}],"stream":true}`), "llama-3-70b")
+	require.NoError(t, err)
+
+	var request map[string]any
+	require.NoError(t, json.Unmarshal(body, &request))
+	assert.Equal(t, "llama-3-70b", request["model"])
+	assert.Equal(t, true, request["stream"])
+	assert.Len(t, request["messages"], 1)
+
+	_, err = rewriteRequestModel([]byte(`not json`), "llama-3-70b")
+	assert.Error(t, err)
+}
+
+func TestHandleRequestBodyWithModelAlias(t *testing.T) {
+	routing.Init()
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "llama"},
+		Status: v1.PodStatus{
+			PodIP:      "1.2.3.4",
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+		},
+	}
+	mockCache := &MockCache{aliases: map[string][]modelv1alpha1.ModelAliasBackend{
+		"chat-default": {{ModelName: "llama-3-70b", Weight: 1}},
+		"chat-empty":   {{ModelName: "llama-3-70b", Weight: 0}},
+	}}
+	mockCache.On("HasModel", "llama-3-70b").Return(true)
+	mockCache.On("ListPodsByModel", "llama-3-70b").Return(&utils.PodArray{Pods: []*v1.Pod{pod}}, nil)
+	mockCache.On("AddRequestCount", mock.Anything, mock.Anything, "llama-3-70b").Return(int64(1))
+	server := &Server{cache: mockCache}
+
+	handle := func(model string) (*extProcPb.ProcessingResponse, string, *types.RoutingContext) {
+		req := &extProcPb.ProcessingRequest{
+			Request: &extProcPb.ProcessingRequest_RequestBody{
+				RequestBody: &extProcPb.HttpBody{
+					Body: []byte(`{"model": "` + model + `", "messages": [{"role": "user", "content": "test"}]}`),
+				},
+			},
+		}
+		routingCtx := types.NewRoutingContext(context.Background(), "random", model, "", "test-request-id", "")
+		routingCtx.ReqPath = PathChatCompletions
+		resp, resolved, routingCtx, _, _ := server.HandleRequestBody(routingCtx, "test-request-id", req, utils.User{})
+		return resp, resolved, routingCtx
+	}
+
+	// Requests to the alias are routed, counted and forwarded as requests to the backend
+	resp, model, routingCtx := handle("chat-default")
+	defer routingCtx.Delete()
+	assert.Equal(t, "llama-3-70b", model)
+	assert.Equal(t, "llama-3-70b", routingCtx.Model)
+	assert.Equal(t, "chat-default", routingCtx.ModelAlias)
+	var request map[string]any
+	require.NoError(t, json.Unmarshal(resp.GetRequestBody().GetResponse().GetBodyMutation().GetBody(), &request))
+	assert.Equal(t, "llama-3-70b", request["model"])
+	mockCache.AssertExpectations(t)
+
+	// Aliases without a backend to send requests to are rejected
+	resp, _, routingCtx = handle("chat-empty")
+	defer routingCtx.Delete()
+	assert.Equal(t, envoyTypePb.StatusCode_ServiceUnavailable, resp.GetImmediateResponse().GetStatus().GetCode())
+	assert.Empty(t, routingCtx.ModelAlias)
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"github.com/vllm-project/aibrix/pkg/plugins/gateway/outlier"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+var (
+	// outlierDetectionEnabled ejects the pods returning server errors from routing.
+	outlierDetectionEnabled = utils.LoadEnvBool(EnvOutlierDetectionEnabled, false)
+	outlierOptions          = outlier.Options{
+		ConsecutiveErrors: utils.LoadEnvInt("AIBRIX_GATEWAY_OUTLIER_CONSECUTIVE_ERRORS", outlier.DefaultConsecutiveErrors),
+		ErrorRate:         utils.LoadEnvFloat("AIBRIX_GATEWAY_OUTLIER_ERROR_RATE", outlier.DefaultErrorRate),
+		MinRequests:       utils.LoadEnvInt("AIBRIX_GATEWAY_OUTLIER_MIN_REQUESTS", outlier.DefaultMinRequests),
+		Interval:          utils.LoadEnvDuration("AIBRIX_GATEWAY_OUTLIER_INTERVAL", outlier.DefaultInterval),
+		BaseEjectionTime:  utils.LoadEnvDuration("AIBRIX_GATEWAY_OUTLIER_BASE_EJECTION_TIME", outlier.DefaultBaseEjectionTime),
+		MaxEjectionTime:   utils.LoadEnvDuration("AIBRIX_GATEWAY_OUTLIER_MAX_EJECTION_TIME", outlier.DefaultMaxEjectionTime),
+	}
+)
+
+// onPodRouted notifies the outlier detector of the pod the request is routed to.
+func (s *Server) onPodRouted(routingCtx *types.RoutingContext) {
+	if s.outlierDetector == nil || !routingCtx.HasRouted() {
+		return
+	}
+	s.outlierDetector.OnRouted(routingCtx.TargetPod())
+}
+
+// recordPodResponse records the response status code of the routed pod for outlier detection.
+func (s *Server) recordPodResponse(routingCtx *types.RoutingContext, statusCode int) {
+	if s.outlierDetector == nil || routingCtx == nil || !routingCtx.HasRouted() {
+		return
+	}
+	s.outlierDetector.Record(routingCtx.TargetPod(), statusCode)
+}
@@ -23,6 +23,7 @@ import (
 	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
 	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/vllm-project/aibrix/pkg/plugins/gateway/ratelimiter"
 	"github.com/vllm-project/aibrix/pkg/utils"
 )
 
@@ -68,7 +69,7 @@ func (s *Server) checkLimits(ctx context.Context, user utils.User) (int64, *extP
 }
 
 func (s *Server) checkRPM(ctx context.Context, username string, rpmLimit int64) (envoyTypePb.StatusCode, error) {
-	rpmCurrent, err := s.ratelimiter.Get(ctx, fmt.Sprintf("%v_RPM_CURRENT", username))
+	rpmCurrent, err := s.getUsage(ctx, fmt.Sprintf("%v_RPM_CURRENT", username), rpmLimit)
 	if err != nil {
 		return envoyTypePb.StatusCode_InternalServerError, fmt.Errorf("fail to get RPM for user: %v", username)
 	}
@@ -90,7 +91,7 @@ func (s *Server) incrRPM(ctx context.Context, username string) (int64, envoyType
 }
 
 func (s *Server) checkTPM(ctx context.Context, username string, tpmLimit int64) (envoyTypePb.StatusCode, error) {
-	tpmCurrent, err := s.ratelimiter.Get(ctx, fmt.Sprintf("%v_TPM_CURRENT", username))
+	tpmCurrent, err := s.getUsage(ctx, fmt.Sprintf("%v_TPM_CURRENT", username), tpmLimit)
 	if err != nil {
 		return envoyTypePb.StatusCode_InternalServerError, fmt.Errorf("fail to get TPM for user: %v", username)
 	}
@@ -101,3 +102,11 @@ func (s *Server) checkTPM(ctx context.Context, username string, tpmLimit int64)
 
 	return envoyTypePb.StatusCode_OK, nil
 }
+
+// getUsage retrieves the usage of the key, passing the limit to rate limiters whose accounting depends on it.
+func (s *Server) getUsage(ctx context.Context, key string, limit int64) (int64, error) {
+	if r, ok := s.ratelimiter.(ratelimiter.LimitAwareRateLimiter); ok {
+		return r.GetWithLimit(ctx, key, limit)
+	}
+	return s.ratelimiter.Get(ctx, key)
+}
@@ -18,8 +18,10 @@ package gateway
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"k8s.io/klog/v2"
 
@@ -36,16 +38,33 @@ func (s *Server) HandleRequestBody(ctx context.Context, requestID string, req *e
 
 	routingCtx, _ := ctx.(*types.RoutingContext)
 	requestPath := routingCtx.ReqPath
-	routingAlgorithm := routingCtx.Algorithm
 
 	body := req.Request.(*extProcPb.ProcessingRequest_RequestBody)
-	model, message, stream, errRes := validateRequestBody(requestID, requestPath, body.RequestBody.GetBody(), user)
+	model, message, stream, errRes := validateRequestBody(requestID, requestPath, body.RequestBody.GetBody(), user, s.injectsStreamUsage(requestPath))
 	if errRes != nil {
 		return errRes, model, routingCtx, stream, term
 	}
 	routingCtx.Model = model
 	routingCtx.Message = message
 	routingCtx.ReqBody = body.RequestBody.GetBody()
+	if errRes := s.resolveModelAlias(routingCtx); errRes != nil {
+		return errRes, model, routingCtx, stream, term
+	}
+	model = routingCtx.Model
+	if cached := s.serveCachedResponse(ctx, requestID, routingCtx, user, stream); cached != nil {
+		// Cache hits are not counted as requests of the model.
+		return cached, "", routingCtx, stream, term
+	}
+	usageInjected := s.injectStreamUsage(requestID, routingCtx, stream)
+	s.applyRoutingPolicy(routingCtx)
+	routingAlgorithm := routingCtx.Algorithm
+
+	// hold the request while a model scaled to zero is scaled up, the model only exists in cache once it has pods.
+	if s.needsActivation(model) {
+		if errRes := s.activate(ctx, requestID, model); errRes != nil {
+			return errRes, model, routingCtx, stream, term
+		}
+	}
 
 	// early reject the request if model doesn't exist.
 	if !s.cache.HasModel(model) {
@@ -66,15 +85,28 @@ func (s *Server) HandleRequestBody(ctx context.Context, requestID string, req *e
 			fmt.Sprintf("error on getting pods for model %s", model)), model, routingCtx, stream, term
 	}
 
+	// reject the request if it expired while waiting, e.g. for activation.
+	if routingCtx.Expired(time.Now()) {
+		klog.ErrorS(nil, "request exceeded its deadline before routing", "requestID", requestID, "model", model)
+		return requestTimeoutResponse(routingCtx), model, routingCtx, stream, term
+	}
+
 	headers := []*configPb.HeaderValueOption{}
 	if routingAlgorithm == routing.RouterNotSet {
 		if err := s.validateHTTPRouteStatus(ctx, model); err != nil {
 			return buildErrorResponse(envoyTypePb.StatusCode_ServiceUnavailable, err.Error(), HeaderErrorRouting, "true"), model, routingCtx, stream, term
 		}
 		headers = buildEnvoyProxyHeaders(headers, HeaderModel, model)
-		klog.InfoS("request start", "requestID", requestID, "requestPath", requestPath, "model", model, "stream", stream)
+		if routingCtx.ModelAlias != "" || usageInjected {
+			headers = buildEnvoyProxyHeaders(headers, "content-length", strconv.Itoa(len(routingCtx.ReqBody)))
+		}
+		klog.InfoS("request start", "requestID", requestID, "requestPath", requestPath, "model", model, "modelAlias", routingCtx.ModelAlias, "stream", stream)
 	} else {
 		targetPodIP, err := s.selectTargetPod(routingCtx, podsArr)
+		if errors.Is(err, context.DeadlineExceeded) {
+			klog.ErrorS(err, "request exceeded its deadline in routing queue", "requestID", requestID, "routingStrategy", routingAlgorithm, "model", model, "routingDuration", routingCtx.GetRoutingDelay())
+			return requestTimeoutResponse(routingCtx), model, routingCtx, stream, term
+		}
 		if targetPodIP == "" || err != nil {
 			klog.ErrorS(err, "failed to select target pod", "requestID", requestID, "routingStrategy", routingAlgorithm, "model", model, "routingDuration", routingCtx.GetRoutingDelay())
 			return generateErrorResponse(
@@ -87,9 +119,10 @@ func (s *Server) HandleRequestBody(ctx context.Context, requestID string, req *e
 			HeaderRoutingStrategy, string(routingAlgorithm),
 			HeaderTargetPod, targetPodIP,
 			"content-length", strconv.Itoa(len(routingCtx.ReqBody)))
-		klog.InfoS("request start", "requestID", requestID, "requestPath", requestPath, "model", model, "stream", stream, "routingAlgorithm", routingAlgorithm, "targetPodIP", targetPodIP, "routingDuration", routingCtx.GetRoutingDelay())
+		klog.InfoS("request start", "requestID", requestID, "requestPath", requestPath, "model", model, "modelAlias", routingCtx.ModelAlias, "stream", stream, "routingAlgorithm", routingAlgorithm, "targetPodIP", targetPodIP, "routingDuration", routingCtx.GetRoutingDelay())
 	}
 
+	headers = buildRequestTimeoutHeaders(headers, routingCtx)
 	term = s.cache.AddRequestCount(routingCtx, requestID, model)
 
 	return &extProcPb.ProcessingResponse{
@@ -19,6 +19,7 @@ package gateway
 import (
 	"context"
 	"strings"
+	"time"
 
 	"k8s.io/klog/v2"
 
@@ -31,7 +32,7 @@ import (
 )
 
 func (s *Server) HandleRequestHeaders(ctx context.Context, requestID string, req *extProcPb.ProcessingRequest) (*extProcPb.ProcessingResponse, utils.User, int64, *types.RoutingContext) {
-	var username, requestPath string
+	var username, requestPath, authorization, priority, requestTimeout string
 	var user utils.User
 	var rpm int64
 	var err error
@@ -41,6 +42,10 @@ func (s *Server) HandleRequestHeaders(ctx context.Context, requestID string, req
 	h := req.Request.(*extProcPb.ProcessingRequest_RequestHeaders)
 	reqHeaders := map[string]string{}
 	for _, n := range h.RequestHeaders.Headers.Headers {
+		if isForwardedHeader(n.Key) {
+			// Kept to send the request again from the gateway, e.g. on retry, and for routers keyed by headers.
+			reqHeaders[strings.ToLower(n.Key)] = string(n.RawValue)
+		}
 		if strings.ToLower(n.Key) == "user" {
 			username = string(n.RawValue)
 		}
@@ -48,7 +53,13 @@ func (s *Server) HandleRequestHeaders(ctx context.Context, requestID string, req
 			requestPath = string(n.RawValue)
 		}
 		if strings.ToLower(n.Key) == "authorization" {
-			reqHeaders[n.Key] = string(n.RawValue)
+			authorization = string(n.RawValue)
+		}
+		if strings.ToLower(n.Key) == HeaderRequestTimeout {
+			requestTimeout = string(n.RawValue)
+		}
+		if strings.ToLower(n.Key) == HeaderPriority {
+			priority = string(n.RawValue)
 		}
 	}
 
@@ -63,21 +74,24 @@ func (s *Server) HandleRequestHeaders(ctx context.Context, requestID string, req
 			}}}, "incorrect routing strategy"), utils.User{}, rpm, routingCtx
 	}
 
-	if username != "" {
-		user, err = utils.GetUser(ctx, utils.User{Name: username}, s.redisClient)
-		if err != nil {
-			klog.ErrorS(err, "unable to process user info", "requestID", requestID, "username", username)
-			return generateErrorResponse(
-				envoyTypePb.StatusCode_InternalServerError,
-				[]*configPb.HeaderValueOption{{Header: &configPb.HeaderValue{
-					Key: HeaderErrorUser, RawValue: []byte("true"),
-				}}},
-				err.Error()), utils.User{}, rpm, routingCtx
+	var timeout time.Duration
+	if requestTimeout != "" {
+		if timeout, err = parseRequestTimeout(requestTimeout); err != nil {
+			klog.ErrorS(err, "incorrect request timeout", "requestID", requestID)
+			return buildErrorResponse(envoyTypePb.StatusCode_BadRequest, err.Error(),
+				HeaderErrorInvalidRequestTimeout, requestTimeout), utils.User{}, rpm, routingCtx
 		}
+	}
 
+	user, errRes = s.authenticate(ctx, requestID, authorization, username)
+	if errRes != nil {
+		return errRes, utils.User{}, rpm, routingCtx
+	}
+
+	if user.Name != "" {
 		rpm, errRes, err = s.checkLimits(ctx, user)
 		if errRes != nil {
-			klog.ErrorS(err, "error on checking limits", "requestID", requestID, "username", username)
+			klog.ErrorS(err, "error on checking limits", "requestID", requestID, "username", user.Name)
 			return errRes, utils.User{}, rpm, routingCtx
 		}
 	}
@@ -85,6 +99,11 @@ func (s *Server) HandleRequestHeaders(ctx context.Context, requestID string, req
 	routingCtx = types.NewRoutingContext(ctx, routingAlgorithm, "", "", requestID, user.Name)
 	routingCtx.ReqPath = requestPath
 	routingCtx.ReqHeaders = reqHeaders
+	routingCtx.Priority = requestPriority(user, priority)
+	routingCtx.UserWeight = user.Weight
+	if timeout > 0 {
+		routingCtx.SetTimeout(timeout)
+	}
 
 	return &extProcPb.ProcessingResponse{
 		Response: &extProcPb.ProcessingResponse_RequestHeaders{
@@ -106,3 +125,40 @@ func (s *Server) HandleRequestHeaders(ctx context.Context, requestID string, req
 		},
 	}, user, rpm, routingCtx
 }
+
+// requestPriority returns the priority class of the request. The priority class of the user record takes precedence
+// over the one requested by header, which is capped at the default class, so that users can not escalate their own
+// priority.
+func requestPriority(user utils.User, headerPriority string) string {
+	if user.Priority != "" {
+		return user.Priority
+	}
+	return routing.CapPriorityClass(strings.TrimSpace(headerPriority))
+}
+
+// unforwardedHeaders are the headers of the client not kept in the routing context, as they are either hop-by-hop
+// headers, or set by the gateway when it sends the request.
+var unforwardedHeaders = map[string]struct{}{
+	"accept-encoding":   {},
+	"connection":        {},
+	"content-length":    {},
+	"content-type":      {},
+	"host":              {},
+	"keep-alive":        {},
+	"proxy-connection":  {},
+	"te":                {},
+	"trailer":           {},
+	"transfer-encoding": {},
+	"upgrade":           {},
+}
+
+// isForwardedHeader returns true if the client header is kept in the routing context.
+func isForwardedHeader(key string) bool {
+	key = strings.ToLower(key)
+	if strings.HasPrefix(key, ":") {
+		// Pseudo headers of HTTP/2.
+		return false
+	}
+	_, ok := unforwardedHeaders[key]
+	return !ok
+}
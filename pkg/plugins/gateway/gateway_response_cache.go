@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/plugins/gateway/responsecache"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+var (
+	// responseCacheStore caches the responses of deterministic requests in memory or in redis, disabled if empty.
+	responseCacheStore   = utils.LoadEnv(EnvResponseCache, "")
+	responseCacheOptions = responsecache.Options{
+		TTL:          utils.LoadEnvDuration("AIBRIX_GATEWAY_RESPONSE_CACHE_TTL", responsecache.DefaultTTL),
+		MaxEntries:   utils.LoadEnvInt("AIBRIX_GATEWAY_RESPONSE_CACHE_MAX_ENTRIES", responsecache.DefaultMaxEntries),
+		MaxBodyBytes: utils.LoadEnvInt("AIBRIX_GATEWAY_RESPONSE_CACHE_MAX_BODY_BYTES", responsecache.DefaultMaxBodyBytes),
+	}
+)
+
+// pendingResponse buffers the response of a cacheable request until the response completes.
+type pendingResponse struct {
+	key  string
+	body bytes.Buffer
+}
+
+// serveCachedResponse returns the cached response of a deterministic completion request, scoped by model and user.
+// On a miss, the response of the request is cached once it completes.
+func (s *Server) serveCachedResponse(ctx context.Context, requestID string, routingCtx *types.RoutingContext, user utils.User, stream bool) *extProcPb.ProcessingResponse {
+	if s.responseCache == nil || (routingCtx.ReqPath != PathChatCompletions && routingCtx.ReqPath != PathCompletions) {
+		return nil
+	}
+	key, ok := responsecache.Key(routingCtx.Model, user.Name, routingCtx.ReqBody)
+	if !ok {
+		return nil
+	}
+	body, ok := s.responseCache.Get(ctx, key)
+	if !ok {
+		s.pendingResponses.Store(requestID, &pendingResponse{key: key})
+		return nil
+	}
+
+	contentType := "application/json"
+	if stream {
+		contentType = "text/event-stream"
+	}
+	headers := buildEnvoyProxyHeaders([]*configPb.HeaderValueOption{},
+		"content-type", contentType,
+		HeaderResponseCache, "hit",
+		HeaderRequestID, requestID)
+	if routingCtx.ModelAlias != "" {
+		headers = buildEnvoyProxyHeaders(headers, HeaderResolvedModel, routingCtx.Model)
+	}
+	klog.InfoS("request served from response cache", "requestID", requestID, "model", routingCtx.Model, "stream", stream)
+	return &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_ImmediateResponse{
+			ImmediateResponse: &extProcPb.ImmediateResponse{
+				Status:  &envoyTypePb.HttpStatus{Code: envoyTypePb.StatusCode_OK},
+				Headers: &extProcPb.HeaderMutation{SetHeaders: headers},
+				Body:    string(body),
+			},
+		},
+	}
+}
+
+// recordCachedResponse appends a chunk of a successful response to the pending response of the request,
+// and caches the response at the end of stream. Responses with stream errors or too large are not cached.
+func (s *Server) recordCachedResponse(ctx context.Context, requestID string, chunk []byte, endOfStream bool) {
+	value, ok := s.pendingResponses.Load(requestID)
+	if !ok {
+		return
+	}
+	pending := value.(*pendingResponse)
+	if hasStreamError(chunk) || pending.body.Len()+len(chunk) > s.responseCache.MaxBodyBytes() {
+		s.pendingResponses.Delete(requestID)
+		return
+	}
+	pending.body.Write(chunk)
+	if endOfStream {
+		s.pendingResponses.Delete(requestID)
+		s.responseCache.Set(ctx, pending.key, pending.body.Bytes())
+	}
+}
+
+// discardCachedResponse drops the pending response of a request that ends without completing it.
+func (s *Server) discardCachedResponse(requestID string) {
+	s.pendingResponses.Delete(requestID)
+}
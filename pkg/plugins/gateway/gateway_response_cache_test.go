@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vllm-project/aibrix/pkg/plugins/gateway/responsecache"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+func TestResponseCache(t *testing.T) {
+	rc, err := responsecache.NewCache(responsecache.StoreMemory, nil, responsecache.Options{})
+	require.NoError(t, err)
+	s := &Server{responseCache: rc}
+	ctx := context.Background()
+	user := utils.User{Name: "alice"}
+
+	newRoutingCtx := func(body string) *types.RoutingContext {
+		routingCtx := types.NewRoutingContext(ctx, "", "llama", "", "request", user.Name)
+		routingCtx.ReqPath = PathChatCompletions
+		routingCtx.ReqBody = []byte(body)
+		return routingCtx
+	}
+	deterministic := `{"model":"llama","temperature":0,"stream":true,"messages":[{"role":"user","content":"hi"}]}`
+
+	// The first request misses and caches its response once complete
+	routingCtx := newRoutingCtx(deterministic)
+	defer routingCtx.Delete()
+	assert.Nil(t, s.serveCachedResponse(ctx, "request-1", routingCtx, user, true))
+	s.recordCachedResponse(ctx, "request-1", []byte("data: {\"choices\":[]}\n\n"), false)
+	s.recordCachedResponse(ctx, "request-1", []byte("data: [DONE]\n\n"), true)
+
+	// Identical requests are served from the cache
+	resp := s.serveCachedResponse(ctx, "request-2", routingCtx, user, true)
+	require.NotNil(t, resp)
+	immediate := resp.GetImmediateResponse()
+	assert.Equal(t, envoyTypePb.StatusCode_OK, immediate.GetStatus().GetCode())
+	assert.Equal(t, "data: {\"choices\":[]}\n\ndata: [DONE]\n\n", immediate.GetBody())
+	headers := map[string]string{}
+	for _, header := range immediate.GetHeaders().GetSetHeaders() {
+		headers[header.Header.Key] = string(header.Header.RawValue)
+	}
+	assert.Equal(t, "hit", headers[HeaderResponseCache])
+	assert.Equal(t, "text/event-stream", headers["content-type"])
+
+	// Other users do not share the response
+	assert.Nil(t, s.serveCachedResponse(ctx, "request-3", routingCtx, utils.User{Name: "bob"}, true))
+	s.discardCachedResponse("request-3")
+	_, pending := s.pendingResponses.Load("request-3")
+	assert.False(t, pending)
+
+	// Responses with stream errors are not cached
+	errorCtx := newRoutingCtx(`{"model":"llama","temperature":0,"messages":[{"role":"user","content":"error"}]}`)
+	defer errorCtx.Delete()
+	assert.Nil(t, s.serveCachedResponse(ctx, "request-4", errorCtx, user, true))
+	s.recordCachedResponse(ctx, "request-4", []byte("data: {\"error\":{\"message\":\"oops\"}}\n\n"), true)
+	assert.Nil(t, s.serveCachedResponse(ctx, "request-5", errorCtx, user, true))
+	s.discardCachedResponse("request-5")
+
+	// Non-deterministic requests are not cached
+	randomCtx := newRoutingCtx(`{"model":"llama","temperature":0.7,"messages":[{"role":"user","content":"hi"}]}`)
+	defer randomCtx.Delete()
+	assert.Nil(t, s.serveCachedResponse(ctx, "request-6", randomCtx, user, false))
+	_, pending = s.pendingResponses.Load("request-6")
+	assert.False(t, pending)
+}
@@ -0,0 +1,427 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/packages/ssestream"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	routing "github.com/vllm-project/aibrix/pkg/plugins/gateway/algorithms"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+const (
+	// Per model retry policy is configured by annotations on the model pods.
+	// Example: "model.aibrix.ai/retry-max-attempts": "3"
+	RetryMaxAttemptsAnnotation = "model.aibrix.ai/retry-max-attempts"
+	// Example: "model.aibrix.ai/retry-status-codes": "500,502,503,504"
+	RetryStatusCodesAnnotation = "model.aibrix.ai/retry-status-codes"
+	// Example: "model.aibrix.ai/retry-after-stream-started": "true"
+	RetryAfterStreamStartedAnnotation = "model.aibrix.ai/retry-after-stream-started"
+
+	defaultRetryMaxAttempts    = 1 // No retry by default
+	defaultRetryStatusCodes    = "500,502,503,504"
+	defaultRetryRequestTimeout = 120 * time.Second
+)
+
+var (
+	defaultRetryPolicy = RetryPolicy{
+		MaxAttempts:             utils.LoadEnvInt("AIBRIX_GATEWAY_RETRY_MAX_ATTEMPTS", defaultRetryMaxAttempts),
+		RetriableStatusCodes:    parseRetriableStatusCodes(utils.LoadEnv("AIBRIX_GATEWAY_RETRY_STATUS_CODES", defaultRetryStatusCodes)),
+		RetryAfterStreamStarted: utils.LoadEnvBool("AIBRIX_GATEWAY_RETRY_AFTER_STREAM_STARTED", false),
+	}
+	retryHTTPClient = &http.Client{Timeout: utils.LoadEnvDuration("AIBRIX_GATEWAY_RETRY_REQUEST_TIMEOUT", defaultRetryRequestTimeout)}
+)
+
+// RetryPolicy controls how a request that failed on the selected pod is retried on a different pod.
+// The response of a retry is buffered and relayed to the client once complete, so a streaming client receives the
+// retried stream at once, and a retry fails if the response is not complete within AIBRIX_GATEWAY_RETRY_REQUEST_TIMEOUT.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one, 1 disables retry.
+	MaxAttempts int
+	// RetriableStatusCodes are the upstream status codes that trigger a retry.
+	RetriableStatusCodes map[int]struct{}
+	// RetryAfterStreamStarted allows retrying a streaming response that reports an error event after its headers
+	// have been sent. Relayed chunks can not be recalled, so the retry is refused once a chunk has been relayed.
+	RetryAfterStreamStarted bool
+}
+
+// IsRetriable returns true if the status code triggers a retry.
+func (p *RetryPolicy) IsRetriable(statusCode int) bool {
+	_, ok := p.RetriableStatusCodes[statusCode]
+	return ok
+}
+
+// getRetryPolicy loads the retry policy of a model from the annotations of its pods, falling back to defaults.
+func getRetryPolicy(pods []*v1.Pod) RetryPolicy {
+	policy := defaultRetryPolicy
+	if len(pods) == 0 {
+		return policy
+	}
+
+	annotations := pods[0].Annotations
+	policy.MaxAttempts = utils.GetPositiveIntAnnotationOrDefault(annotations, RetryMaxAttemptsAnnotation, policy.MaxAttempts)
+	if codes, ok := annotations[RetryStatusCodesAnnotation]; ok {
+		policy.RetriableStatusCodes = parseRetriableStatusCodes(codes)
+	}
+	if value, ok := annotations[RetryAfterStreamStartedAnnotation]; ok {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			policy.RetryAfterStreamStarted = enabled
+		} else {
+			klog.Warningf("invalid boolean for annotation %s: %s, using default %v", RetryAfterStreamStartedAnnotation, value, policy.RetryAfterStreamStarted)
+		}
+	}
+	return policy
+}
+
+func parseRetriableStatusCodes(value string) map[int]struct{} {
+	codes := map[int]struct{}{}
+	for _, code := range strings.Split(value, ",") {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+		if statusCode, err := strconv.Atoi(code); err == nil {
+			codes[statusCode] = struct{}{}
+		} else {
+			klog.Warningf("invalid retriable status code: %s", code)
+		}
+	}
+	return codes
+}
+
+// retryState tracks the attempts of a request, it is owned by a single Process stream.
+type retryState struct {
+	policy    *RetryPolicy
+	attempts  int                 // Number of attempts made so far.
+	failures  []string            // Failed attempts in the form of address=status.
+	triedPods map[string]struct{} // Keys of pods that have been tried.
+	relayed   bool                // A chunk of the response has been relayed to the client.
+	replayed  bool                // The response relayed to the client has been replaced by a retry.
+}
+
+// canRetry returns true if the failed request can be retried on another pod. streamStarted tells a stream error event
+// after the response headers have been sent.
+func (s *Server) canRetry(routerCtx *types.RoutingContext, state *retryState, model string, statusCode int, streamStarted bool) bool {
+	if routerCtx == nil || routerCtx.Algorithm == routing.RouterNotSet || !routerCtx.HasRouted() || state.replayed {
+		return false
+	}
+
+	if state.policy == nil {
+		pods, err := s.cache.ListPodsByModel(model)
+		if err != nil || pods == nil {
+			return false
+		}
+		policy := getRetryPolicy(pods.All())
+		state.policy = &policy
+		state.attempts = 1
+		state.triedPods = map[string]struct{}{}
+	}
+
+	if state.attempts >= state.policy.MaxAttempts || !state.policy.IsRetriable(statusCode) || routerCtx.Expired(time.Now()) {
+		return false
+	}
+	if !streamStarted {
+		return true
+	}
+	if state.policy.RetryAfterStreamStarted && state.relayed {
+		klog.InfoS("stream error is not retried, part of the response has been relayed", "requestID", routerCtx.RequestID, "model", model)
+		return false
+	}
+	return state.policy.RetryAfterStreamStarted
+}
+
+// HandleRetry re-runs the routing algorithm with pods that already failed excluded, and replays the request on
+// the newly selected pod until it succeeds or the retry policy is exhausted. The request is always completed by
+// HandleRetry, so the returned response is final. If the stream has started, failedChunk is the chunk carrying
+// the error event, which is replaced by the retried stream.
+func (s *Server) HandleRetry(ctx context.Context, requestID string, user utils.User, rpm int64, model string, stream bool,
+	traceTerm int64, state *retryState, statusCode int, failedChunk []byte, streamStarted bool) (*extProcPb.ProcessingResponse, bool) {
+	routerCtx, _ := ctx.(*types.RoutingContext)
+	released := false
+	defer func() {
+		if !released {
+			s.cache.DoneRequestCount(routerCtx, requestID, model, traceTerm)
+		}
+		routerCtx.Delete()
+	}()
+
+	var header http.Header
+	errBody := failedChunk
+	for {
+		s.recordPodResponse(routerCtx, statusCode)
+		failedPod := routerCtx.TargetPod()
+		state.triedPods[utils.GeneratePodKey(failedPod.Namespace, failedPod.Name)] = struct{}{}
+		state.failures = append(state.failures, fmt.Sprintf("%s=%d", routerCtx.TargetAddress(), statusCode))
+		if state.attempts >= state.policy.MaxAttempts || !state.policy.IsRetriable(statusCode) {
+			break
+		}
+
+		// Release the failed attempt before routing again.
+		s.cache.DoneRequestCount(routerCtx, requestID, model, traceTerm)
+		released = true
+		routerCtx.ResetTargetPod()
+
+		pods, err := s.cache.ListPodsByModel(model)
+		if err != nil || pods == nil {
+			klog.ErrorS(err, "failed to list pods for retry", "requestID", requestID, "model", model)
+			break
+		}
+		candidates := make([]*v1.Pod, 0, pods.Len())
+		for _, pod := range pods.All() {
+			if _, tried := state.triedPods[utils.GeneratePodKey(pod.Namespace, pod.Name)]; !tried {
+				candidates = append(candidates, pod)
+			}
+		}
+		targetPodIP, err := s.selectTargetPod(routerCtx, &utils.PodArray{Pods: candidates})
+		if targetPodIP == "" || err != nil {
+			klog.ErrorS(err, "failed to select target pod for retry", "requestID", requestID, "model", model, "attempts", state.attempts)
+			break
+		}
+		traceTerm = s.cache.AddRequestCount(routerCtx, requestID, model)
+		released = false
+		state.attempts++
+
+		klog.InfoS("retry request", "requestID", requestID, "model", model, "attempt", state.attempts, "targetPodIP", targetPodIP, "failures", state.failures)
+		statusCode, header, errBody, err = replayRequest(routerCtx, targetPodIP)
+		if err != nil {
+			klog.ErrorS(err, "failed to replay request", "requestID", requestID, "targetPodIP", targetPodIP)
+			statusCode, errBody = int(envoyTypePb.StatusCode_ServiceUnavailable), []byte(err.Error())
+			continue
+		}
+		if statusCode == http.StatusOK {
+			s.recordPodResponse(routerCtx, statusCode)
+			return s.buildRetryResponse(ctx, requestID, user, rpm, model, stream, traceTerm, state, header, errBody, streamStarted, &released), true
+		}
+	}
+
+	klog.ErrorS(nil, "request end after retries", "requestID", requestID, "errorCode", statusCode, "attempts", state.attempts, "failures", state.failures)
+	if streamStarted {
+		// Response headers have been sent, relay the failed chunk and drop the rest of the failed stream.
+		return replaceResponseBody(state, failedChunk), true
+	}
+	return buildErrorResponse(envoyTypePb.StatusCode(statusCode), string(errBody),
+		HeaderRequestID, requestID,
+		HeaderRetryAttempts, strconv.Itoa(state.attempts),
+		HeaderRetryFailures, strings.Join(state.failures, ",")), true
+}
+
+// buildRetryResponse completes the request with the response of the successful retry.
+func (s *Server) buildRetryResponse(ctx context.Context, requestID string, user utils.User, rpm int64, model string, stream bool,
+	traceTerm int64, state *retryState, header http.Header, body []byte, streamStarted bool, released *bool) *extProcPb.ProcessingResponse {
+	routerCtx, _ := ctx.(*types.RoutingContext)
+	targetPodIP := routerCtx.TargetAddress()
+	headers := buildEnvoyProxyHeaders([]*configPb.HeaderValueOption{},
+		HeaderRequestID, requestID,
+		HeaderTargetPod, targetPodIP,
+		HeaderRetryAttempts, strconv.Itoa(state.attempts),
+		HeaderRetryFailures, strings.Join(state.failures, ","))
+	if contentType := header.Get("content-type"); contentType != "" {
+		headers = buildEnvoyProxyHeaders(headers, "content-type", contentType)
+	}
+
+	usage, err := parseResponseUsage(routerCtx.ReqPath, stream, body)
+	if err != nil {
+		klog.ErrorS(err, "error to parse usage of retried response", "requestID", requestID)
+	}
+	if usage.TotalTokens != 0 {
+		s.cache.DoneRequestTrace(routerCtx, requestID, model, usage.PromptTokens, usage.CompletionTokens, traceTerm)
+		*released = true
+		if user.Name != "" {
+			tpm, err := s.ratelimiter.Incr(ctx, fmt.Sprintf("%v_TPM_CURRENT", user.Name), usage.TotalTokens)
+			if err != nil {
+				klog.ErrorS(err, "error to increase TPM for retried request", "requestID", requestID, "user", user.Name)
+			} else {
+				headers = buildEnvoyProxyHeaders(headers,
+					HeaderUpdateRPM, strconv.FormatInt(rpm, 10),
+					HeaderUpdateTPM, strconv.FormatInt(tpm, 10))
+			}
+		}
+	}
+	klog.InfoS("request end after retries", "requestID", requestID, "targetPod", targetPodIP, "attempts", state.attempts, "failures", state.failures)
+	if stream && s.streamUsageFilter(requestID) != nil {
+		body = (&streamUsageFilter{}).filter(body, true)
+	}
+	if streamStarted {
+		// Response headers have been sent, replace the failed chunk with the retried stream and drop the rest.
+		return replaceResponseBody(state, body)
+	}
+
+	return &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_ImmediateResponse{
+			ImmediateResponse: &extProcPb.ImmediateResponse{
+				Status: &envoyTypePb.HttpStatus{
+					Code: envoyTypePb.StatusCode_OK,
+				},
+				Headers: &extProcPb.HeaderMutation{
+					SetHeaders: headers,
+				},
+				Body: string(body),
+			},
+		},
+	}
+}
+
+// replaceResponseBody replaces the current chunk of the response with body, the rest of the response is dropped.
+func replaceResponseBody(state *retryState, body []byte) *extProcPb.ProcessingResponse {
+	state.replayed = true
+	return &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_ResponseBody{
+			ResponseBody: &extProcPb.BodyResponse{
+				Response: &extProcPb.CommonResponse{
+					BodyMutation: &extProcPb.BodyMutation{
+						Mutation: &extProcPb.BodyMutation_Body{
+							Body: body,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// clearResponseBody drops a chunk of a response that has been replaced by a retry.
+func clearResponseBody() *extProcPb.ProcessingResponse {
+	return &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_ResponseBody{
+			ResponseBody: &extProcPb.BodyResponse{
+				Response: &extProcPb.CommonResponse{
+					BodyMutation: &extProcPb.BodyMutation{
+						Mutation: &extProcPb.BodyMutation_ClearBody{ClearBody: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// replayRequest sends the buffered request body to the target pod and reads the whole response.
+func replayRequest(routerCtx *types.RoutingContext, targetPodIP string) (int, http.Header, []byte, error) {
+	url := fmt.Sprintf("http://%s%s", targetPodIP, routerCtx.ReqPath)
+	req, err := http.NewRequestWithContext(routerCtx.Context, http.MethodPost, url, bytes.NewReader(routerCtx.ReqBody))
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to create retry request: %w", err)
+	}
+	for key, value := range routerCtx.ReqHeaders {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("content-type", "application/json")
+	if remaining, ok := remainingTimeoutMs(routerCtx, time.Now()); ok {
+		req.Header.Set(HeaderRequestTimeout, remaining)
+	}
+
+	resp, err := retryHTTPClient.Do(req)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to execute retry request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to read retry response: %w", err)
+	}
+	return resp.StatusCode, resp.Header, body, nil
+}
+
+// parseResponseUsage extracts token usage from a complete response body.
+func parseResponseUsage(requestPath string, stream bool, body []byte) (openai.CompletionUsage, error) {
+	if isPoolingRequestPath(requestPath) {
+		_, usage, err := unmarshalPoolingResponse(body)
+		return usage, err
+	}
+
+	if !stream {
+		var res openai.ChatCompletion
+		if err := json.Unmarshal(body, &res); err != nil {
+			return openai.CompletionUsage{}, err
+		}
+		return res.Usage, nil
+	}
+
+	var usage openai.CompletionUsage
+	t := &http.Response{
+		Body: io.NopCloser(bytes.NewReader(body)),
+	}
+	streaming := ssestream.NewStream[openai.ChatCompletionChunk](ssestream.NewDecoder(t), nil)
+	defer func() {
+		_ = streaming.Close()
+	}()
+	for streaming.Next() {
+		evt := streaming.Current()
+		if len(evt.Choices) == 0 {
+			usage = evt.Usage
+		}
+	}
+	return usage, streaming.Err()
+}
+
+// hasStreamError returns true if a chunk of a streaming response carries an error event from the engine.
+func hasStreamError(chunk []byte) bool {
+	if !bytes.Contains(chunk, []byte("error")) {
+		return false
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(chunk))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(chunk)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if !bytes.HasPrefix(line, []byte("data:")) {
+			continue
+		}
+		var event map[string]json.RawMessage
+		if err := json.Unmarshal(bytes.TrimSpace(line[len("data:"):]), &event); err != nil {
+			continue
+		}
+		if _, ok := event["error"]; ok {
+			return true
+		}
+		if object, ok := event["object"]; ok && string(object) == `"error"` {
+			return true
+		}
+	}
+	return false
+}
+
+// getResponseStatusCode returns the upstream status code from response headers.
+func getResponseStatusCode(req *extProcPb.ProcessingRequest) int {
+	h := req.Request.(*extProcPb.ProcessingRequest_ResponseHeaders)
+	for _, headerValue := range h.ResponseHeaders.Headers.Headers {
+		if headerValue.Key == ":status" {
+			code, _ := strconv.Atoi(string(headerValue.RawValue))
+			return code
+		}
+	}
+	return 0
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	routing "github.com/vllm-project/aibrix/pkg/plugins/gateway/algorithms"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+func newRetryTestPod(t *testing.T, name string, handler http.HandlerFunc, annotations map[string]string) *v1.Pod {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Labels:      map[string]string{"model.aibrix.ai/port": u.Port()},
+			Annotations: annotations,
+		},
+		Status: v1.PodStatus{
+			PodIP:      u.Hostname(),
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+		},
+	}
+}
+
+func Test_getRetryPolicy(t *testing.T) {
+	policy := getRetryPolicy(nil)
+	assert.Equal(t, defaultRetryMaxAttempts, policy.MaxAttempts)
+	assert.True(t, policy.IsRetriable(503))
+	assert.False(t, policy.IsRetriable(400))
+	assert.False(t, policy.RetryAfterStreamStarted)
+
+	policy = getRetryPolicy([]*v1.Pod{{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		RetryMaxAttemptsAnnotation:        "3",
+		RetryStatusCodesAnnotation:        "502, 429",
+		RetryAfterStreamStartedAnnotation: "true",
+	}}}})
+	assert.Equal(t, 3, policy.MaxAttempts)
+	assert.True(t, policy.IsRetriable(429))
+	assert.False(t, policy.IsRetriable(503))
+	assert.True(t, policy.RetryAfterStreamStarted)
+
+	policy = getRetryPolicy([]*v1.Pod{{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		RetryMaxAttemptsAnnotation:        "-1",
+		RetryAfterStreamStartedAnnotation: "maybe",
+	}}}})
+	assert.Equal(t, defaultRetryMaxAttempts, policy.MaxAttempts)
+	assert.False(t, policy.RetryAfterStreamStarted)
+}
+
+func Test_hasStreamError(t *testing.T) {
+	assert.False(t, hasStreamError([]byte("data: {\"id\":\"1\",\"choices\":[{\"delta\":{\"content\":\"hi\"}}]}\n\n")))
+	assert.False(t, hasStreamError([]byte("data: [DONE]\n\n")))
+	assert.True(t, hasStreamError([]byte("data: {\"id\":\"1\",\"choices\":[]}\n\ndata: {\"error\":{\"message\":\"CUDA error\"}}\n\n")))
+	assert.True(t, hasStreamError([]byte("data: {\"object\":\"error\",\"message\":\"CUDA error\",\"code\":500}\n\n")))
+}
+
+func Test_HandleRetry(t *testing.T) {
+	routing.Init()
+	annotations := map[string]string{RetryMaxAttemptsAnnotation: "3"}
+	okBody := `{"id":"1","object":"chat.completion","model":"test-model","choices":[],"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}`
+
+	tests := []struct {
+		name        string
+		handlers    []http.HandlerFunc
+		expectCode  envoyTypePb.StatusCode
+		expectBody  string
+		expectTrace bool
+		attempts    string
+	}{
+		{
+			name: "retry succeeds on another pod",
+			handlers: []http.HandlerFunc{
+				func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusServiceUnavailable) },
+				func(w http.ResponseWriter, r *http.Request) {
+					// Headers of the client are replayed, except the ones set by the gateway.
+					if r.Header.Get("x-client-header") != "client" || r.Header.Get("authorization") != "Bearer key" ||
+						r.Header.Get("content-type") != "application/json" {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					_, _ = w.Write([]byte(okBody))
+				},
+			},
+			expectCode:  envoyTypePb.StatusCode_OK,
+			expectBody:  okBody,
+			expectTrace: true,
+			attempts:    "2",
+		},
+		{
+			name: "retry stops on non-retriable status code",
+			handlers: []http.HandlerFunc{
+				func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusServiceUnavailable) },
+				// Either pod may be selected for the retry, the retry stops after the first one.
+				func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusBadRequest) },
+				func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusBadRequest) },
+			},
+			expectCode: envoyTypePb.StatusCode_BadRequest,
+			attempts:   "2",
+		},
+		{
+			name: "retry fails when all pods fail",
+			handlers: []http.HandlerFunc{
+				func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusServiceUnavailable) },
+				func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusServiceUnavailable) },
+			},
+			expectCode: envoyTypePb.StatusCode_ServiceUnavailable,
+			attempts:   "2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pods := make([]*v1.Pod, 0, len(tt.handlers))
+			for i, handler := range tt.handlers {
+				pods = append(pods, newRetryTestPod(t, "pod-"+string(rune('a'+i)), handler, annotations))
+			}
+
+			mockCache := new(MockCache)
+			mockCache.On("ListPodsByModel", "test-model").Return(&utils.PodArray{Pods: pods}, nil)
+			mockCache.On("AddRequestCount", mock.Anything, "r1", "test-model").Return(int64(1))
+			mockCache.On("DoneRequestCount", mock.Anything, "r1", "test-model", mock.Anything).Return()
+			mockCache.On("DoneRequestTrace", mock.Anything, "r1", "test-model", int64(3), int64(2), int64(1)).Return()
+			server := &Server{cache: mockCache}
+
+			routerCtx := types.NewRoutingContext(context.Background(), routing.RouterRandom, "test-model", "", "r1", "")
+			routerCtx.ReqPath = PathChatCompletions
+			routerCtx.ReqHeaders = map[string]string{"x-client-header": "client", "authorization": "Bearer key"}
+			routerCtx.ReqBody = []byte(`{"model":"test-model","messages":[{"role":"user","content":"hi"}]}`)
+			routerCtx.SetTargetPod(pods[0])
+
+			var state retryState
+			assert.True(t, server.canRetry(routerCtx, &state, "test-model", 503, false))
+			resp, completed := server.HandleRetry(routerCtx, "r1", utils.User{}, 0, "test-model", false, 0, &state, 503, nil, false)
+			assert.True(t, completed)
+
+			immediate := resp.GetImmediateResponse()
+			assert.Equal(t, tt.expectCode, immediate.GetStatus().GetCode())
+			if tt.expectBody != "" {
+				assert.Equal(t, tt.expectBody, immediate.GetBody())
+			}
+			found := false
+			for _, header := range immediate.GetHeaders().GetSetHeaders() {
+				if header.Header.Key == HeaderRetryAttempts {
+					found = true
+					assert.Equal(t, tt.attempts, string(header.Header.RawValue))
+				}
+			}
+			assert.True(t, found)
+			if tt.expectTrace {
+				mockCache.AssertCalled(t, "DoneRequestTrace", mock.Anything, "r1", "test-model", int64(3), int64(2), int64(1))
+			} else {
+				mockCache.AssertNotCalled(t, "DoneRequestTrace", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func Test_HandleRetryAfterStreamStarted(t *testing.T) {
+	routing.Init()
+	failedChunk := []byte("data: {\"object\":\"error\",\"message\":\"CUDA error\",\"code\":500}\n\n")
+	retriedStream := "data: {\"id\":\"1\",\"choices\":[{\"delta\":{\"content\":\"hi\"}}]}\n\n" +
+		"data: {\"id\":\"1\",\"choices\":[],\"usage\":{\"prompt_tokens\":3,\"completion_tokens\":2,\"total_tokens\":5}}\n\n" +
+		"data: [DONE]\n\n"
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		relayed     bool
+		expectRetry bool
+	}{
+		{
+			name:        "stream errors are not retried by default",
+			annotations: map[string]string{RetryMaxAttemptsAnnotation: "2"},
+		},
+		{
+			name:        "stream error is retried before any chunk is relayed",
+			annotations: map[string]string{RetryMaxAttemptsAnnotation: "2", RetryAfterStreamStartedAnnotation: "true"},
+			expectRetry: true,
+		},
+		{
+			name:        "stream error is not retried after a chunk is relayed",
+			annotations: map[string]string{RetryMaxAttemptsAnnotation: "2", RetryAfterStreamStartedAnnotation: "true"},
+			relayed:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pods := []*v1.Pod{
+				newRetryTestPod(t, "pod-a", func(w http.ResponseWriter, r *http.Request) {}, tt.annotations),
+				newRetryTestPod(t, "pod-b", func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("content-type", "text/event-stream")
+					_, _ = w.Write([]byte(retriedStream))
+				}, tt.annotations),
+			}
+
+			mockCache := new(MockCache)
+			mockCache.On("ListPodsByModel", "test-model").Return(&utils.PodArray{Pods: pods}, nil)
+			mockCache.On("AddRequestCount", mock.Anything, "r1", "test-model").Return(int64(1))
+			mockCache.On("DoneRequestCount", mock.Anything, "r1", "test-model", mock.Anything).Return()
+			mockCache.On("DoneRequestTrace", mock.Anything, "r1", "test-model", int64(3), int64(2), int64(1)).Return()
+			server := &Server{cache: mockCache}
+
+			routerCtx := types.NewRoutingContext(context.Background(), routing.RouterRandom, "test-model", "", "r1", "")
+			routerCtx.ReqPath = PathChatCompletions
+			routerCtx.ReqBody = []byte(`{"model":"test-model","stream":true,"messages":[{"role":"user","content":"hi"}]}`)
+			routerCtx.SetTargetPod(pods[0])
+
+			state := retryState{relayed: tt.relayed}
+			assert.Equal(t, tt.expectRetry, server.canRetry(routerCtx, &state, "test-model", http.StatusInternalServerError, true))
+			if !tt.expectRetry {
+				return
+			}
+
+			resp, completed := server.HandleRetry(routerCtx, "r1", utils.User{}, 0, "test-model", true, 0, &state,
+				http.StatusInternalServerError, failedChunk, true)
+			assert.True(t, completed)
+			assert.True(t, state.replayed)
+			assert.Equal(t, retriedStream, string(resp.GetResponseBody().GetResponse().GetBodyMutation().GetBody()))
+			mockCache.AssertCalled(t, "DoneRequestTrace", mock.Anything, "r1", "test-model", int64(3), int64(2), int64(1))
+			// The rest of the failed stream is dropped, and is not retried again.
+			assert.False(t, server.canRetry(routerCtx, &state, "test-model", http.StatusInternalServerError, true))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"k8s.io/klog/v2"
+
+	routing "github.com/vllm-project/aibrix/pkg/plugins/gateway/algorithms"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+// routingPolicyEnabled applies the RoutingPolicy resources to the requests of their models, it requires the
+// RoutingPolicy CRD to be installed.
+var routingPolicyEnabled = utils.LoadEnvBool(EnvRoutingPolicyEnabled, false)
+
+// applyRoutingPolicy applies the RoutingPolicy of the request model: the algorithm of the policy replaces the
+// default algorithm, and the routing-strategy header unless the policy allows overrides. The parameters of the
+// policy are set when its algorithm routes the request. The request timeout of the policy applies to requests
+// without a timeout header.
+func (s *Server) applyRoutingPolicy(routingCtx *types.RoutingContext) {
+	if s.routingPolicies == nil {
+		return
+	}
+	policy, ok := s.routingPolicies.Get(routingCtx.Model)
+	if !ok {
+		return
+	}
+
+	// The timeout of the policy is the default, the x-request-timeout-ms header overrides it.
+	if policy.Spec.RequestTimeout != nil && policy.Spec.RequestTimeout.Duration > 0 && routingCtx.RequestDeadline.IsZero() {
+		routingCtx.SetTimeout(policy.Spec.RequestTimeout.Duration)
+	}
+
+	header, fromHeader := routingCtx.ReqHeaders[HeaderRoutingStrategy]
+	if fromHeader && policy.IsOverrideAllowed() {
+		return
+	}
+	algorithm, ok := routing.Validate(policy.Spec.Algorithm)
+	if !ok {
+		klog.ErrorS(nil, "unsupported routing algorithm in routing policy, ignoring the policy", "requestID", routingCtx.RequestID,
+			"policy", klog.KObj(policy), "algorithm", policy.Spec.Algorithm)
+		return
+	}
+	if fromHeader {
+		klog.V(4).InfoS("routing policy does not allow overrides, ignoring routing-strategy header", "requestID", routingCtx.RequestID,
+			"policy", klog.KObj(policy), "routing-strategy", header, "algorithm", algorithm)
+	}
+
+	routingCtx.Algorithm = algorithm
+	routingCtx.Parameters = policy.Spec.Parameters
+}
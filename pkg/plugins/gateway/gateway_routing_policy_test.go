@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	modelv1alpha1 "github.com/vllm-project/aibrix/api/model/v1alpha1"
+	"github.com/vllm-project/aibrix/pkg/cache"
+	"github.com/vllm-project/aibrix/pkg/client/clientset/versioned/fake"
+	routing "github.com/vllm-project/aibrix/pkg/plugins/gateway/algorithms"
+	"github.com/vllm-project/aibrix/pkg/plugins/gateway/routingpolicy"
+	"github.com/vllm-project/aibrix/pkg/types"
+)
+
+func TestApplyRoutingPolicy(t *testing.T) {
+	cache.NewForTest()
+	routing.Init()
+
+	client := fake.NewSimpleClientset(
+		&modelv1alpha1.RoutingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "fixed"},
+			Spec: modelv1alpha1.RoutingPolicySpec{
+				ModelName:     "fixed-model",
+				Algorithm:     "least-request",
+				Parameters:    map[string]string{"imbalance-abs-count": "4"},
+				AllowOverride: ptr.To(false),
+			},
+		},
+		&modelv1alpha1.RoutingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "overridable"},
+			Spec: modelv1alpha1.RoutingPolicySpec{ModelName: "overridable-model", Algorithm: "least-request",
+				RequestTimeout: &metav1.Duration{Duration: 5 * time.Second}},
+		},
+		&modelv1alpha1.RoutingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unsupported"},
+			Spec:       modelv1alpha1.RoutingPolicySpec{ModelName: "unsupported-model", Algorithm: "unknown"},
+		},
+	)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	policies := routingpolicy.NewStore()
+	require.NoError(t, policies.Start(client, stopCh))
+	require.Eventually(t, func() bool {
+		_, ok := policies.Get("unsupported-model")
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	s := &Server{routingPolicies: policies}
+
+	tests := []struct {
+		name               string
+		model              string
+		header             string
+		expectedAlgorithm  types.RoutingAlgorithm
+		expectedParameters map[string]string
+	}{
+		{name: "no policy", model: "other-model", expectedAlgorithm: routing.RouterNotSet},
+		{name: "no policy with header", model: "other-model", header: "random", expectedAlgorithm: "random"},
+		{name: "policy default", model: "overridable-model", expectedAlgorithm: "least-request"},
+		{name: "policy overridden by header", model: "overridable-model", header: "random", expectedAlgorithm: "random"},
+		{name: "policy without override", model: "fixed-model", header: "random", expectedAlgorithm: "least-request",
+			expectedParameters: map[string]string{"imbalance-abs-count": "4"}},
+		{name: "unsupported policy algorithm", model: "unsupported-model", expectedAlgorithm: routing.RouterNotSet},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var algorithm types.RoutingAlgorithm = routing.RouterNotSet
+			if tt.header != "" {
+				algorithm = types.RoutingAlgorithm(tt.header)
+			}
+			routingCtx := types.NewRoutingContext(context.Background(), algorithm, tt.model, "", "request", "")
+			defer routingCtx.Delete()
+			if tt.header != "" {
+				routingCtx.ReqHeaders[HeaderRoutingStrategy] = tt.header
+			}
+
+			s.applyRoutingPolicy(routingCtx)
+			assert.Equal(t, tt.expectedAlgorithm, routingCtx.Algorithm)
+			assert.Equal(t, tt.expectedParameters, routingCtx.Parameters)
+		})
+	}
+
+	// The request timeout of the policy applies whether the algorithm is overridden or not, unless set by header
+	routingCtx := types.NewRoutingContext(context.Background(), "random", "overridable-model", "", "request", "")
+	routingCtx.ReqHeaders[HeaderRoutingStrategy] = "random"
+	s.applyRoutingPolicy(routingCtx)
+	assert.Equal(t, routingCtx.RequestTime.Add(5*time.Second), routingCtx.RequestDeadline)
+	routingCtx.Delete()
+
+	routingCtx = types.NewRoutingContext(context.Background(), routing.RouterNotSet, "overridable-model", "", "request", "")
+	routingCtx.SetTimeout(time.Second)
+	s.applyRoutingPolicy(routingCtx)
+	assert.Equal(t, routingCtx.RequestTime.Add(time.Second), routingCtx.RequestDeadline)
+	routingCtx.Delete()
+
+	// Servers without routing policies leave the request untouched
+	routingCtx = types.NewRoutingContext(context.Background(), routing.RouterNotSet, "fixed-model", "", "request", "")
+	defer routingCtx.Delete()
+	(&Server{}).applyRoutingPolicy(routingCtx)
+	assert.Equal(t, types.RoutingAlgorithm(routing.RouterNotSet), routingCtx.Algorithm)
+}
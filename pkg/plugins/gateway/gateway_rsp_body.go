@@ -42,6 +42,7 @@ func (s *Server) HandleResponseBody(ctx context.Context, requestID string, req *
 	var usage openai.CompletionUsage
 	var promptTokens, completionTokens int64
 	var headers []*configPb.HeaderValueOption
+	var bodyMutation *extProcPb.BodyMutation
 	complete := hasCompleted
 	routerCtx, _ := ctx.(*types.RoutingContext)
 
@@ -80,6 +81,17 @@ func (s *Server) HandleResponseBody(ctx context.Context, requestID string, req *
 				}}},
 				err.Error()), complete
 		}
+		body := b.ResponseBody.GetBody()
+		if filter := s.streamUsageFilter(requestID); filter != nil {
+			// The usage is counted, but not relayed to the client that did not ask for it.
+			if filtered := filter.filter(body, b.ResponseBody.EndOfStream); !bytes.Equal(filtered, body) {
+				body = filtered
+				bodyMutation = &extProcPb.BodyMutation{
+					Mutation: &extProcPb.BodyMutation_Body{Body: body},
+				}
+			}
+		}
+		s.recordCachedResponse(ctx, requestID, body, b.ResponseBody.EndOfStream)
 	} else {
 		// Use request ID as a key to store per-request buffer
 		// Retrieve or create buffer
@@ -104,7 +116,14 @@ func (s *Server) HandleResponseBody(ctx context.Context, requestID string, req *
 		// Clean up the buffer after final processing
 		requestBuffers.Delete(requestID)
 
-		if err := json.Unmarshal(finalBody, &res); err != nil {
+		var err error
+		var responseModel string
+		if routerCtx != nil && isPoolingRequestPath(routerCtx.ReqPath) {
+			responseModel, usage, err = unmarshalPoolingResponse(finalBody)
+		} else if err = json.Unmarshal(finalBody, &res); err == nil {
+			responseModel = res.Model
+		}
+		if err != nil {
 			klog.ErrorS(err, "error to unmarshal response", "requestID", requestID, "responseBody", string(b.ResponseBody.GetBody()))
 			complete = true
 			return generateErrorResponse(
@@ -113,7 +132,7 @@ func (s *Server) HandleResponseBody(ctx context.Context, requestID string, req *
 					Key: HeaderErrorResponseUnmarshal, RawValue: []byte("true"),
 				}}},
 				err.Error()), complete
-		} else if len(res.Model) == 0 {
+		} else if len(responseModel) == 0 {
 			msg := ErrorUnknownResponse.Error()
 			responseBodyContent := string(b.ResponseBody.GetBody())
 			if len(responseBodyContent) != 0 {
@@ -128,8 +147,11 @@ func (s *Server) HandleResponseBody(ctx context.Context, requestID string, req *
 				}}},
 				msg), complete
 		}
-		// Do not overwrite model, res can be empty.
-		usage = res.Usage
+		if routerCtx == nil || !isPoolingRequestPath(routerCtx.ReqPath) {
+			// Do not overwrite model, res can be empty.
+			usage = res.Usage
+		}
+		s.recordCachedResponse(ctx, requestID, finalBody, true)
 	}
 
 	var requestEnd string
@@ -140,7 +162,7 @@ func (s *Server) HandleResponseBody(ctx context.Context, requestID string, req *
 		completionTokens = usage.CompletionTokens
 		// Count token per user.
 		if user.Name != "" {
-			tpm, err := s.ratelimiter.Incr(ctx, fmt.Sprintf("%v_TPM_CURRENT", user.Name), res.Usage.TotalTokens)
+			tpm, err := s.ratelimiter.Incr(ctx, fmt.Sprintf("%v_TPM_CURRENT", user.Name), usage.TotalTokens)
 			if err != nil {
 				return generateErrorResponse(
 					envoyTypePb.StatusCode_InternalServerError,
@@ -198,6 +220,7 @@ func (s *Server) HandleResponseBody(ctx context.Context, requestID string, req *
 					HeaderMutation: &extProcPb.HeaderMutation{
 						SetHeaders: headers,
 					},
+					BodyMutation: bodyMutation,
 				},
 			},
 		},
@@ -45,10 +45,14 @@ func (s *Server) HandleResponseHeaders(ctx context.Context, requestID string, mo
 	if routerCtx != nil && routerCtx.HasRouted() {
 		headers = buildEnvoyProxyHeaders(headers, HeaderTargetPod, routerCtx.TargetAddress())
 	}
+	if routerCtx != nil && routerCtx.ModelAlias != "" {
+		headers = buildEnvoyProxyHeaders(headers, HeaderResolvedModel, routerCtx.Model)
+	}
 
 	for _, headerValue := range b.ResponseHeaders.Headers.Headers {
 		if headerValue.Key == ":status" {
 			code, _ := strconv.Atoi(string(headerValue.RawValue))
+			s.recordPodResponse(routerCtx, code)
 			if code != 200 {
 				isProcessingError = true
 				processingErrorCode = code
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+// streamUsageInjectionEnabled sets stream_options.include_usage in streaming chat completion requests, so that
+// the usage of every streaming request is known to the gateway without clients asking for it. Disabled by default
+// as it rewrites the request bodies and the response streams.
+var streamUsageInjectionEnabled = utils.LoadEnvBool(EnvStreamUsageInjection, false)
+
+// Separators of server-sent events, engines end the lines of events with either LF or CRLF.
+var sseEventSeparators = [][]byte{[]byte("\n\n"), []byte("\r\n\r\n")}
+
+// injectsStreamUsage returns true if the gateway asks for the usage of streaming requests of the path.
+func (s *Server) injectsStreamUsage(requestPath string) bool {
+	return s.streamUsageInjection && requestPath == PathChatCompletions
+}
+
+// injectStreamUsage rewrites the body of a streaming chat completion request to include usage, and returns true if
+// the body is rewritten. The usage chunk is stripped from the response of the request by a streamUsageFilter.
+func (s *Server) injectStreamUsage(requestID string, routingCtx *types.RoutingContext, stream bool) bool {
+	if !stream || !s.injectsStreamUsage(routingCtx.ReqPath) {
+		return false
+	}
+
+	body, injected, err := setStreamIncludeUsage(routingCtx.ReqBody)
+	if err != nil {
+		klog.ErrorS(err, "failed to inject stream usage option", "requestID", requestID)
+		return false
+	}
+	if !injected {
+		return false
+	}
+	routingCtx.ReqBody = body
+	s.usageInjected.Store(requestID, &streamUsageFilter{})
+	return true
+}
+
+// streamUsageFilter returns the filter of the response if its usage chunk was not requested by the client, or nil.
+func (s *Server) streamUsageFilter(requestID string) *streamUsageFilter {
+	if filter, ok := s.usageInjected.Load(requestID); ok {
+		return filter.(*streamUsageFilter)
+	}
+	return nil
+}
+
+// forgetStreamUsage drops the injection state of a request when the request ends.
+func (s *Server) forgetStreamUsage(requestID string) {
+	s.usageInjected.Delete(requestID)
+}
+
+// setStreamIncludeUsage sets stream_options.include_usage of the request body, keeping other stream options.
+// It returns false if the request already includes usage.
+func setStreamIncludeUsage(body []byte) ([]byte, bool, error) {
+	var jsonMap map[string]json.RawMessage
+	if err := json.Unmarshal(body, &jsonMap); err != nil {
+		return nil, false, err
+	}
+	streamOptions := map[string]json.RawMessage{}
+	if raw, ok := jsonMap["stream_options"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &streamOptions); err != nil {
+			return nil, false, err
+		}
+	}
+	var includeUsage bool
+	if raw, ok := streamOptions["include_usage"]; ok {
+		_ = json.Unmarshal(raw, &includeUsage)
+	}
+	if includeUsage {
+		return body, false, nil
+	}
+
+	streamOptions["include_usage"] = json.RawMessage("true")
+	raw, err := json.Marshal(streamOptions)
+	if err != nil {
+		return nil, false, err
+	}
+	jsonMap["stream_options"] = raw
+	body, err = json.Marshal(jsonMap)
+	if err != nil {
+		return nil, false, err
+	}
+	return body, true, nil
+}
+
+// streamUsageFilter removes the usage-only events from the chunks of a streaming response. Events may span chunks,
+// the incomplete event at the end of a chunk is held back until the following chunk completes it.
+type streamUsageFilter struct {
+	pending []byte // Incomplete event at the end of the last chunk.
+}
+
+// filter returns the events of the chunk completed so far, without usage-only events. At the end of the stream, the
+// incomplete event held back is returned as well.
+func (f *streamUsageFilter) filter(chunk []byte, endOfStream bool) []byte {
+	data := append(f.pending, chunk...)
+	f.pending = nil
+	filtered := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		end := sseEventEnd(data)
+		if end < 0 {
+			if !endOfStream {
+				f.pending = data
+				break
+			}
+			end = len(data)
+		}
+		if event := data[:end]; !isUsageOnlyEvent(event) {
+			filtered = append(filtered, event...)
+		}
+		data = data[end:]
+	}
+	return filtered
+}
+
+// sseEventEnd returns the end of the first event of data including its separator, or -1 if the event is incomplete.
+func sseEventEnd(data []byte) int {
+	end := -1
+	for _, separator := range sseEventSeparators {
+		if i := bytes.Index(data, separator); i >= 0 && (end < 0 || i+len(separator) < end) {
+			end = i + len(separator)
+		}
+	}
+	return end
+}
+
+// isUsageOnlyEvent returns true if the event is the final chunk of a stream with usage and no choices.
+func isUsageOnlyEvent(event []byte) bool {
+	line := bytes.TrimSpace(event)
+	if !bytes.HasPrefix(line, []byte("data:")) {
+		return false
+	}
+	var chunk struct {
+		Choices []json.RawMessage `json:"choices"`
+		Usage   json.RawMessage   `json:"usage"`
+	}
+	if err := json.Unmarshal(bytes.TrimSpace(line[len("data:"):]), &chunk); err != nil {
+		return false
+	}
+	return len(chunk.Choices) == 0 && len(chunk.Usage) > 0 && string(chunk.Usage) != "null"
+}
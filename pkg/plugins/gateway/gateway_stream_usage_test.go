@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+func TestSetStreamIncludeUsage(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		injected      bool
+		streamOptions string
+	}{
+		{name: "no stream options", body: `{"model":"llama","stream":true}`, injected: true, streamOptions: `{"include_usage":true}`},
+		{name: "null stream options", body: `{"model":"llama","stream":true,"stream_options":null}`, injected: true, streamOptions: `{"include_usage":true}`},
+		{name: "usage not included", body: `{"model":"llama","stream":true,"stream_options":{"include_usage":false,"continuous_usage_stats":false}}`,
+			injected: true, streamOptions: `{"continuous_usage_stats":false,"include_usage":true}`},
+		{name: "usage included", body: `{"model":"llama","stream":true,"stream_options":{"include_usage":true}}`, injected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, injected, err := setStreamIncludeUsage([]byte(tt.body))
+			require.NoError(t, err)
+			assert.Equal(t, tt.injected, injected)
+			if !tt.injected {
+				assert.Equal(t, tt.body, string(body))
+				return
+			}
+			var jsonMap map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(body, &jsonMap))
+			assert.JSONEq(t, tt.streamOptions, string(jsonMap["stream_options"]))
+			assert.Equal(t, `"llama"`, string(jsonMap["model"]))
+		})
+	}
+
+	_, _, err := setStreamIncludeUsage([]byte(`{"stream_options":"invalid"}`))
+	assert.Error(t, err)
+}
+
+func TestStreamUsageFilter(t *testing.T) {
+	content := "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"hi\"}}],\"usage\":null}\n\n"
+	usage := "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":1,\"completion_tokens\":2,\"total_tokens\":3}}\n\n"
+	done := "data: [DONE]\n\n"
+	crlf := func(event string) string { return strings.ReplaceAll(event, "\n", "\r\n") }
+
+	assert.Equal(t, content+done, string((&streamUsageFilter{}).filter([]byte(content+usage+done), true)))
+	assert.Equal(t, content+done, string((&streamUsageFilter{}).filter([]byte(content+done), true)))
+	assert.Equal(t, crlf(content+done), string((&streamUsageFilter{}).filter([]byte(crlf(content+usage+done)), true)))
+
+	// Events split across chunks are held back until complete
+	filter := &streamUsageFilter{}
+	stream := content + usage + done
+	var relayed string
+	last := 0
+	for _, split := range []int{10, len(content) + 5, len(content) + len(usage) - 1, len(stream) - 2} {
+		relayed += string(filter.filter([]byte(stream[last:split]), false))
+		last = split
+		assert.NotContains(t, relayed, "usage\":{")
+	}
+	assert.Equal(t, content, relayed)
+	relayed += string(filter.filter([]byte(stream[last:]), true))
+	assert.Equal(t, content+done, relayed)
+
+	// An incomplete event at the end of the stream is relayed as is
+	assert.Equal(t, content+"data: [DONE]", string((&streamUsageFilter{}).filter([]byte(content+"data: [DONE]"), true)))
+}
+
+func TestInjectedStreamUsage(t *testing.T) {
+	mockCache := &MockCache{}
+	s := &Server{cache: mockCache, streamUsageInjection: true}
+	ctx := context.Background()
+
+	routingCtx := types.NewRoutingContext(ctx, "", "llama", "", "request-1", "")
+	routingCtx.ReqPath = PathChatCompletions
+	routingCtx.ReqBody = []byte(`{"model":"llama","stream":true,"messages":[{"role":"user","content":"hi"}]}`)
+	assert.True(t, s.injectStreamUsage("request-1", routingCtx, true))
+	assert.Contains(t, string(routingCtx.ReqBody), `"include_usage":true`)
+	assert.NotNil(t, s.streamUsageFilter("request-1"))
+
+	// Content chunks are relayed untouched
+	content := "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"hi\"}}]}\n\n"
+	resp, complete := s.HandleResponseBody(routingCtx, "request-1", &extProcPb.ProcessingRequest{
+		Request: &extProcPb.ProcessingRequest_ResponseBody{
+			ResponseBody: &extProcPb.HttpBody{Body: []byte(content)},
+		},
+	}, utils.User{}, 0, "llama", true, 1, false)
+	assert.False(t, complete)
+	assert.Nil(t, resp.GetResponseBody().GetResponse().GetBodyMutation())
+
+	// The usage chunk is counted but stripped from the response
+	mockCache.On("DoneRequestTrace", routingCtx, "request-1", "llama", int64(1), int64(2), int64(1)).Once()
+	usage := "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":1,\"completion_tokens\":2,\"total_tokens\":3}}\n\n"
+	resp, complete = s.HandleResponseBody(routingCtx, "request-1", &extProcPb.ProcessingRequest{
+		Request: &extProcPb.ProcessingRequest_ResponseBody{
+			ResponseBody: &extProcPb.HttpBody{Body: []byte(usage + "data: [DONE]\n\n"), EndOfStream: true},
+		},
+	}, utils.User{}, 0, "llama", true, 1, false)
+	assert.True(t, complete)
+	assert.Equal(t, "data: [DONE]\n\n", string(resp.GetResponseBody().GetResponse().GetBodyMutation().GetBody()))
+	mockCache.AssertExpectations(t)
+
+	s.forgetStreamUsage("request-1")
+	assert.Nil(t, s.streamUsageFilter("request-1"))
+
+	// Requests including usage, non-streaming requests and other paths are not rewritten
+	routingCtx = types.NewRoutingContext(ctx, "", "llama", "", "request-2", "")
+	defer routingCtx.Delete()
+	routingCtx.ReqPath = PathChatCompletions
+	routingCtx.ReqBody = []byte(`{"model":"llama","stream":true,"stream_options":{"include_usage":true}}`)
+	assert.False(t, s.injectStreamUsage("request-2", routingCtx, true))
+	assert.False(t, s.injectStreamUsage("request-2", routingCtx, false))
+	routingCtx.ReqPath = PathCompletions
+	assert.False(t, s.injectStreamUsage("request-2", routingCtx, true))
+	assert.Nil(t, s.streamUsageFilter("request-2"))
+
+	// Nothing is rewritten if stream usage injection is disabled
+	s.streamUsageInjection = false
+	routingCtx.ReqPath = PathChatCompletions
+	routingCtx.ReqBody = []byte(`{"model":"llama","stream":true}`)
+	assert.False(t, s.injectStreamUsage("request-2", routingCtx, true))
+	assert.Nil(t, s.streamUsageFilter("request-2"))
+	mockCache.AssertNotCalled(t, "DoneRequestTrace", mock.Anything, "request-2", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
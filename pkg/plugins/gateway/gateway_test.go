@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/vllm-project/aibrix/pkg/cache"
@@ -139,6 +140,53 @@ func TestGetRoutingStrategy(t *testing.T) {
 	}
 }
 
+func TestHandleRequestHeaders_ReqHeaders(t *testing.T) {
+	header := func(key, value string) *configPb.HeaderValue {
+		return &configPb.HeaderValue{Key: key, RawValue: []byte(value)}
+	}
+	req := &extProcPb.ProcessingRequest{
+		Request: &extProcPb.ProcessingRequest_RequestHeaders{
+			RequestHeaders: &extProcPb.HttpHeaders{
+				Headers: &configPb.HeaderMap{Headers: []*configPb.HeaderValue{
+					header(":path", "/v1/chat/completions"),
+					header("X-Session-Id", "session-a"),
+					header("Content-Length", "42"),
+					header("x-client-header", "client"),
+				}},
+			},
+		},
+	}
+
+	resp, _, _, routingCtx := (&Server{}).HandleRequestHeaders(context.Background(), "request-1", req)
+	assert.NotNil(t, resp.GetRequestHeaders())
+	assert.NotNil(t, routingCtx)
+	defer routingCtx.Delete()
+
+	// The session header reaches the session affinity router, pseudo and hop-by-hop headers are dropped.
+	assert.Equal(t, map[string]string{"x-session-id": "session-a", "x-client-header": "client"}, routingCtx.ReqHeaders)
+	assert.Equal(t, "/v1/chat/completions", routingCtx.ReqPath)
+}
+
+func TestRequestPriority(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     utils.User
+		header   string
+		expected string
+	}{
+		{name: "no priority", user: utils.User{Name: "u"}, header: "", expected: ""},
+		{name: "header priority", user: utils.User{Name: "u"}, header: " interactive ", expected: "interactive"},
+		{name: "user priority", user: utils.User{Name: "u", Priority: "batch"}, header: "", expected: "batch"},
+		{name: "user priority overrides header", user: utils.User{Name: "u", Priority: "batch"}, header: "interactive", expected: "batch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, requestPriority(tt.user, tt.header))
+		})
+	}
+}
+
 func Test_buildEnvoyProxyHeaders(t *testing.T) {
 	headers := []*configPb.HeaderValueOption{}
 
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/stretchr/testify/mock"
+	modelv1alpha1 "github.com/vllm-project/aibrix/api/model/v1alpha1"
 	"github.com/vllm-project/aibrix/pkg/cache"
 	"github.com/vllm-project/aibrix/pkg/metrics"
 	"github.com/vllm-project/aibrix/pkg/types"
@@ -50,6 +51,9 @@ func (m *mockRouter) Name() string { return "mock-router" }
 type MockCache struct {
 	mock.Mock
 	cache.Cache
+
+	// aliases are returned by GetModelAlias without expectations, so that tests may ignore model aliases.
+	aliases map[string][]modelv1alpha1.ModelAliasBackend
 }
 
 func (m *MockCache) HasModel(model string) bool {
@@ -104,6 +108,11 @@ func (m *MockCache) ListModelsByPod(namespace string, podName string) ([]string,
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *MockCache) GetModelAlias(alias string) ([]modelv1alpha1.ModelAliasBackend, bool) {
+	backends, ok := m.aliases[alias]
+	return backends, ok
+}
+
 // MockGatewayClient implements gatewayapi.Clientset interface
 type MockGatewayClient struct {
 	mock.Mock
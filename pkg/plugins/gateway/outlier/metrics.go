@@ -0,0 +1,39 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outlier
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	podStateGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "aibrix_gateway_outlier_pod_state",
+			Help: "Outlier detection state of a pod: 0 closed, 1 open (ejected), 2 half-open",
+		},
+		[]string{"namespace", "pod"},
+	)
+	ejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "aibrix_gateway_outlier_ejections_total",
+			Help: "Total number of times a pod was ejected from routing",
+		},
+		[]string{"namespace", "pod"},
+	)
+)
@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outlier
+
+import (
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+const (
+	DefaultConsecutiveErrors = 5
+	DefaultErrorRate         = 0.5
+	DefaultMinRequests       = 10
+	DefaultInterval          = 30 * time.Second
+	DefaultBaseEjectionTime  = 30 * time.Second
+	DefaultMaxEjectionTime   = 5 * time.Minute
+)
+
+type Options struct {
+	// ConsecutiveErrors is the number of consecutive errors that ejects a pod.
+	ConsecutiveErrors int
+	// ErrorRate is the ratio of errors within Interval that ejects a pod.
+	ErrorRate float64
+	// MinRequests is the minimum number of requests within Interval for ErrorRate to apply.
+	MinRequests int
+	// Interval is the window the error rate is computed over.
+	Interval time.Duration
+	// BaseEjectionTime is the duration of the first ejection, doubled on each ejection that follows.
+	BaseEjectionTime time.Duration
+	// MaxEjectionTime caps the ejection duration. A pod without ejection for this long is forgiven its past ejections.
+	MaxEjectionTime time.Duration
+}
+
+// State is the circuit breaker state of a pod.
+type State int
+
+const (
+	// StateClosed pods receive traffic.
+	StateClosed State = iota
+	// StateOpen pods are ejected from routing.
+	StateOpen
+	// StateHalfOpen pods finished their ejection and receive a single probe request.
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// Detector ejects the pods returning server errors from routing, and readmits them through half-open probing.
+type Detector struct {
+	opts Options
+	now  func() time.Time
+
+	mu        sync.Mutex
+	pods      map[string]*podState // pod key -> state
+	lastPrune time.Time
+}
+
+// podState tracks the responses of a pod.
+type podState struct {
+	namespace, name string
+	state           State
+
+	consecutiveErrors int
+	requests, errors  int // Responses within the current window.
+	windowStart       time.Time
+	lastSeen          time.Time
+
+	ejections     int // Ejections since the pod was last forgiven, the exponent of the ejection duration.
+	lastEjection  time.Time
+	ejectedUntil  time.Time
+	probing       bool // A probe request is in flight in half-open state.
+	probeDeadline time.Time
+}
+
+func NewDetector(opts Options) *Detector {
+	if opts.ConsecutiveErrors <= 0 {
+		opts.ConsecutiveErrors = DefaultConsecutiveErrors
+	}
+	if opts.ErrorRate <= 0 {
+		opts.ErrorRate = DefaultErrorRate
+	}
+	if opts.MinRequests <= 0 {
+		opts.MinRequests = DefaultMinRequests
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.BaseEjectionTime <= 0 {
+		opts.BaseEjectionTime = DefaultBaseEjectionTime
+	}
+	if opts.MaxEjectionTime < opts.BaseEjectionTime {
+		opts.MaxEjectionTime = max(DefaultMaxEjectionTime, opts.BaseEjectionTime)
+	}
+	return &Detector{
+		opts: opts,
+		now:  time.Now,
+		pods: map[string]*podState{},
+	}
+}
+
+// Filter returns the pods that can be routed to. Ejected pods whose ejection is over turn half-open, and
+// half-open pods are kept out while their probe is in flight. If all pods are ejected, all pods are returned
+// so that the model stays available.
+func (d *Detector) Filter(pods []*v1.Pod) []*v1.Pod {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	routable := make([]*v1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		ps, ok := d.pods[utils.GeneratePodKey(pod.Namespace, pod.Name)]
+		if !ok {
+			routable = append(routable, pod)
+			continue
+		}
+		if ps.state == StateOpen && !now.Before(ps.ejectedUntil) {
+			d.setState(ps, StateHalfOpen)
+			klog.InfoS("readmitting ejected pod for probing", "pod", klog.KRef(ps.namespace, ps.name))
+		}
+		switch ps.state {
+		case StateClosed:
+			routable = append(routable, pod)
+		case StateHalfOpen:
+			if !ps.probing || now.After(ps.probeDeadline) {
+				routable = append(routable, pod)
+			}
+		}
+	}
+
+	if len(routable) == 0 && len(pods) > 0 {
+		klog.V(4).InfoS("all pods are ejected, ignoring outlier detection", "pods", len(pods))
+		return pods
+	}
+	return routable
+}
+
+// OnRouted records that a request is routed to the pod, which starts the probe of a half-open pod.
+func (d *Detector) OnRouted(pod *v1.Pod) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if ps, ok := d.pods[utils.GeneratePodKey(pod.Namespace, pod.Name)]; ok && ps.state == StateHalfOpen {
+		ps.probing = true
+		// A probe without response, e.g. canceled by the client, does not hold the pod forever.
+		ps.probeDeadline = d.now().Add(d.opts.BaseEjectionTime)
+	}
+}
+
+// Record records the response status code of a request routed to the pod, 5xx status codes are errors.
+func (d *Detector) Record(pod *v1.Pod, statusCode int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	d.pruneLocked(now)
+
+	key := utils.GeneratePodKey(pod.Namespace, pod.Name)
+	ps, ok := d.pods[key]
+	if !ok {
+		ps = &podState{namespace: pod.Namespace, name: pod.Name, windowStart: now}
+		d.pods[key] = ps
+	}
+	ps.lastSeen = now
+	isError := statusCode >= 500
+
+	switch ps.state {
+	case StateOpen:
+		// Response of a request routed before the ejection.
+		return
+	case StateHalfOpen:
+		ps.probing = false
+		if isError {
+			d.ejectLocked(ps, now, "probe failed")
+		} else {
+			d.setState(ps, StateClosed)
+			klog.InfoS("ejected pod recovered", "pod", klog.KRef(ps.namespace, ps.name), "statusCode", statusCode)
+		}
+		return
+	}
+
+	if ps.ejections > 0 && now.Sub(ps.lastEjection) > d.opts.MaxEjectionTime {
+		ps.ejections = 0
+	}
+	if now.Sub(ps.windowStart) > d.opts.Interval {
+		ps.requests, ps.errors, ps.windowStart = 0, 0, now
+	}
+	ps.requests++
+	if !isError {
+		ps.consecutiveErrors = 0
+		return
+	}
+	ps.errors++
+	ps.consecutiveErrors++
+
+	if ps.consecutiveErrors >= d.opts.ConsecutiveErrors {
+		d.ejectLocked(ps, now, "consecutive errors")
+	} else if ps.requests >= d.opts.MinRequests && float64(ps.errors)/float64(ps.requests) >= d.opts.ErrorRate {
+		d.ejectLocked(ps, now, "error rate")
+	}
+}
+
+// State returns the state of the pod.
+func (d *Detector) State(pod *v1.Pod) State {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if ps, ok := d.pods[utils.GeneratePodKey(pod.Namespace, pod.Name)]; ok {
+		return ps.state
+	}
+	return StateClosed
+}
+
+// ejectLocked ejects the pod for BaseEjectionTime * 2^ejections, capped by MaxEjectionTime.
+func (d *Detector) ejectLocked(ps *podState, now time.Time, reason string) {
+	duration := d.opts.BaseEjectionTime
+	for i := 0; i < ps.ejections && duration < d.opts.MaxEjectionTime; i++ {
+		duration *= 2
+	}
+	duration = min(duration, d.opts.MaxEjectionTime)
+
+	ps.ejections++
+	ps.lastEjection = now
+	ps.ejectedUntil = now.Add(duration)
+	ps.consecutiveErrors, ps.requests, ps.errors, ps.windowStart = 0, 0, 0, now
+	d.setState(ps, StateOpen)
+	ejectionsTotal.WithLabelValues(ps.namespace, ps.name).Inc()
+
+	klog.InfoS("ejecting pod from routing", "pod", klog.KRef(ps.namespace, ps.name), "reason", reason,
+		"ejections", ps.ejections, "duration", duration)
+}
+
+func (d *Detector) setState(ps *podState, state State) {
+	ps.state = state
+	podStateGauge.WithLabelValues(ps.namespace, ps.name).Set(float64(state))
+}
+
+// pruneLocked forgets the closed pods without response for MaxEjectionTime, e.g. deleted pods.
+func (d *Detector) pruneLocked(now time.Time) {
+	if now.Sub(d.lastPrune) < d.opts.Interval {
+		return
+	}
+	d.lastPrune = now
+	for key, ps := range d.pods {
+		if ps.state == StateClosed && now.Sub(ps.lastSeen) > d.opts.MaxEjectionTime {
+			delete(d.pods, key)
+			podStateGauge.DeleteLabelValues(ps.namespace, ps.name)
+			ejectionsTotal.DeleteLabelValues(ps.namespace, ps.name)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outlier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestDetector() (*Detector, *time.Time) {
+	now := time.Unix(0, 0)
+	d := NewDetector(Options{
+		ConsecutiveErrors: 3,
+		ErrorRate:         0.5,
+		MinRequests:       4,
+		Interval:          10 * time.Second,
+		BaseEjectionTime:  10 * time.Second,
+		MaxEjectionTime:   30 * time.Second,
+	})
+	d.now = func() time.Time { return now }
+	return d, &now
+}
+
+func newTestPod(name string) *v1.Pod {
+	return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+}
+
+func TestDetectorConsecutiveErrors(t *testing.T) {
+	d, _ := newTestDetector()
+	p1, p2 := newTestPod("p1"), newTestPod("p2")
+	pods := []*v1.Pod{p1, p2}
+
+	for _, code := range []int{500, 500, 200, 200, 200, 200, 429, 500, 503} {
+		d.Record(p1, code)
+	}
+	assert.Equal(t, StateClosed, d.State(p1))
+	assert.Equal(t, pods, d.Filter(pods))
+
+	d.Record(p1, 502)
+	assert.Equal(t, StateOpen, d.State(p1))
+	assert.Equal(t, []*v1.Pod{p2}, d.Filter(pods))
+
+	// All pods ejected, outlier detection is ignored
+	for i := 0; i < 3; i++ {
+		d.Record(p2, 500)
+	}
+	assert.Equal(t, pods, d.Filter(pods))
+}
+
+func TestDetectorErrorRate(t *testing.T) {
+	d, now := newTestDetector()
+	p1 := newTestPod("p1")
+
+	// Errors of an expired window are forgotten
+	d.Record(p1, 500)
+	d.Record(p1, 200)
+	*now = now.Add(11 * time.Second)
+	d.Record(p1, 500)
+	d.Record(p1, 200)
+	d.Record(p1, 200)
+	assert.Equal(t, StateClosed, d.State(p1))
+
+	d.Record(p1, 500)
+	assert.Equal(t, StateOpen, d.State(p1))
+}
+
+func TestDetectorHalfOpen(t *testing.T) {
+	d, now := newTestDetector()
+	p1, p2 := newTestPod("p1"), newTestPod("p2")
+	pods := []*v1.Pod{p1, p2}
+	eject := func() {
+		for i := 0; i < 3; i++ {
+			d.Record(p1, 500)
+		}
+	}
+
+	// Ejection times grow exponentially: 10s, 20s, then capped at 30s
+	for _, ejection := range []time.Duration{10 * time.Second, 20 * time.Second, 30 * time.Second} {
+		if d.State(p1) == StateClosed {
+			eject()
+		}
+		*now = now.Add(ejection - time.Second)
+		assert.Equal(t, []*v1.Pod{p2}, d.Filter(pods))
+
+		// Readmitted for a single probe
+		*now = now.Add(time.Second)
+		assert.Equal(t, pods, d.Filter(pods))
+		assert.Equal(t, StateHalfOpen, d.State(p1))
+		d.OnRouted(p1)
+		assert.Equal(t, []*v1.Pod{p2}, d.Filter(pods))
+
+		// Failed probe ejects the pod again
+		d.Record(p1, 500)
+		assert.Equal(t, StateOpen, d.State(p1))
+	}
+
+	*now = now.Add(30 * time.Second)
+	assert.Equal(t, pods, d.Filter(pods))
+	d.OnRouted(p1)
+	d.Record(p1, 200)
+	assert.Equal(t, StateClosed, d.State(p1))
+
+	// A probe without response does not hold the pod forever
+	*now = now.Add(31 * time.Second)
+	eject()
+	*now = now.Add(10 * time.Second)
+	d.Filter(pods)
+	d.OnRouted(p1)
+	*now = now.Add(11 * time.Second)
+	assert.Equal(t, pods, d.Filter(pods))
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/vllm-project/aibrix/pkg/types"
+)
+
+// DeadlineFunc returns the deadline of a request, false if the request has no deadline.
+type DeadlineFunc func(ctx *types.RoutingContext) (time.Time, bool)
+
+type edfEntry struct {
+	ctx         *types.RoutingContext
+	deadline    time.Time
+	hasDeadline bool
+	seq         uint64
+	missed      bool // The request can no longer meet its deadline.
+	demoted     bool // The request is dequeued after the requests not demoted.
+	index       int
+}
+
+type edfHeap []*edfEntry
+
+func (h edfHeap) Len() int { return len(h) }
+
+func (h edfHeap) Less(i, j int) bool {
+	if h[i].demoted != h[j].demoted {
+		return !h[i].demoted
+	}
+	if h[i].hasDeadline != h[j].hasDeadline {
+		return h[i].hasDeadline
+	}
+	if h[i].hasDeadline && !h[i].deadline.Equal(h[j].deadline) {
+		return h[i].deadline.Before(h[j].deadline)
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h edfHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *edfHeap) Push(x any) {
+	entry := x.(*edfEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *edfHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return entry
+}
+
+// EDFQueue is a RouterQueue dequeuing requests in the order of their deadlines, earliest deadline first.
+// Requests without deadline are dequeued after the requests with deadline, in arrival order. A request can be
+// demoted behind all requests not demoted, e.g. if it can no longer meet its deadline.
+type EDFQueue struct {
+	deadline DeadlineFunc
+
+	mu      sync.Mutex
+	entries edfHeap
+	index   map[*types.RoutingContext]*edfEntry
+	seq     uint64
+}
+
+// NewEDFQueue creates an EDFQueue, the deadline of a request is evaluated once on Enqueue().
+func NewEDFQueue(deadline DeadlineFunc) *EDFQueue {
+	return &EDFQueue{
+		deadline: deadline,
+		index:    make(map[*types.RoutingContext]*edfEntry),
+	}
+}
+
+func (q *EDFQueue) Enqueue(ctx *types.RoutingContext, _ time.Time) error {
+	if ctx == nil {
+		return ErrZeroValueNotSupported
+	}
+	deadline, ok := q.deadline(ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entry := &edfEntry{ctx: ctx, deadline: deadline, hasDeadline: ok, seq: q.seq}
+	q.seq++
+	heap.Push(&q.entries, entry)
+	q.index[ctx] = entry
+	return nil
+}
+
+func (q *EDFQueue) Peek(_ time.Time, _ types.PodList) (*types.RoutingContext, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.entries) == 0 {
+		return nil, types.ErrQueueEmpty
+	}
+	return q.entries[0].ctx, nil
+}
+
+func (q *EDFQueue) Dequeue(_ time.Time) (*types.RoutingContext, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.entries) == 0 {
+		return nil, types.ErrQueueEmpty
+	}
+	entry := heap.Pop(&q.entries).(*edfEntry)
+	delete(q.index, entry.ctx)
+	return entry.ctx, nil
+}
+
+func (q *EDFQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.entries)
+}
+
+// RemoveIf removes the queued requests matching remove wherever they are in the queue. It always returns true.
+func (q *EDFQueue) RemoveIf(remove func(*types.RoutingContext) bool) ([]*types.RoutingContext, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var removed []*types.RoutingContext
+	kept := q.entries[:0]
+	for _, entry := range q.entries {
+		if remove(entry.ctx) {
+			removed = append(removed, entry.ctx)
+			delete(q.index, entry.ctx)
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	for i := len(kept); i < len(q.entries); i++ {
+		q.entries[i] = nil
+	}
+	q.entries = kept
+	for i, entry := range q.entries {
+		entry.index = i
+	}
+	heap.Init(&q.entries)
+	return removed, true
+}
+
+// Deadline returns the deadline of a queued request, false if the request has no deadline or is not queued.
+func (q *EDFQueue) Deadline(ctx *types.RoutingContext) (time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if entry, ok := q.index[ctx]; ok && entry.hasDeadline {
+		return entry.deadline, true
+	}
+	return time.Time{}, false
+}
+
+// markMissed marks the queued request as missing its deadline, and returns true if it was not marked before.
+func (q *EDFQueue) markMissed(ctx *types.RoutingContext) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entry, ok := q.index[ctx]
+	if !ok || entry.missed {
+		return false
+	}
+	entry.missed = true
+	return true
+}
+
+// demote moves the queued request behind all requests not demoted, and returns true if it was not demoted before.
+func (q *EDFQueue) demote(ctx *types.RoutingContext) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entry, ok := q.index[ctx]
+	if !ok || entry.demoted {
+		return false
+	}
+	entry.demoted = true
+	heap.Fix(&q.entries, entry.index)
+	return true
+}
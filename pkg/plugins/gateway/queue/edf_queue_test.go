@@ -0,0 +1,267 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vllm-project/aibrix/pkg/cache"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+// firstPodRouter routes requests to the first pod.
+type firstPodRouter struct{}
+
+func (r *firstPodRouter) Route(ctx *types.RoutingContext, pods types.PodList) (string, error) {
+	ctx.SetTargetPod(pods.All()[0])
+	return ctx.TargetAddress(), nil
+}
+
+var _ = Describe("EDFQueue", func() {
+	var (
+		queue *EDFQueue
+		now   time.Time
+	)
+
+	newRequest := func(requestID string) *types.RoutingContext {
+		return types.NewRoutingContext(context.Background(), "", "model", "", requestID, "")
+	}
+
+	dequeue := func() string {
+		ctx, err := queue.Peek(now, nil)
+		Expect(err).ToNot(HaveOccurred())
+		dequeued, err := queue.Dequeue(now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dequeued).To(BeIdenticalTo(ctx))
+		return dequeued.RequestID
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+		deadlines := map[string]time.Duration{"early": time.Second, "late": 3 * time.Second, "late-too": 3 * time.Second, "middle": 2 * time.Second}
+		queue = NewEDFQueue(func(ctx *types.RoutingContext) (time.Time, bool) {
+			deadline, ok := deadlines[ctx.RequestID]
+			return now.Add(deadline), ok
+		})
+	})
+
+	It("should dequeue the earliest deadline first", func() {
+		for _, id := range []string{"late", "none", "middle", "late-too", "early", "none-too"} {
+			Expect(queue.Enqueue(newRequest(id), now)).To(Succeed())
+		}
+		Expect(queue.Len()).To(Equal(6))
+
+		deadline, ok := queue.Deadline(queue.entries[0].ctx)
+		Expect(ok).To(BeTrue())
+		Expect(deadline).To(Equal(now.Add(time.Second)))
+
+		// Ties and requests without deadline are dequeued in arrival order.
+		for _, id := range []string{"early", "middle", "late", "late-too", "none", "none-too"} {
+			Expect(dequeue()).To(Equal(id))
+		}
+		Expect(queue.Len()).To(Equal(0))
+		_, err := queue.Peek(now, nil)
+		Expect(err).To(Equal(types.ErrQueueEmpty))
+		_, err = queue.Dequeue(now)
+		Expect(err).To(Equal(types.ErrQueueEmpty))
+	})
+
+	It("should dequeue demoted requests last", func() {
+		early, middle := newRequest("early"), newRequest("middle")
+		Expect(queue.Enqueue(middle, now)).To(Succeed())
+		Expect(queue.Enqueue(early, now)).To(Succeed())
+		Expect(queue.Enqueue(newRequest("none"), now)).To(Succeed())
+
+		Expect(queue.demote(early)).To(BeTrue())
+		Expect(queue.demote(early)).To(BeFalse())
+		Expect(queue.markMissed(early)).To(BeTrue())
+		Expect(queue.markMissed(early)).To(BeFalse())
+
+		for _, id := range []string{"middle", "none", "early"} {
+			Expect(dequeue()).To(Equal(id))
+		}
+		Expect(queue.demote(early)).To(BeFalse(), "dequeued requests can not be demoted")
+	})
+
+	It("should reject nil request", func() {
+		Expect(queue.Enqueue(nil, now)).To(Equal(ErrZeroValueNotSupported))
+	})
+})
+
+var _ = Describe("SLOQueue in EDF mode", func() {
+	var (
+		model      = "llama2-7b"
+		deployment = "simulator-llama2-7b-a100"
+		pods       types.PodList
+		now        time.Time
+	)
+
+	BeforeEach(func() {
+		data, err := os.ReadFile("../../../../python/aibrix/aibrix/gpu_optimizer/optimizer/profiling/result/simulator-llama2-7b-a100.json")
+		Expect(err).ToNot(HaveOccurred())
+		profile := &cache.ModelGPUProfile{}
+		Expect(profile.Unmarshal(data)).To(Succeed())
+		Expect(profile.SLOs.E2E).To(Equal(5.0))
+
+		store := cache.InitWithPods(cache.InitForTest(), []*v1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      deployment + "-replicaset-pod1",
+				Namespace: "default",
+				Labels:    map[string]string{utils.DeploymentIdentifier: deployment},
+			},
+			Status: v1.PodStatus{
+				PodIP:      "1.0.0.1",
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+			},
+		}}, model)
+		store.UpdateModelProfile(cache.ModelGPUProfileKey(model, deployment), profile, true)
+		pods, err = store.ListPodsByModel(model)
+		Expect(err).ToNot(HaveOccurred())
+		now = time.Now()
+	})
+
+	newQueue := func(opts SLOQueueOptions) *SLOQueue {
+		q, err := NewSLOQueueWithOptions(func(*types.RoutingContext) (types.Router, error) {
+			return &firstPodRouter{}, nil
+		}, model, opts)
+		Expect(err).ToNot(HaveOccurred())
+		return q
+	}
+
+	// enqueue enqueues a request arrived before now.
+	enqueue := func(q *SLOQueue, requestID string, arrived time.Duration) *types.RoutingContext {
+		ctx := types.NewRoutingContext(context.Background(), "slo", model, "message", requestID, "")
+		ctx.RequestTime = now.Add(-arrived)
+		Expect(q.Enqueue(ctx, now)).To(Succeed())
+		return ctx
+	}
+
+	peekAndDequeue := func(q *SLOQueue) (*types.RoutingContext, error) {
+		ctx, err := q.Peek(now, pods)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctx).ToNot(BeNil())
+		routingErr := q.LastError()
+		dequeued, err := q.Dequeue(now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dequeued).To(BeIdenticalTo(ctx))
+		return dequeued, routingErr
+	}
+
+	It("should reject unknown miss policy", func() {
+		_, err := NewSLOQueueWithOptions(nil, model, SLOQueueOptions{EDF: true, MissPolicy: "drop"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should dequeue in deadline order", func() {
+		fifo := newQueue(SLOQueueOptions{})
+		edf := newQueue(SLOQueueOptions{EDF: true})
+		for _, q := range []*SLOQueue{fifo, edf} {
+			enqueue(q, "recent", 0)
+			enqueue(q, "older", 2*time.Second)
+		}
+
+		ctx, err := peekAndDequeue(fifo)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctx.RequestID).To(Equal("recent"))
+
+		ctx, err = peekAndDequeue(edf)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctx.RequestID).To(Equal("older"))
+		Expect(ctx.HasRouted()).To(BeTrue())
+	})
+
+	It("should compute deadlines from the SLO target", func() {
+		q := newQueue(SLOQueueOptions{EDF: true})
+		ctx := enqueue(q, "request", time.Second)
+		q.subs.Range(func(_ string, sub types.RouterQueue[*types.RoutingContext]) bool {
+			deadline, ok := sub.(*EDFQueue).Deadline(ctx)
+			Expect(ok).To(BeTrue())
+			Expect(deadline).To(Equal(ctx.RequestTime.Add(5 * time.Second)))
+			return true
+		})
+	})
+
+	It("should shed requests missing their SLO", func() {
+		q := newQueue(SLOQueueOptions{EDF: true, MissPolicy: SLOMissPolicyShed})
+		counter := sloDeadlineMissCounter.WithLabelValues(model, string(SLOMissPolicyShed))
+		misses := testutil.ToFloat64(counter)
+		enqueue(q, "recent", 0)
+		enqueue(q, "missed", 10*time.Second)
+
+		ctx, err := peekAndDequeue(q)
+		Expect(ctx.RequestID).To(Equal("missed"))
+		Expect(err).To(BeIdenticalTo(cache.ErrorSLOFailureRequest))
+		Expect(ctx.HasRouted()).To(BeFalse())
+		Expect(testutil.ToFloat64(counter)).To(Equal(misses + 1))
+
+		ctx, err = peekAndDequeue(q)
+		Expect(ctx.RequestID).To(Equal("recent"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctx.HasRouted()).To(BeTrue())
+	})
+
+	It("should drop expired requests behind the head", func() {
+		for _, opts := range []SLOQueueOptions{{}, {EDF: true}} {
+			q := newQueue(opts)
+			enqueue(q, "head", 2*time.Second)
+			expired := types.NewRoutingContext(context.Background(), "slo", model, "message", "expired", "")
+			expired.RequestTime = now
+			expired.RequestDeadline = now.Add(-time.Millisecond)
+			Expect(q.Enqueue(expired, now)).To(Succeed())
+			enqueue(q, "tail", 0)
+			Expect(q.Len()).To(Equal(3))
+
+			// The expired request is dropped before routing the head.
+			ctx, err := peekAndDequeue(q)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ctx).To(BeIdenticalTo(expired))
+			Expect(ctx.HasRouted()).To(BeFalse())
+			Expect(q.Len()).To(Equal(2))
+
+			ctx, err = peekAndDequeue(q)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ctx.RequestID).To(Equal("head"))
+			Expect(ctx.HasRouted()).To(BeTrue())
+		}
+	})
+
+	It("should demote requests missing their SLO", func() {
+		q := newQueue(SLOQueueOptions{EDF: true, MissPolicy: SLOMissPolicyDemote})
+		counter := sloDeadlineMissCounter.WithLabelValues(model, string(SLOMissPolicyDemote))
+		misses := testutil.ToFloat64(counter)
+		enqueue(q, "missed", 10*time.Second)
+		enqueue(q, "missed-too", 8*time.Second)
+		enqueue(q, "recent", 0)
+
+		for _, id := range []string{"recent", "missed", "missed-too"} {
+			ctx, err := peekAndDequeue(q)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ctx.RequestID).To(Equal(id))
+			Expect(ctx.HasRouted()).To(BeTrue())
+		}
+		Expect(testutil.ToFloat64(counter)).To(Equal(misses + 2))
+	})
+})
@@ -0,0 +1,267 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vllm-project/aibrix/pkg/types"
+)
+
+var _ types.QueueRouter = &FairQueue{}
+
+// DefaultUserWeight is the weight of users without a weight in their user record, and of requests without user.
+const DefaultUserWeight = 1
+
+type userQueue struct {
+	user    string
+	weight  int64
+	deficit int64 // Number of requests the user can dequeue in its turn, negative if served out of turn.
+	pending int   // Number of requests enqueued and not dequeued.
+	queue   types.RouterQueue[*types.RoutingContext]
+}
+
+// FairQueue is a RouterQueue that keeps a sub-queue per user and dequeues using deficit round robin, so that a user
+// flooding requests can not delay the requests of other users. In its turn, a user is credited its weight and dequeues
+// requests until the credit is used up. Requests without user share the sub-queue of the empty user.
+//
+// If the candidate of the user in turn can not be routed, candidates of the following users are tried without
+// consuming the turn, and the user served out of turn is charged, so that it is served less in its own turn.
+type FairQueue struct {
+	modelName string
+	newSub    func() (types.RouterQueue[*types.RoutingContext], error)
+
+	mu      sync.Mutex
+	users   map[string]*userQueue
+	active  []*userQueue // Users with pending requests in round robin order.
+	current int          // Index of the user in turn in active.
+
+	lastUser *userQueue // Accessed by Peek(), Dequeue(), Route() in serving goroutine only.
+}
+
+// NewFairQueue creates a FairQueue of the model, newSub creates the sub-queue of each user. Sub-queues are created on
+// the first request of a user and released once the user has no pending request.
+func NewFairQueue(modelName string, newSub func() (types.RouterQueue[*types.RoutingContext], error)) (*FairQueue, error) {
+	if newSub == nil {
+		return nil, fmt.Errorf("sub-queue constructor of fair queue is required")
+	}
+	return &FairQueue{
+		modelName: modelName,
+		newSub:    newSub,
+		users:     make(map[string]*userQueue),
+	}, nil
+}
+
+func (q *FairQueue) Enqueue(ctx *types.RoutingContext, currentTime time.Time) error {
+	if ctx == nil {
+		return ErrZeroValueNotSupported
+	}
+	user := userOf(ctx)
+
+	// Count the request before enqueuing so that the sub-queue is not released by a concurrent Dequeue().
+	q.mu.Lock()
+	uq, ok := q.users[user]
+	if !ok {
+		sub, err := q.newSub()
+		if err != nil {
+			q.mu.Unlock()
+			return err
+		}
+		uq = &userQueue{user: user, queue: sub}
+		q.users[user] = uq
+	}
+	// The weight of the latest request applies, so that updates of the user record take effect.
+	uq.weight = userWeight(ctx)
+	if uq.pending == 0 {
+		q.activate(uq)
+	}
+	uq.pending++
+	addFairQueueDepth(q.modelName, user, 1)
+	q.mu.Unlock()
+
+	if err := uq.queue.Enqueue(ctx, currentTime); err != nil {
+		q.mu.Lock()
+		q.release(uq)
+		q.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (q *FairQueue) Peek(currentTime time.Time, pods types.PodList) (*types.RoutingContext, error) {
+	q.mu.Lock()
+	order := q.peekOrder()
+	q.mu.Unlock()
+
+	blocked := false
+	for _, uq := range order {
+		ctx, err := uq.queue.Peek(currentTime, pods)
+		if err == types.ErrQueueEmpty {
+			// The request of the user is being enqueued.
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if ctx == nil {
+			// Candidates of the user can not be routed for now, try the following users.
+			blocked = true
+			continue
+		}
+		q.lastUser = uq
+		return ctx, nil
+	}
+	if blocked {
+		return nil, nil
+	}
+	return nil, types.ErrQueueEmpty
+}
+
+func (q *FairQueue) Dequeue(currentTime time.Time) (*types.RoutingContext, error) {
+	if q.lastUser == nil {
+		return nil, fmt.Errorf("call FairQueue.Peek first")
+	}
+	uq := q.lastUser
+	q.lastUser = nil
+
+	ctx, err := uq.queue.Dequeue(currentTime)
+	if err != nil {
+		return ctx, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	inTurn := len(q.active) > 0 && q.active[q.current] == uq
+	uq.deficit--
+	q.release(uq)
+	if inTurn && uq.pending > 0 && uq.deficit <= 0 {
+		q.advance()
+	}
+	return ctx, nil
+}
+
+func (q *FairQueue) Len() (total int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, uq := range q.active {
+		total += uq.pending
+	}
+	return
+}
+
+// UserLen returns the number of requests of the user in the queue.
+func (q *FairQueue) UserLen(user string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if uq, ok := q.users[user]; ok {
+		return uq.pending
+	}
+	return 0
+}
+
+// Route routes the request peeked last if the sub-queue of its user is a router, e.g. the SLOQueue.
+func (q *FairQueue) Route(ctx *types.RoutingContext, pods types.PodList) (string, error) {
+	if q.lastUser != nil {
+		if router, ok := q.lastUser.queue.(types.Router); ok {
+			return router.Route(ctx, pods)
+		}
+	}
+	return "", fmt.Errorf("sub-queue of user does not support routing")
+}
+
+// LastError returns the routing error concluded during last Peek() by the sub-queue, if supported.
+func (q *FairQueue) LastError() error {
+	if q.lastUser != nil {
+		if sub, ok := q.lastUser.queue.(interface{ LastError() error }); ok {
+			return sub.LastError()
+		}
+	}
+	return nil
+}
+
+// activate appends the user to the round robin, the user is credited once its turn comes.
+func (q *FairQueue) activate(uq *userQueue) {
+	uq.deficit = 0
+	q.active = append(q.active, uq)
+	if len(q.active) == 1 {
+		q.current = 0
+		uq.deficit += uq.weight
+	}
+}
+
+// release uncounts a request of the user, and removes the user from the round robin if it has no pending request.
+func (q *FairQueue) release(uq *userQueue) {
+	uq.pending--
+	addFairQueueDepth(q.modelName, uq.user, -1)
+	if uq.pending > 0 {
+		return
+	}
+
+	delete(q.users, uq.user)
+	for i, active := range q.active {
+		if active != uq {
+			continue
+		}
+		q.active = append(q.active[:i], q.active[i+1:]...)
+		if i < q.current {
+			q.current--
+		} else if i == q.current && len(q.active) > 0 {
+			// The turn passes to the following user.
+			q.current %= len(q.active)
+			q.active[q.current].deficit += q.active[q.current].weight
+		}
+		break
+	}
+	if len(q.active) == 0 {
+		q.current = 0
+	}
+}
+
+// advance passes the turn to the following user and credits its weight.
+func (q *FairQueue) advance() {
+	q.current = (q.current + 1) % len(q.active)
+	q.active[q.current].deficit += q.active[q.current].weight
+}
+
+// peekOrder returns active users starting from the user in turn, skipping users without credit.
+func (q *FairQueue) peekOrder() []*userQueue {
+	if len(q.active) == 0 {
+		return nil
+	}
+	// Terminates as every turn credits a positive weight.
+	for q.active[q.current].deficit <= 0 {
+		q.advance()
+	}
+	order := make([]*userQueue, 0, len(q.active))
+	order = append(order, q.active[q.current:]...)
+	return append(order, q.active[:q.current]...)
+}
+
+func userOf(ctx *types.RoutingContext) string {
+	if ctx.User == nil {
+		return ""
+	}
+	return *ctx.User
+}
+
+func userWeight(ctx *types.RoutingContext) int64 {
+	if ctx.UserWeight <= 0 {
+		return DefaultUserWeight
+	}
+	return ctx.UserWeight
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/vllm-project/aibrix/pkg/types"
+)
+
+// blockableQueue is a SimpleQueue whose candidates can be blocked from routing.
+type blockableQueue struct {
+	*SimpleQueue[*types.RoutingContext]
+	blocked bool
+}
+
+func (q *blockableQueue) Peek(currentTime time.Time, pods types.PodList) (*types.RoutingContext, error) {
+	ctx, err := q.SimpleQueue.Peek(currentTime, pods)
+	if err == nil && q.blocked {
+		return nil, nil
+	}
+	return ctx, err
+}
+
+var _ = Describe("FairQueue", func() {
+	var (
+		queue *FairQueue
+		now   time.Time
+	)
+
+	newRequest := func(requestID, user string, weight int64) *types.RoutingContext {
+		ctx := types.NewRoutingContext(context.Background(), "", "model", "", requestID, user)
+		ctx.UserWeight = weight
+		return ctx
+	}
+
+	enqueue := func(user string, weight int64, requestIDs ...string) {
+		for _, id := range requestIDs {
+			Expect(queue.Enqueue(newRequest(id, user, weight), now)).To(Succeed())
+		}
+	}
+
+	dequeueAll := func() (ids []string) {
+		for {
+			ctx, err := queue.Peek(now, nil)
+			if err == types.ErrQueueEmpty {
+				return
+			}
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ctx).ToNot(BeNil())
+			dequeued, err := queue.Dequeue(now)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dequeued).To(BeIdenticalTo(ctx))
+			ids = append(ids, dequeued.RequestID)
+		}
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+		var err error
+		queue, err = NewFairQueue("fair-model", func() (types.RouterQueue[*types.RoutingContext], error) {
+			return &blockableQueue{SimpleQueue: NewSimpleQueue[*types.RoutingContext](8)}, nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	// subOf returns the sub-queue of a user with pending requests.
+	subOf := func(user string) *blockableQueue {
+		return queue.users[user].queue.(*blockableQueue)
+	}
+
+	It("should not delay other users behind a flooding user", func() {
+		enqueue("alice", 0, "a1", "a2", "a3", "a4")
+		enqueue("bob", 0, "b1", "b2")
+		enqueue("", 0, "n1")
+
+		Expect(dequeueAll()).To(Equal([]string{"a1", "b1", "n1", "a2", "b2", "a3", "a4"}))
+		Expect(queue.users).To(BeEmpty(), "sub-queues are released once drained")
+	})
+
+	It("should dequeue users in proportion to their weights", func() {
+		enqueue("alice", 2, "a1", "a2", "a3", "a4", "a5")
+		enqueue("bob", 1, "b1", "b2", "b3")
+
+		Expect(dequeueAll()).To(Equal([]string{"a1", "a2", "b1", "a3", "a4", "b2", "a5", "b3"}))
+	})
+
+	It("should charge users served out of turn", func() {
+		enqueue("alice", 0, "a1", "a2")
+		enqueue("bob", 0, "b1", "b2", "b3")
+
+		subOf("alice").blocked = true
+		for _, id := range []string{"b1", "b2"} {
+			ctx, err := queue.Peek(now, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ctx.RequestID).To(Equal(id))
+			_, err = queue.Dequeue(now)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		subOf("bob").blocked = true
+		ctx, err := queue.Peek(now, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctx).To(BeNil(), "no candidate can be routed")
+
+		subOf("alice").blocked = false
+		subOf("bob").blocked = false
+		Expect(dequeueAll()).To(Equal([]string{"a1", "a2", "b3"}))
+	})
+
+	It("should report queue length by user", func() {
+		enqueue("alice", 0, "a1", "a2")
+		enqueue("bob", 0, "b1")
+
+		Expect(queue.Len()).To(Equal(3))
+		Expect(queue.UserLen("alice")).To(Equal(2))
+		Expect(queue.UserLen("bob")).To(Equal(1))
+		Expect(queue.UserLen("carol")).To(Equal(0))
+		Expect(testutil.ToFloat64(fairQueueDepthGauge.WithLabelValues("fair-model", "alice"))).To(Equal(2.0))
+
+		dequeueAll()
+		Expect(queue.Len()).To(Equal(0))
+		Expect(queue.UserLen("alice")).To(Equal(0))
+		// Series of users without pending request are deleted
+		Expect(fairQueueDepthGauge.DeleteLabelValues("fair-model", "alice")).To(BeFalse())
+		Expect(fairQueueDepthGauge.DeleteLabelValues("fair-model", "bob")).To(BeFalse())
+	})
+
+	It("should report the depth of all users under the empty user if disabled", func() {
+		fairQueueDepthByUser = false
+		defer func() { fairQueueDepthByUser = true }()
+
+		enqueue("alice", 0, "a1", "a2")
+		enqueue("bob", 0, "b1")
+		Expect(queue.UserLen("alice")).To(Equal(2))
+		Expect(testutil.ToFloat64(fairQueueDepthGauge.WithLabelValues("fair-model", ""))).To(Equal(3.0))
+
+		dequeueAll()
+		Expect(fairQueueDepthGauge.DeleteLabelValues("fair-model", "")).To(BeFalse())
+		Expect(fairQueueDepthGauge.DeleteLabelValues("fair-model", "alice")).To(BeFalse())
+	})
+
+	It("should reject invalid calls", func() {
+		Expect(queue.Enqueue(nil, now)).To(Equal(ErrZeroValueNotSupported))
+		_, err := queue.Peek(now, nil)
+		Expect(err).To(Equal(types.ErrQueueEmpty))
+		_, err = queue.Dequeue(now)
+		Expect(err).To(HaveOccurred())
+		_, err = queue.Route(newRequest("r", "alice", 0), nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
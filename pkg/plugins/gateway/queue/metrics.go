@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+// fairQueueDepthByUser labels the fair queue depth by user. The number of series grows with the number of users
+// with queued requests, disable it to report the depth of all users under the empty user.
+var fairQueueDepthByUser = utils.LoadEnvBool("AIBRIX_USER_FAIR_QUEUE_DEPTH_BY_USER", true)
+
+var (
+	queueDepthGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "aibrix_gateway_queue_depth",
+			Help: "Number of requests waiting in the routing queue by priority class",
+		},
+		[]string{"model", "priority_class"},
+	)
+	queueWaitHistogram = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "aibrix_gateway_queue_wait_seconds",
+			Help:    "Time requests waited in the routing queue by priority class",
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 14),
+		},
+		[]string{"model", "priority_class"},
+	)
+	sloDeadlineMissCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "aibrix_gateway_slo_deadline_misses_total",
+			Help: "Number of queued requests that can no longer meet their SLO deadline by miss policy",
+		},
+		[]string{"model", "policy"},
+	)
+	fairQueueDepthGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "aibrix_gateway_fair_queue_depth",
+			Help: "Number of requests waiting in the routing queue by user",
+		},
+		[]string{"model", "user"},
+	)
+)
+
+// fairQueueDepths counts the pending requests by model and user across the fair queues of a model, e.g. one per
+// priority class, so that the series of a user is deleted once the user has no pending request in the model.
+var fairQueueDepths = struct {
+	sync.Mutex
+	depths map[[2]string]int // [model, user] -> pending requests
+}{depths: make(map[[2]string]int)}
+
+// addFairQueueDepth adds delta to the pending requests of the user in the model.
+func addFairQueueDepth(model, user string, delta int) {
+	if !fairQueueDepthByUser {
+		user = ""
+	}
+	fairQueueDepths.Lock()
+	defer fairQueueDepths.Unlock()
+	key := [2]string{model, user}
+	depth := fairQueueDepths.depths[key] + delta
+	if depth > 0 {
+		fairQueueDepths.depths[key] = depth
+		fairQueueDepthGauge.WithLabelValues(model, user).Set(float64(depth))
+		return
+	}
+	delete(fairQueueDepths.depths, key)
+	fairQueueDepthGauge.DeleteLabelValues(model, user)
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"container/list"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vllm-project/aibrix/pkg/types"
+)
+
+// PriorityClass is a named request priority, requests of higher Priority are dequeued first.
+type PriorityClass struct {
+	Name     string
+	Priority int
+}
+
+// ParsePriorityClasses parses priority classes in the format of "name=priority,name=priority".
+func ParsePriorityClasses(spec string) ([]PriorityClass, error) {
+	var classes []PriorityClass
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, found := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid priority class %q, expected name=priority", item)
+		}
+		priority, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority of class %s: %v", name, err)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicated priority class %s", name)
+		}
+		seen[name] = true
+		classes = append(classes, PriorityClass{Name: name, Priority: priority})
+	}
+	return classes, nil
+}
+
+// CapPriorityClass returns the class of a request asking for the class by itself, capped at the default class, so
+// that requests can lower their priority but not raise it. The default class is the lowest class if empty.
+// Unknown classes are returned as is, they are queued in the default class.
+func CapPriorityClass(classes []PriorityClass, defaultClass, class string) string {
+	if len(classes) == 0 || class == "" {
+		return class
+	}
+	var requested, capped *PriorityClass
+	for i := range classes {
+		c := &classes[i]
+		if c.Name == class {
+			requested = c
+		}
+		if c.Name == defaultClass || defaultClass == "" && (capped == nil || c.Priority < capped.Priority) {
+			capped = c
+		}
+	}
+	if requested == nil || capped == nil || requested.Priority <= capped.Priority {
+		return class
+	}
+	return capped.Name
+}
+
+// PriorityQueueOptions configures a PriorityQueue.
+type PriorityQueueOptions struct {
+	// Classes are the priority classes available, at least one class is required.
+	Classes []PriorityClass
+	// DefaultClass is the class of requests without a priority or with an unknown priority.
+	// The lowest priority class is used if not set.
+	DefaultClass string
+	// AgingInterval raises the effective priority of a class by one for every interval its oldest request waited,
+	// so that lower classes are not starved. Zero disables aging.
+	AgingInterval time.Duration
+}
+
+type priorityClassQueue struct {
+	PriorityClass
+	queue   types.RouterQueue[*types.RoutingContext]
+	waiting *list.List // Enqueue time of waiting requests, ordered by arrival.
+	index   map[*types.RoutingContext]*list.Element
+}
+
+type waitingRequest struct {
+	ctx         *types.RoutingContext
+	enqueueTime time.Time
+}
+
+var _ types.QueueRouter = &PriorityQueue{}
+
+// PriorityQueue is a RouterQueue that keeps a sub-queue per priority class and always peeks the class of the
+// highest effective priority. The effective priority of a class is its priority plus the aging bonus of its oldest
+// request. A class whose candidate can not be routed blocks lower classes, so that higher classes are always served first.
+type PriorityQueue struct {
+	modelName     string
+	agingInterval time.Duration
+
+	mu           sync.Mutex
+	classes      []*priorityClassQueue // Ordered by priority descendingly.
+	byName       map[string]*priorityClassQueue
+	defaultClass *priorityClassQueue
+
+	lastClass *priorityClassQueue // Accessed by Peek(), Dequeue(), Route() in serving goroutine only.
+}
+
+// NewPriorityQueue creates a PriorityQueue of the model, newSub creates the sub-queue of each class.
+func NewPriorityQueue(modelName string, opts PriorityQueueOptions, newSub func() (types.RouterQueue[*types.RoutingContext], error)) (*PriorityQueue, error) {
+	if len(opts.Classes) == 0 {
+		return nil, fmt.Errorf("no priority class configured")
+	}
+
+	q := &PriorityQueue{
+		modelName:     modelName,
+		agingInterval: opts.AgingInterval,
+		classes:       make([]*priorityClassQueue, 0, len(opts.Classes)),
+		byName:        make(map[string]*priorityClassQueue, len(opts.Classes)),
+	}
+	for _, class := range opts.Classes {
+		sub, err := newSub()
+		if err != nil {
+			return nil, err
+		}
+		pcq := &priorityClassQueue{
+			PriorityClass: class,
+			queue:         sub,
+			waiting:       list.New(),
+			index:         make(map[*types.RoutingContext]*list.Element),
+		}
+		q.classes = append(q.classes, pcq)
+		q.byName[class.Name] = pcq
+		queueDepthGauge.WithLabelValues(modelName, class.Name).Set(0)
+	}
+	sort.SliceStable(q.classes, func(i, j int) bool {
+		return q.classes[i].Priority > q.classes[j].Priority
+	})
+
+	if opts.DefaultClass == "" {
+		q.defaultClass = q.classes[len(q.classes)-1]
+	} else if q.defaultClass = q.byName[opts.DefaultClass]; q.defaultClass == nil {
+		return nil, fmt.Errorf("default priority class %s is not configured", opts.DefaultClass)
+	}
+	return q, nil
+}
+
+func (q *PriorityQueue) Enqueue(ctx *types.RoutingContext, currentTime time.Time) error {
+	class := q.classOf(ctx)
+
+	// Track the request before enqueuing so that a concurrent Dequeue() always finds it.
+	q.mu.Lock()
+	class.index[ctx] = class.waiting.PushBack(&waitingRequest{ctx: ctx, enqueueTime: currentTime})
+	q.mu.Unlock()
+
+	if err := class.queue.Enqueue(ctx, currentTime); err != nil {
+		q.mu.Lock()
+		class.waiting.Remove(class.index[ctx])
+		delete(class.index, ctx)
+		q.mu.Unlock()
+		return err
+	}
+
+	q.mu.Lock()
+	depth := class.waiting.Len()
+	q.mu.Unlock()
+
+	queueDepthGauge.WithLabelValues(q.modelName, class.Name).Set(float64(depth))
+	return nil
+}
+
+func (q *PriorityQueue) Peek(currentTime time.Time, pods types.PodList) (*types.RoutingContext, error) {
+	for _, class := range q.peekOrder(currentTime) {
+		ctx, err := class.queue.Peek(currentTime, pods)
+		if err == types.ErrQueueEmpty {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if ctx == nil {
+			// Candidates of the class can not be routed for now, wait without serving lower classes.
+			return nil, nil
+		}
+		q.lastClass = class
+		return ctx, nil
+	}
+	return nil, types.ErrQueueEmpty
+}
+
+func (q *PriorityQueue) Dequeue(currentTime time.Time) (*types.RoutingContext, error) {
+	if q.lastClass == nil {
+		return nil, fmt.Errorf("call PriorityQueue.Peek first")
+	}
+	class := q.lastClass
+	q.lastClass = nil
+
+	ctx, err := class.queue.Dequeue(currentTime)
+	if err != nil {
+		return ctx, err
+	}
+
+	q.mu.Lock()
+	var waited time.Duration
+	if elem, ok := class.index[ctx]; ok {
+		waited = currentTime.Sub(elem.Value.(*waitingRequest).enqueueTime)
+		class.waiting.Remove(elem)
+		delete(class.index, ctx)
+	}
+	depth := class.waiting.Len()
+	q.mu.Unlock()
+
+	queueDepthGauge.WithLabelValues(q.modelName, class.Name).Set(float64(depth))
+	queueWaitHistogram.WithLabelValues(q.modelName, class.Name).Observe(waited.Seconds())
+	return ctx, nil
+}
+
+func (q *PriorityQueue) Len() (total int) {
+	for _, class := range q.classes {
+		total += class.queue.Len()
+	}
+	return
+}
+
+// UserLen returns the number of requests of the user in the queue, if sub-queues keep requests by user, e.g. the FairQueue.
+func (q *PriorityQueue) UserLen(user string) (total int) {
+	for _, class := range q.classes {
+		if sub, ok := class.queue.(types.QueueRouter); ok {
+			total += sub.UserLen(user)
+		}
+	}
+	return
+}
+
+// Route routes the request peeked last if the sub-queue of its class is a router, e.g. the SLOQueue.
+func (q *PriorityQueue) Route(ctx *types.RoutingContext, pods types.PodList) (string, error) {
+	if q.lastClass != nil {
+		if router, ok := q.lastClass.queue.(types.Router); ok {
+			return router.Route(ctx, pods)
+		}
+	}
+	return "", fmt.Errorf("sub-queue of priority class does not support routing")
+}
+
+// LastError returns the routing error concluded during last Peek() by the sub-queue, if supported.
+func (q *PriorityQueue) LastError() error {
+	if q.lastClass != nil {
+		if sub, ok := q.lastClass.queue.(interface{ LastError() error }); ok {
+			return sub.LastError()
+		}
+	}
+	return nil
+}
+
+// EffectivePriority returns the priority of the class plus the aging bonus of a request waited for the duration.
+func (q *PriorityQueue) EffectivePriority(class PriorityClass, waited time.Duration) int {
+	if q.agingInterval <= 0 || waited <= 0 {
+		return class.Priority
+	}
+	return class.Priority + int(waited/q.agingInterval)
+}
+
+func (q *PriorityQueue) classOf(ctx *types.RoutingContext) *priorityClassQueue {
+	if class, ok := q.byName[ctx.Priority]; ok {
+		return class
+	}
+	return q.defaultClass
+}
+
+// peekOrder returns non-empty classes ordered by effective priority, ties are broken by the age of the oldest request.
+func (q *PriorityQueue) peekOrder(currentTime time.Time) []*priorityClassQueue {
+	type rankedClass struct {
+		*priorityClassQueue
+		effective int
+		oldest    time.Time
+	}
+
+	q.mu.Lock()
+	ranked := make([]rankedClass, 0, len(q.classes))
+	for _, class := range q.classes {
+		front := class.waiting.Front()
+		if front == nil {
+			continue
+		}
+		oldest := front.Value.(*waitingRequest).enqueueTime
+		ranked = append(ranked, rankedClass{
+			priorityClassQueue: class,
+			effective:          q.EffectivePriority(class.PriorityClass, currentTime.Sub(oldest)),
+			oldest:             oldest,
+		})
+	}
+	q.mu.Unlock()
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].effective != ranked[j].effective {
+			return ranked[i].effective > ranked[j].effective
+		}
+		return ranked[i].oldest.Before(ranked[j].oldest)
+	})
+	order := make([]*priorityClassQueue, len(ranked))
+	for i := range ranked {
+		order[i] = ranked[i].priorityClassQueue
+	}
+	return order
+}
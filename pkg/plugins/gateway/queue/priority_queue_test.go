@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vllm-project/aibrix/pkg/types"
+)
+
+var _ = Describe("PriorityQueue", func() {
+	var (
+		queue *PriorityQueue
+		now   time.Time
+	)
+
+	newRequest := func(requestID, priority string) *types.RoutingContext {
+		ctx := types.NewRoutingContext(context.Background(), "", "model", "", requestID, "")
+		ctx.Priority = priority
+		return ctx
+	}
+
+	newQueue := func(aging time.Duration) *PriorityQueue {
+		q, err := NewPriorityQueue("model", PriorityQueueOptions{
+			Classes:       []PriorityClass{{Name: "batch", Priority: 0}, {Name: "interactive", Priority: 2}, {Name: "default", Priority: 1}},
+			DefaultClass:  "default",
+			AgingInterval: aging,
+		}, func() (types.RouterQueue[*types.RoutingContext], error) {
+			return NewSimpleQueue[*types.RoutingContext](8), nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		return q
+	}
+
+	peekAndDequeue := func(currentTime time.Time) string {
+		ctx, err := queue.Peek(currentTime, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctx).ToNot(BeNil())
+		dequeued, err := queue.Dequeue(currentTime)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dequeued).To(BeIdenticalTo(ctx))
+		return dequeued.RequestID
+	}
+
+	BeforeEach(func() {
+		now = time.Now()
+	})
+
+	It("should parse priority classes", func() {
+		classes, err := ParsePriorityClasses(" interactive=2, batch=0,,default = 1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(classes).To(Equal([]PriorityClass{{"interactive", 2}, {"batch", 0}, {"default", 1}}))
+
+		_, err = ParsePriorityClasses("interactive")
+		Expect(err).To(HaveOccurred())
+		_, err = ParsePriorityClasses("interactive=high")
+		Expect(err).To(HaveOccurred())
+		_, err = ParsePriorityClasses("batch=0,batch=1")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should cap requested classes at the default class", func() {
+		classes := []PriorityClass{{"interactive", 2}, {"default", 1}, {"batch", 0}}
+		Expect(CapPriorityClass(classes, "default", "interactive")).To(Equal("default"))
+		Expect(CapPriorityClass(classes, "default", "default")).To(Equal("default"))
+		Expect(CapPriorityClass(classes, "default", "batch")).To(Equal("batch"))
+		Expect(CapPriorityClass(classes, "default", "unknown")).To(Equal("unknown"))
+		Expect(CapPriorityClass(classes, "default", "")).To(Equal(""))
+		Expect(CapPriorityClass(classes, "", "interactive")).To(Equal("batch"), "the lowest class is the default")
+		Expect(CapPriorityClass(nil, "", "interactive")).To(Equal("interactive"))
+	})
+
+	It("should reject unknown default class", func() {
+		_, err := NewPriorityQueue("model", PriorityQueueOptions{
+			Classes:      []PriorityClass{{Name: "batch", Priority: 0}},
+			DefaultClass: "default",
+		}, func() (types.RouterQueue[*types.RoutingContext], error) {
+			return NewSimpleQueue[*types.RoutingContext](8), nil
+		})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should dequeue higher classes first", func() {
+		queue = newQueue(0)
+		Expect(queue.Enqueue(newRequest("batch-1", "batch"), now)).To(Succeed())
+		Expect(queue.Enqueue(newRequest("default-1", ""), now)).To(Succeed())
+		Expect(queue.Enqueue(newRequest("interactive-1", "interactive"), now)).To(Succeed())
+		Expect(queue.Enqueue(newRequest("unknown-1", "unknown"), now)).To(Succeed())
+		Expect(queue.Enqueue(newRequest("interactive-2", "interactive"), now)).To(Succeed())
+		Expect(queue.Len()).To(Equal(5))
+
+		later := now.Add(time.Hour)
+		Expect(peekAndDequeue(later)).To(Equal("interactive-1"))
+		Expect(peekAndDequeue(later)).To(Equal("interactive-2"))
+		Expect(peekAndDequeue(later)).To(Equal("default-1"))
+		Expect(peekAndDequeue(later)).To(Equal("unknown-1"))
+		Expect(peekAndDequeue(later)).To(Equal("batch-1"))
+
+		_, err := queue.Peek(later, nil)
+		Expect(err).To(BeIdenticalTo(types.ErrQueueEmpty))
+		Expect(queue.Len()).To(Equal(0))
+	})
+
+	It("should age waiting requests to prevent starvation", func() {
+		queue = newQueue(time.Second)
+		Expect(queue.Enqueue(newRequest("batch-1", "batch"), now)).To(Succeed())
+		Expect(queue.Enqueue(newRequest("interactive-1", "interactive"), now.Add(time.Second))).To(Succeed())
+
+		// batch-1 waited 1s and is still below interactive.
+		Expect(peekAndDequeue(now.Add(time.Second))).To(Equal("interactive-1"))
+
+		Expect(queue.Enqueue(newRequest("interactive-2", "interactive"), now.Add(3*time.Second))).To(Succeed())
+		// batch-1 waited 3s, reaching priority 3 over the fresh interactive-2.
+		Expect(queue.EffectivePriority(PriorityClass{Priority: 0}, 3*time.Second)).To(Equal(3))
+		Expect(peekAndDequeue(now.Add(3 * time.Second))).To(Equal("batch-1"))
+		Expect(peekAndDequeue(now.Add(3 * time.Second))).To(Equal("interactive-2"))
+	})
+
+	It("should require peek before dequeue", func() {
+		queue = newQueue(0)
+		Expect(queue.Enqueue(newRequest("batch-1", "batch"), now)).To(Succeed())
+		_, err := queue.Dequeue(now)
+		Expect(err).To(HaveOccurred())
+	})
+})
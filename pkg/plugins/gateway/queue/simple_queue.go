@@ -150,6 +150,42 @@ func (q *SimpleQueue[V]) Dequeue(_ time.Time) (c V, err error) {
 	}
 }
 
+// RemoveIf removes the queued values matching remove wherever they are in the queue, and returns them in queue order.
+// It returns false without removing anything if a value is being enqueued, the caller can try again later.
+func (q *SimpleQueue[V]) RemoveIf(remove func(V) bool) ([]V, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var nilVal V
+	dequeuePos := q.physicalPosRLocked(q.dequeueCursor)
+	enqueuePos := q.physicalPosRLocked(q.enqueueCursor)
+	if enqueuePos > int64(len(q.queue)) {
+		// Enqueue() is waiting to expand the queue.
+		return nil, false
+	}
+	for pos := dequeuePos; pos < enqueuePos; pos++ {
+		if q.queue[pos] == nilVal {
+			return nil, false
+		}
+	}
+
+	var removed []V
+	keep := dequeuePos
+	for pos := dequeuePos; pos < enqueuePos; pos++ {
+		if remove(q.queue[pos]) {
+			removed = append(removed, q.queue[pos])
+			continue
+		}
+		q.queue[keep] = q.queue[pos]
+		keep++
+	}
+	for pos := keep; pos < enqueuePos; pos++ {
+		q.queue[pos] = nilVal
+	}
+	atomic.StoreInt64(&q.enqueueCursor, q.dequeueCursor+keep-dequeuePos)
+	return removed, true
+}
+
 func (q *SimpleQueue[V]) Len() int {
 	q.mu.RLock()
 	defer q.mu.RUnlock()
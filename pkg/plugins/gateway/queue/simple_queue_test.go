@@ -75,6 +75,29 @@ var _ = Describe("SimpleQueue", func() {
 			Expect(err).To(BeIdenticalTo(types.ErrQueueEmpty))
 			Expect(val).To(BeZero())
 		})
+
+		It("should remove items anywhere in the queue", func() {
+			for i := 1; i <= 6; i++ {
+				// nolint:errcheck
+				queue.Enqueue(i, time.Now())
+			}
+			// nolint:errcheck
+			queue.Dequeue(time.Now())
+
+			removed, ok := queue.RemoveIf(func(v int) bool { return v%2 == 0 })
+			Expect(ok).To(BeTrue())
+			Expect(removed).To(Equal([]int{2, 4, 6}))
+			Expect(queue.Len()).To(Equal(2))
+
+			// nolint:errcheck
+			queue.Enqueue(7, time.Now())
+			for _, expected := range []int{3, 5, 7} {
+				val, err := queue.Dequeue(time.Now())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(val).To(Equal(expected))
+			}
+			Expect(queue.Len()).To(Equal(0))
+		})
 	})
 
 	Describe("Expansion", func() {
@@ -77,21 +77,65 @@ func (crr *candidateRouterRequest) nextProfile() *candidateProfiles {
 	return ret
 }
 
+// SLOMissPolicy is the action taken on queued requests that can no longer meet their SLO in EDF mode.
+type SLOMissPolicy string
+
+const (
+	// SLOMissPolicyNone keeps requests missing their SLO in deadline order.
+	SLOMissPolicyNone SLOMissPolicy = "none"
+	// SLOMissPolicyShed rejects requests missing their SLO with cache.ErrorSLOFailureRequest.
+	SLOMissPolicyShed SLOMissPolicy = "shed"
+	// SLOMissPolicyDemote dequeues requests missing their SLO after the requests still able to meet theirs.
+	SLOMissPolicyDemote SLOMissPolicy = "demote"
+)
+
+// SLOQueueOptions configures a SLOQueue.
+type SLOQueueOptions struct {
+	// EDF dequeues the requests of a sub-queue in the order of their SLO deadlines instead of arrival order.
+	// The SLO deadline of a request is its RequestTime plus the SLO target of the most relaxing profile.
+	EDF bool
+	// MissPolicy is the action taken on requests that can no longer meet their SLO in EDF mode, none by default.
+	MissPolicy SLOMissPolicy
+}
+
+// removableQueue is a sub-queue supporting the removal of requests wherever they are in the queue.
+type removableQueue interface {
+	RemoveIf(remove func(*types.RoutingContext) bool) ([]*types.RoutingContext, bool)
+}
+
 type SLOQueue struct {
 	routerProvider types.RouterProviderFunc
 	cache          cache.Cache
+	options        SLOQueueOptions
 
 	modelName string
 	subs      utils.SyncMap[string, types.RouterQueue[*types.RoutingContext]]
 	// features  utils.SyncMap[string, types.RequestFeatures]
 	subpool sync.Pool
 
-	dequeueCandidates   []*candidateRouterRequest
-	lastCandidateSubKey string // Clear in Dequeue()
-	lastCandidateError  error  // Clear in Dequeue()
+	expiryMu   sync.Mutex
+	nextExpiry time.Time               // Earliest deadline of the queued requests, zero if none.
+	expired    []*types.RoutingContext // Expired requests removed from sub-queues, to be dequeued first.
+
+	dequeueCandidates    []*candidateRouterRequest
+	lastCandidateSubKey  string // Clear in Dequeue()
+	lastCandidateError   error  // Clear in Dequeue()
+	lastCandidateExpired bool   // Clear in Dequeue()
 }
 
 func NewSLOQueue(provider types.RouterProviderFunc, modelName string) (router *SLOQueue, err error) {
+	return NewSLOQueueWithOptions(provider, modelName, SLOQueueOptions{})
+}
+
+func NewSLOQueueWithOptions(provider types.RouterProviderFunc, modelName string, opts SLOQueueOptions) (router *SLOQueue, err error) {
+	switch opts.MissPolicy {
+	case "":
+		opts.MissPolicy = SLOMissPolicyNone
+	case SLOMissPolicyNone, SLOMissPolicyShed, SLOMissPolicyDemote:
+	default:
+		return nil, fmt.Errorf("unknown SLO miss policy: %s", opts.MissPolicy)
+	}
+
 	// Dedup deployments
 	c, err := cache.Get()
 	if err != nil {
@@ -101,9 +145,14 @@ func NewSLOQueue(provider types.RouterProviderFunc, modelName string) (router *S
 	router = &SLOQueue{
 		routerProvider: provider,
 		cache:          c,
+		options:        opts,
 		modelName:      modelName,
 	}
-	router.subpool.New = func() any { return NewSimpleQueue[*types.RoutingContext](initialSubQueueSize) }
+	if opts.EDF {
+		router.subpool.New = func() any { return NewEDFQueue(router.sloDeadline) }
+	} else {
+		router.subpool.New = func() any { return NewSimpleQueue[*types.RoutingContext](initialSubQueueSize) }
+	}
 	router.expandDequeueCandidatesLocked(initialTotalSubQueues)
 	return router, nil
 }
@@ -130,11 +179,24 @@ func (q *SLOQueue) Enqueue(ctx *types.RoutingContext, currentTime time.Time) err
 
 	// SimpleQueue.Enqueue() returns nil always.
 	sub.Enqueue(ctx, currentTime) // nolint: errcheck
+	if !ctx.RequestDeadline.IsZero() {
+		q.expiryMu.Lock()
+		if q.nextExpiry.IsZero() || ctx.RequestDeadline.Before(q.nextExpiry) {
+			q.nextExpiry = ctx.RequestDeadline
+		}
+		q.expiryMu.Unlock()
+	}
 	q.debugSub(fmt.Sprintf("%s request enqueued, request=%s", ctx.Model, ctx.RequestID))
 	return nil
 }
 
 func (q *SLOQueue) Peek(currentTime time.Time, pods types.PodList) (*types.RoutingContext, error) {
+	// Expired requests are returned first to be dropped without routing.
+	if expired := q.peekExpired(currentTime); expired != nil {
+		q.lastCandidateExpired = true
+		return expired, nil
+	}
+
 	// Most implementation goes here.
 	var err error
 
@@ -177,6 +239,8 @@ func (q *SLOQueue) Peek(currentTime time.Time, pods types.PodList) (*types.Routi
 		q.dequeueCandidates[0].SubKey = key
 		return true
 	}
+	var shed *types.RoutingContext
+	var shedSubKey string
 	q.subs.Range(func(key string, sub types.RouterQueue[*types.RoutingContext]) bool {
 		r, peekErr := sub.Peek(currentTime, pods)
 		if peekErr == types.ErrQueueEmpty {
@@ -185,6 +249,14 @@ func (q *SLOQueue) Peek(currentTime time.Time, pods types.PodList) (*types.Routi
 			klog.Errorf("Failed to peek subqueue %s: %v.", key, peekErr)
 			return true
 		}
+		// In EDF mode, requests that can no longer meet their SLO are shed or demoted as configured.
+		if edf, ok := sub.(*EDFQueue); ok && availableProfiles > 0 {
+			var shedding bool
+			if r, shedding = q.handleSLOMiss(currentTime, edf, r, deploymentProfiles); shedding {
+				shed, shedSubKey = r, key
+				return false
+			}
+		}
 
 		// Keep fallback decision in case anything wrong.
 		// Fallback decision occupies first element(0) of q.dequeueCandidates.
@@ -238,6 +310,11 @@ func (q *SLOQueue) Peek(currentTime time.Time, pods types.PodList) (*types.Routi
 		})
 		return true
 	})
+	if shed != nil {
+		q.lastCandidateSubKey = shedSubKey
+		q.lastCandidateError = cache.ErrorSLOFailureRequest
+		return shed, nil
+	}
 	if err != nil {
 		// Apply fallback decision by just keep the first one.
 		q.dequeueCandidates = q.dequeueCandidates[:1]
@@ -300,6 +377,15 @@ func (q *SLOQueue) Peek(currentTime time.Time, pods types.PodList) (*types.Routi
 }
 
 func (q *SLOQueue) Dequeue(ts time.Time) (*types.RoutingContext, error) {
+	if q.lastCandidateExpired {
+		q.lastCandidateExpired = false
+		q.expiryMu.Lock()
+		defer q.expiryMu.Unlock()
+		expired := q.expired[0]
+		q.expired[0] = nil
+		q.expired = q.expired[1:]
+		return expired, nil
+	}
 	if len(q.lastCandidateSubKey) == 0 {
 		return nil, fmt.Errorf("call SLOQueue.Peek first")
 	}
@@ -312,6 +398,9 @@ func (q *SLOQueue) Dequeue(ts time.Time) (*types.RoutingContext, error) {
 }
 
 func (q *SLOQueue) Len() (total int) {
+	q.expiryMu.Lock()
+	total = len(q.expired)
+	q.expiryMu.Unlock()
 	q.subs.Range(func(_ string, sub types.RouterQueue[*types.RoutingContext]) bool {
 		total += sub.Len()
 		return true
@@ -330,10 +419,128 @@ func (q *SLOQueue) Route(ctx *types.RoutingContext, pods types.PodList) (string,
 	return ctx.TargetAddress(), nil
 }
 
+// peekExpired returns the first expired request, sweeping the sub-queues for expired requests once the earliest
+// deadline of the queued requests has passed, so that expired requests behind a blocked head are dropped as well.
+func (q *SLOQueue) peekExpired(currentTime time.Time) *types.RoutingContext {
+	q.expiryMu.Lock()
+	defer q.expiryMu.Unlock()
+
+	if len(q.expired) == 0 && !q.nextExpiry.IsZero() && !currentTime.Before(q.nextExpiry) {
+		var nextExpiry time.Time
+		swept := true
+		q.subs.Range(func(key string, sub types.RouterQueue[*types.RoutingContext]) bool {
+			removable, ok := sub.(removableQueue)
+			if !ok {
+				return true
+			}
+			expired, ok := removable.RemoveIf(func(ctx *types.RoutingContext) bool {
+				if ctx.Expired(currentTime) {
+					return true
+				}
+				if !ctx.RequestDeadline.IsZero() && (nextExpiry.IsZero() || ctx.RequestDeadline.Before(nextExpiry)) {
+					nextExpiry = ctx.RequestDeadline
+				}
+				return false
+			})
+			if !ok {
+				// A request is being enqueued, try again on next Peek.
+				swept = false
+				return true
+			}
+			if len(expired) > 0 {
+				q.expired = append(q.expired, expired...)
+				q.debugSub(fmt.Sprintf("%s requests expired in sub %s, count=%d,", q.modelName, key, len(expired)))
+			}
+			return true
+		})
+		if swept {
+			q.nextExpiry = nextExpiry
+		}
+	}
+	if len(q.expired) == 0 {
+		return nil
+	}
+	return q.expired[0]
+}
+
 func (q *SLOQueue) LastError() error {
 	return q.lastCandidateError
 }
 
+// sloDeadline returns RequestTime plus the SLO target of the request on the most relaxing profile of the model.
+func (q *SLOQueue) sloDeadline(req *types.RoutingContext) (time.Time, bool) {
+	pods, err := q.cache.ListPodsByModel(q.modelName)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	target, found := 0.0, false
+	for _, deploymentName := range pods.Indexes() {
+		profile, err := q.cache.GetModelProfileByDeploymentName(deploymentName, q.modelName)
+		if err != nil {
+			continue
+		}
+		_, _, profileTarget, err := q.rankImpl(req.RequestTime, req, profile)
+		if err != nil {
+			continue
+		}
+		if !found || profileTarget > target {
+			target, found = profileTarget, true
+		}
+	}
+	if !found {
+		return time.Time{}, false
+	}
+	return req.RequestTime.Add(time.Duration(target * float64(time.Second))), true
+}
+
+// missesSLO returns true if the request is expected to violate its SLO on all profiles available.
+func (q *SLOQueue) missesSLO(currentTime time.Time, req *types.RoutingContext, profiles []*cache.ModelGPUProfile) bool {
+	ranked := false
+	for _, profile := range profiles {
+		if profile == nil {
+			continue
+		}
+		rank, err := q.rank(currentTime, req, profile)
+		if err != nil {
+			continue
+		}
+		if rank <= 0 {
+			return false
+		}
+		ranked = true
+	}
+	return ranked
+}
+
+// handleSLOMiss applies the miss policy to the head request of the EDF sub-queue if it can no longer meet its SLO.
+// It returns the request to be considered for dequeue, and true if the request should be shed.
+func (q *SLOQueue) handleSLOMiss(currentTime time.Time, sub *EDFQueue, req *types.RoutingContext, profiles []*cache.ModelGPUProfile) (*types.RoutingContext, bool) {
+	for q.missesSLO(currentTime, req, profiles) {
+		if sub.markMissed(req) {
+			sloDeadlineMissCounter.WithLabelValues(q.modelName, string(q.options.MissPolicy)).Inc()
+			klog.V(4).InfoS("queued request can no longer meet its SLO", "model", q.modelName, "requestID", req.RequestID, "policy", q.options.MissPolicy)
+		}
+		switch q.options.MissPolicy {
+		case SLOMissPolicyShed:
+			return req, true
+		case SLOMissPolicyDemote:
+			if !sub.demote(req) {
+				// All requests in the sub-queue miss their SLO, serve them in deadline order.
+				return req, false
+			}
+			next, err := sub.Peek(currentTime, nil)
+			if err != nil {
+				return req, false
+			}
+			req = next
+		default:
+			return req, false
+		}
+	}
+	return req, false
+}
+
 func (q *SLOQueue) validateDequeueCandidatesLocked(size int) {
 	if size <= cap(q.dequeueCandidates) {
 		return
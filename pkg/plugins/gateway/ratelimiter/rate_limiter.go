@@ -18,6 +18,19 @@ package ratelimiter
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// FixedWindow counts usage in fixed windows, usage can reach twice the limit around window boundaries.
+	FixedWindow = "fixed-window"
+	// SlidingWindow estimates usage over a window sliding with the current time.
+	SlidingWindow = "sliding-window"
+	// TokenBucket refills the limit per window and allows bursts up to the limit times the burst factor.
+	TokenBucket = "token-bucket"
 )
 
 // RateLimiter defines an interface for rate limiting operations.
@@ -35,3 +48,27 @@ type RateLimiter interface {
 	// Returns the updated rate limit counter after the increment and an error if the operation fails.
 	Incr(ctx context.Context, key string, val int64) (int64, error)
 }
+
+// LimitAwareRateLimiter is a RateLimiter whose accounting depends on the limit of the key,
+// e.g. the refill rate of a token bucket. The caller passes the limit when checking the usage,
+// following calls of Get and Incr keep accounting at the last limit passed.
+type LimitAwareRateLimiter interface {
+	RateLimiter
+
+	// GetWithLimit retrieves the current rate limit usage for the given key, whose maximum rate limit is limit.
+	GetWithLimit(ctx context.Context, key string, limit int64) (int64, error)
+}
+
+// NewRateLimiter creates a RateLimiter of the given type, an empty type falls back to FixedWindow.
+func NewRateLimiter(limiterType string, name string, client *redis.Client, windowSize time.Duration, burstFactor float64) (RateLimiter, error) {
+	switch limiterType {
+	case "", FixedWindow:
+		return NewRedisAccountRateLimiter(name, client, windowSize), nil
+	case SlidingWindow:
+		return NewRedisSlidingWindowRateLimiter(name, client, windowSize), nil
+	case TokenBucket:
+		return NewRedisTokenBucketRateLimiter(name, client, windowSize, burstFactor), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limiter type: %s", limiterType)
+	}
+}
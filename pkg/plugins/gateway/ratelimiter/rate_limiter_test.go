@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRedisClient(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return mr, client
+}
+
+func Test_NewRateLimiter(t *testing.T) {
+	_, client := newTestRedisClient(t)
+
+	r, err := NewRateLimiter("", "aibrix", client, time.Minute, 1)
+	assert.NoError(t, err)
+	assert.IsType(t, &redisRateLimiter{}, r)
+
+	r, err = NewRateLimiter(SlidingWindow, "aibrix", client, time.Minute, 1)
+	assert.NoError(t, err)
+	assert.IsType(t, &redisSlidingWindowRateLimiter{}, r)
+
+	r, err = NewRateLimiter(TokenBucket, "aibrix", client, time.Minute, 1)
+	assert.NoError(t, err)
+	_, ok := r.(LimitAwareRateLimiter)
+	assert.True(t, ok)
+
+	_, err = NewRateLimiter("leaky", "aibrix", client, time.Minute, 1)
+	assert.Error(t, err)
+}
+
+func Test_SlidingWindowRateLimiter(t *testing.T) {
+	_, client := newTestRedisClient(t)
+	ctx := context.Background()
+	now := time.UnixMilli(60_000 * 1000)
+	rl := NewRedisSlidingWindowRateLimiter("aibrix", client, time.Minute).(*redisSlidingWindowRateLimiter)
+	rl.now = func() time.Time { return now }
+
+	val, err := rl.Get(ctx, "user_RPM_CURRENT")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), val)
+
+	// 10 requests at the end of a window.
+	now = now.Add(59 * time.Second)
+	val, err = rl.Incr(ctx, "user_RPM_CURRENT", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), val)
+
+	// Right after the window boundary, the previous window still counts almost fully,
+	// where a fixed window would have reset the usage to 0.
+	now = now.Add(2 * time.Second)
+	val, err = rl.Get(ctx, "user_RPM_CURRENT")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), val)
+
+	val, err = rl.Incr(ctx, "user_RPM_CURRENT", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(14), val)
+
+	// Half way through the window, the previous window counts half.
+	now = now.Add(29 * time.Second)
+	val, err = rl.Get(ctx, "user_RPM_CURRENT")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), val)
+
+	// Two windows later, all usage has expired.
+	now = now.Add(2 * time.Minute)
+	val, err = rl.Get(ctx, "user_RPM_CURRENT")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), val)
+}
+
+func Test_TokenBucketRateLimiter(t *testing.T) {
+	mr, client := newTestRedisClient(t)
+	ctx := context.Background()
+	now := time.UnixMilli(60_000 * 1000)
+	rl := NewRedisTokenBucketRateLimiter("aibrix", client, time.Minute, 2).(*redisTokenBucketRateLimiter)
+	rl.now = func() time.Time { return now }
+	val, err := rl.GetWithLimit(ctx, "user_RPM_CURRENT", 60)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), val)
+	_, err = rl.GetWithLimit(ctx, "user_RPM_CURRENT", -1)
+	assert.Error(t, err)
+
+	// The bucket holds 120 tokens, the usage reaches the limit of 60 once they are consumed.
+	val, err = rl.Incr(ctx, "user_RPM_CURRENT", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50), val)
+	val, err = rl.Incr(ctx, "user_RPM_CURRENT", 20)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(60), val)
+
+	// Tokens refill at 1 per second.
+	now = now.Add(10 * time.Second)
+	val, err = rl.Get(ctx, "user_RPM_CURRENT")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(55), val)
+
+	// Get does not consume tokens.
+	val, err = rl.Get(ctx, "user_RPM_CURRENT")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(55), val)
+
+	// The limit is stored with the bucket, other replicas refill the bucket at the same rate.
+	replica := NewRedisTokenBucketRateLimiter("aibrix", client, time.Minute, 2).(*redisTokenBucketRateLimiter)
+	replica.now = func() time.Time { return now }
+	val, err = replica.Get(ctx, "user_RPM_CURRENT")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(55), val)
+
+	// Passing the same limit again does not change the bucket.
+	val, err = replica.GetWithLimit(ctx, "user_RPM_CURRENT", 60)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(55), val)
+
+	// A limit change takes effect on all replicas.
+	val, err = replica.GetWithLimit(ctx, "user_RPM_CURRENT", 120)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(55), val)
+	now = now.Add(10 * time.Second)
+	val, err = rl.Get(ctx, "user_RPM_CURRENT")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(45), val)
+
+	// The bucket refills completely after limit * burst tokens.
+	now = now.Add(2 * time.Minute)
+	val, err = rl.Get(ctx, "user_RPM_CURRENT")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), val)
+
+	client.Set(ctx, "aibrix:user_RPM_CURRENT", 60, 0)
+	limit, err := rl.GetLimit(ctx, "user_RPM_CURRENT")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(60), limit)
+
+	// Buckets of idle keys expire with their limits.
+	_, err = rl.GetWithLimit(ctx, "idle_RPM_CURRENT", 60)
+	assert.NoError(t, err)
+	assert.True(t, mr.Exists("aibrix:idle_RPM_CURRENT:tb"))
+	mr.FastForward(2 * time.Minute)
+	assert.False(t, mr.Exists("aibrix:idle_RPM_CURRENT:tb"))
+}
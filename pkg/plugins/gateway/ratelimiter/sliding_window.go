@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type redisSlidingWindowRateLimiter struct {
+	client     *redis.Client
+	name       string
+	windowSize time.Duration
+	now        func() time.Time
+}
+
+// NewRedisSlidingWindowRateLimiter is a sliding window counter rate limiter.
+// The usage is estimated as the count of the current window plus the count of the previous window
+// weighted by its overlap with the sliding window, which smooths bursts at window boundaries.
+func NewRedisSlidingWindowRateLimiter(name string, client *redis.Client, windowSize time.Duration) RateLimiter {
+	if windowSize < time.Second {
+		windowSize = time.Second
+	}
+
+	return &redisSlidingWindowRateLimiter{
+		name:       name,
+		client:     client,
+		windowSize: windowSize,
+		now:        time.Now,
+	}
+}
+
+func (rl *redisSlidingWindowRateLimiter) Get(ctx context.Context, key string) (int64, error) {
+	currKey, prevKey, elapsed := rl.genKeys(key)
+	vals, err := rl.client.MGet(ctx, currKey, prevKey).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	curr, err := parseCount(vals[0])
+	if err != nil {
+		return 0, err
+	}
+	prev, err := parseCount(vals[1])
+	if err != nil {
+		return 0, err
+	}
+	return rl.estimate(prev, curr, elapsed), nil
+}
+
+func (rl *redisSlidingWindowRateLimiter) GetLimit(ctx context.Context, key string) (int64, error) {
+	val, err := rl.client.Get(ctx, fmt.Sprintf("%s:%s", rl.name, key)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return val, err
+}
+
+func (rl *redisSlidingWindowRateLimiter) Incr(ctx context.Context, key string, val int64) (int64, error) {
+	currKey, prevKey, elapsed := rl.genKeys(key)
+	pipe := rl.client.TxPipeline()
+
+	incr := pipe.IncrBy(ctx, currKey, val)
+	// The current window is read as the previous window during the next window.
+	pipe.Expire(ctx, currKey, 2*rl.windowSize)
+	prev := pipe.Get(ctx, prevKey)
+
+	_, err := pipe.Exec(ctx)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+
+	prevCount, err := prev.Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+	return rl.estimate(prevCount, incr.Val(), elapsed), nil
+}
+
+// genKeys returns the keys of the current and previous windows, and the time elapsed in the current window.
+func (rl *redisSlidingWindowRateLimiter) genKeys(key string) (string, string, time.Duration) {
+	now := rl.now().UnixMilli()
+	windowMs := rl.windowSize.Milliseconds()
+	window := now / windowMs
+	elapsed := time.Duration(now%windowMs) * time.Millisecond
+	return fmt.Sprintf("%s:%s:sw:%d", rl.name, key, window),
+		fmt.Sprintf("%s:%s:sw:%d", rl.name, key, window-1),
+		elapsed
+}
+
+func (rl *redisSlidingWindowRateLimiter) estimate(prev, curr int64, elapsed time.Duration) int64 {
+	weight := float64(rl.windowSize-elapsed) / float64(rl.windowSize)
+	return int64(float64(prev)*weight) + curr
+}
+
+func parseCount(val interface{}) (int64, error) {
+	if val == nil {
+		return 0, nil
+	}
+	str, ok := val.(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected counter value: %v", val)
+	}
+	var count int64
+	if _, err := fmt.Sscan(str, &count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript tracks the consumed tokens of a bucket, which refill at limit tokens per window
+// up to a capacity of limit * burst. The consumed tokens are scaled by the burst factor, so that the
+// returned usage reaches limit exactly when the bucket is empty. The limit is stored with the bucket,
+// so that all gateway replicas refill the bucket at the same rate. On a limit change, the tokens
+// refilled so far are settled at the previous limit. A bucket without limit does not refill.
+//
+// KEYS[1]: bucket key
+// ARGV[1]: now in milliseconds
+// ARGV[2]: window in milliseconds
+// ARGV[3]: burst factor
+// ARGV[4]: tokens to consume, 0 only reads the bucket
+// ARGV[5]: limit per window, negative keeps the stored limit
+var tokenBucketScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local burst = tonumber(ARGV[3])
+local incr = tonumber(ARGV[4])
+local limit = tonumber(ARGV[5])
+
+local consumed = 0
+local bucket = redis.call("HMGET", KEYS[1], "consumed", "ts", "limit")
+local previous = tonumber(bucket[3]) or 0
+if bucket[1] then
+	local elapsed = math.max(0, now - tonumber(bucket[2]))
+	consumed = math.max(0, tonumber(bucket[1]) - elapsed * previous / window)
+end
+if limit < 0 then
+	limit = previous
+end
+
+if incr > 0 or limit ~= previous then
+	consumed = consumed + incr
+	redis.call("HSET", KEYS[1], "consumed", tostring(consumed), "ts", tostring(now), "limit", tostring(limit))
+	local ttl = window
+	if limit > 0 then
+		ttl = math.ceil(consumed * window / limit) + window
+	end
+	redis.call("PEXPIRE", KEYS[1], ttl)
+end
+
+return math.floor(consumed / burst)
+`)
+
+type redisTokenBucketRateLimiter struct {
+	client      *redis.Client
+	name        string
+	windowSize  time.Duration
+	burstFactor float64
+	now         func() time.Time
+}
+
+// NewRedisTokenBucketRateLimiter is a token bucket rate limiter. Tokens refill at the configured limit
+// per window, and the bucket holds up to limit * burstFactor tokens, which allows short bursts above
+// the limit after a period of inactivity. The refill rate is set by the gateway through GetWithLimit
+// and shared by all gateway replicas.
+func NewRedisTokenBucketRateLimiter(name string, client *redis.Client, windowSize time.Duration, burstFactor float64) LimitAwareRateLimiter {
+	if windowSize < time.Second {
+		windowSize = time.Second
+	}
+	if burstFactor < 1 {
+		burstFactor = 1
+	}
+
+	return &redisTokenBucketRateLimiter{
+		name:        name,
+		client:      client,
+		windowSize:  windowSize,
+		burstFactor: burstFactor,
+		now:         time.Now,
+	}
+}
+
+func (rl *redisTokenBucketRateLimiter) Get(ctx context.Context, key string) (int64, error) {
+	return rl.run(ctx, key, 0, -1)
+}
+
+func (rl *redisTokenBucketRateLimiter) GetLimit(ctx context.Context, key string) (int64, error) {
+	val, err := rl.client.Get(ctx, fmt.Sprintf("%s:%s", rl.name, key)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return val, err
+}
+
+func (rl *redisTokenBucketRateLimiter) Incr(ctx context.Context, key string, val int64) (int64, error) {
+	return rl.run(ctx, key, val, -1)
+}
+
+func (rl *redisTokenBucketRateLimiter) GetWithLimit(ctx context.Context, key string, limit int64) (int64, error) {
+	if limit < 0 {
+		return 0, fmt.Errorf("invalid limit %d for key %s", limit, key)
+	}
+	return rl.run(ctx, key, 0, limit)
+}
+
+// run consumes val tokens of the bucket and returns its usage, a negative limit keeps the stored limit.
+func (rl *redisTokenBucketRateLimiter) run(ctx context.Context, key string, val int64, limit int64) (int64, error) {
+	return tokenBucketScript.Run(ctx, rl.client, []string{rl.bucketKey(key)},
+		rl.now().UnixMilli(), rl.windowSize.Milliseconds(), rl.burstFactor, val, limit).Int64()
+}
+
+func (rl *redisTokenBucketRateLimiter) bucketKey(key string) string {
+	return fmt.Sprintf("%s:%s:tb", rl.name, key)
+}
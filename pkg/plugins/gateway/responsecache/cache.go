@@ -0,0 +1,221 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package responsecache caches the responses of deterministic requests, so that identical requests are served
+// without inference.
+package responsecache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"k8s.io/klog/v2"
+
+	lrustore "github.com/vllm-project/aibrix/pkg/utils/lrustore"
+)
+
+const (
+	StoreMemory = "memory"
+	StoreRedis  = "redis"
+
+	DefaultTTL          = 10 * time.Minute
+	DefaultMaxEntries   = 1024
+	DefaultMaxBodyBytes = 1 << 20
+
+	keyPrefix       = "aibrix:response-cache:"
+	storeTimeout    = 100 * time.Millisecond
+	minEvictionTick = time.Second
+)
+
+type Options struct {
+	// TTL is the duration a response is served from the cache after it is stored.
+	TTL time.Duration
+	// MaxEntries caps the number of responses of the memory store, the least recently stored are evicted first.
+	MaxEntries int
+	// MaxBodyBytes caps the size of a cached response, larger responses are not cached.
+	MaxBodyBytes int
+}
+
+// store persists the cached responses, values expire after ttl.
+type store interface {
+	get(ctx context.Context, key string) ([]byte, bool, error)
+	set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Cache stores the responses of deterministic requests in memory or in Redis.
+type Cache struct {
+	opts  Options
+	store store
+}
+
+func NewCache(storeType string, redisClient *redis.Client, opts Options) (*Cache, error) {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultTTL
+	}
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = DefaultMaxEntries
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+
+	c := &Cache{opts: opts}
+	switch storeType {
+	case StoreMemory:
+		c.store = newMemoryStore(opts.MaxEntries, opts.TTL)
+	case StoreRedis:
+		if redisClient == nil {
+			return nil, fmt.Errorf("redis client is required by the %s response cache", storeType)
+		}
+		c.store = &redisStore{client: redisClient}
+	default:
+		return nil, fmt.Errorf("unknown response cache store %q, expected %s or %s", storeType, StoreMemory, StoreRedis)
+	}
+	return c, nil
+}
+
+// Get returns the cached response of the key. Store errors are logged and reported as misses.
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, bool) {
+	ctx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+	body, ok, err := c.store.get(ctx, key)
+	if err != nil {
+		klog.ErrorS(err, "failed to get cached response", "key", key)
+		return nil, false
+	}
+	if ok {
+		lookupsTotal.WithLabelValues("hit").Inc()
+	} else {
+		lookupsTotal.WithLabelValues("miss").Inc()
+	}
+	return body, ok
+}
+
+// Set caches the response of the key, unless it exceeds MaxBodyBytes.
+func (c *Cache) Set(ctx context.Context, key string, body []byte) {
+	if len(body) == 0 || len(body) > c.opts.MaxBodyBytes {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+	if err := c.store.set(ctx, key, body, c.opts.TTL); err != nil {
+		klog.ErrorS(err, "failed to cache response", "key", key)
+	}
+}
+
+// MaxBodyBytes returns the size of the largest response cached.
+func (c *Cache) MaxBodyBytes() int {
+	return c.opts.MaxBodyBytes
+}
+
+// Key returns the cache key of a completion request of the model sent by the user, or false if the response of
+// the request is not deterministic: only requests with temperature 0 generating a single choice are cached.
+// Requests differing only by the order or formatting of their fields share the key.
+func Key(model, user string, body []byte) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var request map[string]any
+	if err := decoder.Decode(&request); err != nil {
+		return "", false
+	}
+	if !isZero(request["temperature"]) {
+		return "", false
+	}
+	if n, ok := request["n"]; ok && fmt.Sprint(n) != "1" {
+		return "", false
+	}
+
+	request["temperature"] = 0
+
+	// Maps are marshaled with sorted keys
+	normalized, err := json.Marshal(request)
+	if err != nil {
+		return "", false
+	}
+	hash := sha256.New()
+	for _, part := range [][]byte{[]byte(model), []byte(user), normalized} {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	return keyPrefix + hex.EncodeToString(hash.Sum(nil)), true
+}
+
+func isZero(value any) bool {
+	number, ok := value.(json.Number)
+	if !ok {
+		return false
+	}
+	f, err := number.Float64()
+	return err == nil && f == 0
+}
+
+type memoryEntry struct {
+	body      []byte
+	expiresAt time.Time
+}
+
+// memoryStore keeps the responses in an in-process LRU store.
+type memoryStore struct {
+	entries *lrustore.LRUStore[string, memoryEntry]
+	now     func() time.Time
+}
+
+func newMemoryStore(maxEntries int, ttl time.Duration) *memoryStore {
+	return &memoryStore{
+		entries: lrustore.NewLRUStore[string, memoryEntry](maxEntries, ttl, max(ttl/2, minEvictionTick), lrustore.DefaultGetCurrentTime),
+		now:     time.Now,
+	}
+}
+
+func (s *memoryStore) get(_ context.Context, key string) ([]byte, bool, error) {
+	entry, ok := s.entries.Get(key)
+	// Entries are evicted periodically, expired entries may still be stored.
+	if !ok || !s.now().Before(entry.expiresAt) {
+		return nil, false, nil
+	}
+	return entry.body, true, nil
+}
+
+func (s *memoryStore) set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.entries.Put(key, memoryEntry{body: value, expiresAt: s.now().Add(ttl)})
+	return nil
+}
+
+// redisStore keeps the responses in Redis, shared by the gateway plugin replicas.
+type redisStore struct {
+	client *redis.Client
+}
+
+func (s *redisStore) get(ctx context.Context, key string) ([]byte, bool, error) {
+	body, err := s.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return body, true, nil
+}
+
+func (s *redisStore) set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, key, value, ttl).Err()
+}
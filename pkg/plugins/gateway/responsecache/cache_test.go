@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package responsecache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKey(t *testing.T) {
+	key, ok := Key("llama", "alice", []byte(`{"model":"llama","temperature":0,"messages":[{"role":"user","content":"hi"}]}`))
+	require.True(t, ok)
+
+	// Field order and formatting do not matter
+	same, ok := Key("llama", "alice", []byte(`{ "messages": [{"content": "hi", "role": "user"}], "temperature": 0.0, "model": "llama" }`))
+	require.True(t, ok)
+	assert.Equal(t, key, same)
+
+	// Keys are scoped by model and user
+	other, _ := Key("llama", "bob", []byte(`{"model":"llama","temperature":0,"messages":[{"role":"user","content":"hi"}]}`))
+	assert.NotEqual(t, key, other)
+	other, _ = Key("mistral", "alice", []byte(`{"model":"llama","temperature":0,"messages":[{"role":"user","content":"hi"}]}`))
+	assert.NotEqual(t, key, other)
+
+	for name, body := range map[string]string{
+		"default temperature":  `{"model":"llama","messages":[]}`,
+		"positive temperature": `{"model":"llama","temperature":0.7,"messages":[]}`,
+		"several choices":      `{"model":"llama","temperature":0,"n":2,"messages":[]}`,
+		"invalid body":         `not json`,
+	} {
+		_, ok := Key("llama", "alice", []byte(body))
+		assert.False(t, ok, name)
+	}
+	_, ok = Key("llama", "alice", []byte(`{"model":"llama","temperature":0,"n":1,"messages":[]}`))
+	assert.True(t, ok)
+}
+
+func TestMemoryCache(t *testing.T) {
+	c, err := NewCache(StoreMemory, nil, Options{TTL: time.Minute, MaxEntries: 2, MaxBodyBytes: 8})
+	require.NoError(t, err)
+	now := time.Now()
+	c.store.(*memoryStore).now = func() time.Time { return now }
+	ctx := context.Background()
+
+	c.Set(ctx, "a", []byte("response"))
+	c.Set(ctx, "large", []byte("too large"))
+	body, ok := c.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("response"), body)
+	_, ok = c.Get(ctx, "large")
+	assert.False(t, ok)
+
+	// The least recently stored responses are evicted
+	c.Set(ctx, "b", []byte("b"))
+	c.Set(ctx, "c", []byte("c"))
+	_, ok = c.Get(ctx, "a")
+	assert.False(t, ok)
+
+	// Responses expire after the TTL
+	now = now.Add(2 * time.Minute)
+	_, ok = c.Get(ctx, "c")
+	assert.False(t, ok)
+}
+
+func TestRedisCache(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	_, err := NewCache(StoreRedis, nil, Options{})
+	assert.Error(t, err)
+	_, err = NewCache("unknown", client, Options{})
+	assert.Error(t, err)
+
+	c, err := NewCache(StoreRedis, client, Options{TTL: time.Minute})
+	require.NoError(t, err)
+	ctx := context.Background()
+	c.Set(ctx, "a", []byte("response"))
+	body, ok := c.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("response"), body)
+
+	mr.FastForward(2 * time.Minute)
+	_, ok = c.Get(ctx, "a")
+	assert.False(t, ok)
+}
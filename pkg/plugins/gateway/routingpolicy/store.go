@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package routingpolicy keeps the RoutingPolicy resources of the cluster, which set the routing defaults of models.
+package routingpolicy
+
+import (
+	"sync"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	modelv1alpha1 "github.com/vllm-project/aibrix/api/model/v1alpha1"
+	"github.com/vllm-project/aibrix/pkg/client/clientset/versioned"
+	crdinformers "github.com/vllm-project/aibrix/pkg/client/informers/externalversions"
+)
+
+// Store keeps the RoutingPolicy of each model up to date with the RoutingPolicy resources.
+// If several policies bind the same model, the oldest one applies.
+type Store struct {
+	mu       sync.RWMutex
+	policies map[string]*modelv1alpha1.RoutingPolicy // namespace/name -> policy
+	models   map[string]*modelv1alpha1.RoutingPolicy // model -> applied policy
+}
+
+func NewStore() *Store {
+	return &Store{
+		policies: map[string]*modelv1alpha1.RoutingPolicy{},
+		models:   map[string]*modelv1alpha1.RoutingPolicy{},
+	}
+}
+
+// Start watches the RoutingPolicy resources of all namespaces until stopCh is closed.
+// Start does not wait for the initial list, requests use the default routing until then.
+func (s *Store) Start(client versioned.Interface, stopCh <-chan struct{}) error {
+	factory := crdinformers.NewSharedInformerFactoryWithOptions(client, 0)
+	informer := factory.Model().V1alpha1().RoutingPolicies().Informer()
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    s.addPolicy,
+		UpdateFunc: s.updatePolicy,
+		DeleteFunc: s.deletePolicy,
+	}); err != nil {
+		return err
+	}
+	factory.Start(stopCh)
+	return nil
+}
+
+// Get returns the RoutingPolicy of the model. The policy is shared and must be treated as read-only.
+func (s *Store) Get(model string) (*modelv1alpha1.RoutingPolicy, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	policy, ok := s.models[model]
+	return policy, ok
+}
+
+func (s *Store) addPolicy(obj interface{}) {
+	policy, ok := obj.(*modelv1alpha1.RoutingPolicy)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.policies[policyKey(policy)] = policy
+	s.resolveLocked(policy.Spec.ModelName)
+	klog.InfoS("routing policy added", "policy", klog.KObj(policy), "model", policy.Spec.ModelName,
+		"algorithm", policy.Spec.Algorithm, "parameters", policy.Spec.Parameters, "allowOverride", policy.IsOverrideAllowed())
+}
+
+func (s *Store) updatePolicy(oldObj interface{}, newObj interface{}) {
+	oldPolicy, ok := oldObj.(*modelv1alpha1.RoutingPolicy)
+	if !ok {
+		return
+	}
+	newPolicy, ok := newObj.(*modelv1alpha1.RoutingPolicy)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.policies[policyKey(newPolicy)] = newPolicy
+	s.resolveLocked(newPolicy.Spec.ModelName)
+	if oldPolicy.Spec.ModelName != newPolicy.Spec.ModelName {
+		s.resolveLocked(oldPolicy.Spec.ModelName)
+	}
+	klog.InfoS("routing policy updated", "policy", klog.KObj(newPolicy), "model", newPolicy.Spec.ModelName,
+		"algorithm", newPolicy.Spec.Algorithm, "parameters", newPolicy.Spec.Parameters, "allowOverride", newPolicy.IsOverrideAllowed())
+}
+
+func (s *Store) deletePolicy(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	policy, ok := obj.(*modelv1alpha1.RoutingPolicy)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.policies, policyKey(policy))
+	s.resolveLocked(policy.Spec.ModelName)
+	klog.InfoS("routing policy deleted", "policy", klog.KObj(policy), "model", policy.Spec.ModelName)
+}
+
+// resolveLocked selects the policy applied to the model among the policies binding it.
+func (s *Store) resolveLocked(model string) {
+	var applied *modelv1alpha1.RoutingPolicy
+	conflicts := 0
+	for _, policy := range s.policies {
+		if policy.Spec.ModelName != model {
+			continue
+		}
+		conflicts++
+		if applied == nil || olderThan(policy, applied) {
+			applied = policy
+		}
+	}
+
+	if applied == nil {
+		delete(s.models, model)
+		return
+	}
+	if conflicts > 1 {
+		klog.InfoS("multiple routing policies bind the model, applying the oldest one", "model", model, "policy", klog.KObj(applied))
+	}
+	s.models[model] = applied
+}
+
+// olderThan orders policies by creation time, then by namespace and name.
+func olderThan(a, b *modelv1alpha1.RoutingPolicy) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return policyKey(a) < policyKey(b)
+}
+
+func policyKey(policy *modelv1alpha1.RoutingPolicy) string {
+	return policy.Namespace + "/" + policy.Name
+}
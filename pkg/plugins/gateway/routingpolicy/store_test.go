@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routingpolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	modelv1alpha1 "github.com/vllm-project/aibrix/api/model/v1alpha1"
+	"github.com/vllm-project/aibrix/pkg/client/clientset/versioned/fake"
+)
+
+func newPolicy(namespace, name, model, algorithm string, created time.Time) *modelv1alpha1.RoutingPolicy {
+	return &modelv1alpha1.RoutingPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, CreationTimestamp: metav1.NewTime(created)},
+		Spec:       modelv1alpha1.RoutingPolicySpec{ModelName: model, Algorithm: algorithm},
+	}
+}
+
+func TestStoreResolvesConflicts(t *testing.T) {
+	s := NewStore()
+	now := time.Now()
+	older := newPolicy("team-b", "older", "m1", "least-request", now.Add(-time.Hour))
+	newer := newPolicy("team-a", "newer", "m1", "prefix-cache", now)
+
+	s.addPolicy(newer)
+	policy, ok := s.Get("m1")
+	require.True(t, ok)
+	assert.Equal(t, "prefix-cache", policy.Spec.Algorithm)
+
+	// The oldest policy applies
+	s.addPolicy(older)
+	policy, _ = s.Get("m1")
+	assert.Equal(t, "least-request", policy.Spec.Algorithm)
+
+	// Moving the oldest policy to another model hands m1 back to the newer one
+	moved := older.DeepCopy()
+	moved.Spec.ModelName = "m2"
+	s.updatePolicy(older, moved)
+	policy, _ = s.Get("m1")
+	assert.Equal(t, "prefix-cache", policy.Spec.Algorithm)
+	policy, _ = s.Get("m2")
+	assert.Equal(t, "least-request", policy.Spec.Algorithm)
+
+	s.deletePolicy(cache.DeletedFinalStateUnknown{Key: "team-a/newer", Obj: newer})
+	_, ok = s.Get("m1")
+	assert.False(t, ok)
+}
+
+func TestStoreWatchesPolicies(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(newPolicy("default", "p1", "m1", "least-request", time.Now()))
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	s := NewStore()
+	require.NoError(t, s.Start(client, stopCh))
+	algorithmOf := func(model string) string {
+		if policy, ok := s.Get(model); ok {
+			return policy.Spec.Algorithm
+		}
+		return ""
+	}
+	assert.Eventually(t, func() bool { return algorithmOf("m1") == "least-request" }, 5*time.Second, 10*time.Millisecond)
+
+	policy, err := client.ModelV1alpha1().RoutingPolicies("default").Get(ctx, "p1", metav1.GetOptions{})
+	require.NoError(t, err)
+	policy.Spec.Algorithm = "prefix-cache"
+	_, err = client.ModelV1alpha1().RoutingPolicies("default").Update(ctx, policy, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return algorithmOf("m1") == "prefix-cache" }, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, client.ModelV1alpha1().RoutingPolicies("default").Delete(ctx, "p1", metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool { return algorithmOf("m1") == "" }, 5*time.Second, 10*time.Millisecond)
+}
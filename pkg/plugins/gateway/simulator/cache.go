@@ -0,0 +1,279 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	modelv1alpha1 "github.com/vllm-project/aibrix/api/model/v1alpha1"
+	"github.com/vllm-project/aibrix/pkg/cache"
+	"github.com/vllm-project/aibrix/pkg/metrics"
+	"github.com/vllm-project/aibrix/pkg/types"
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+// Output predictor settings of the cache store.
+const (
+	predictorMaxInputTokens  = 1024 * 1024
+	predictorMaxOutputTokens = 1024 * 1024
+	predictorWindow          = 240 * time.Second
+)
+
+// ModelRouterProviderFunc creates the queue router of a model, e.g. routingalgorithms.NewManualSLORouter.
+type ModelRouterProviderFunc func(modelName string) (types.QueueRouter, error)
+
+// podMetrics is the snapshot of the metrics scraped from a pod.
+type podMetrics struct {
+	running  float64
+	waiting  float64
+	kvCache  float64
+	busyTime float64
+}
+
+// simCache implements cache.Cache on the simulated pods. Realtime metrics, which the gateway tracks by itself,
+// reflect the simulated pods immediately, while the metrics scraped from pods are refreshed every metric interval.
+//
+// The simulator holds the lock when the pods are changing, routers hold the read lock when reading metrics.
+type simCache struct {
+	mu sync.RWMutex
+
+	model    string
+	pods     []*simPod
+	podIndex map[string]*simPod
+	podList  types.PodList
+	scraped  map[string]podMetrics
+
+	profile        *cache.ModelGPUProfile
+	predictor      *cache.SimpleOutputPredictor
+	routerProvider ModelRouterProviderFunc
+	queueRouter    types.QueueRouter
+}
+
+var _ cache.Cache = &simCache{}
+
+func newSimCache(model string, numPods int, config PodConfig, profile *cache.ModelGPUProfile, routerProvider ModelRouterProviderFunc) *simCache {
+	if profile != nil {
+		// The slo queue looks pods up by the deployment of the profile, so serve the profile as the simulated deployment's.
+		simProfile := *profile
+		simProfile.Deployment = simDeployment
+		profile = &simProfile
+	}
+	c := &simCache{
+		model:          model,
+		podIndex:       make(map[string]*simPod, numPods),
+		scraped:        make(map[string]podMetrics, numPods),
+		profile:        profile,
+		routerProvider: routerProvider,
+	}
+	c.reset(numPods, config)
+	return c
+}
+
+// reset replaces the simulated pods with idle pods and forgets the state learned from the previous run.
+func (c *simCache) reset(numPods int, config PodConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pods = make([]*simPod, numPods)
+	pods := make([]*v1.Pod, numPods)
+	clear(c.podIndex)
+	for i := range c.pods {
+		c.pods[i] = newSimPod(i, c.model, config)
+		pods[i] = c.pods[i].pod
+		c.podIndex[pods[i].Name] = c.pods[i]
+	}
+	c.podList = &utils.PodArray{Pods: pods}
+	c.predictor = cache.NewSimpleOutputPredictor(predictorMaxInputTokens, predictorMaxOutputTokens, predictorWindow)
+	// Requests left in the queue of the previous run can never be routed, the queue router is recreated on first use.
+	c.queueRouter = nil
+	c.scrapeLocked()
+}
+
+// scrape refreshes the snapshot of the metrics scraped from pods.
+func (c *simCache) scrape() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.scrapeLocked()
+}
+
+func (c *simCache) scrapeLocked() {
+	clear(c.scraped)
+	for _, pod := range c.pods {
+		c.scraped[pod.pod.Name] = podMetrics{
+			running:  float64(pod.running),
+			waiting:  float64(pod.waiting.Len()),
+			kvCache:  pod.kvCacheUsage(),
+			busyTime: float64(pod.running) / float64(pod.config.MaxRunning),
+		}
+	}
+}
+
+func (c *simCache) GetPod(podName, podNamespace string) (*v1.Pod, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if pod, ok := c.podIndex[podName]; ok && pod.pod.Namespace == podNamespace {
+		return pod.pod, nil
+	}
+	return nil, fmt.Errorf("pod does not exist in the cache: %s", podName)
+}
+
+func (c *simCache) ListPodsByModel(modelName string) (types.PodList, error) {
+	if !c.HasModel(modelName) {
+		return nil, fmt.Errorf("model does not exist in the cache: %s", modelName)
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.podList, nil
+}
+
+func (c *simCache) HasModel(modelName string) bool {
+	return modelName == c.model
+}
+
+func (c *simCache) ListModels() []string {
+	return []string{c.model}
+}
+
+func (c *simCache) ListModelsByPod(podName, podNamespace string) ([]string, error) {
+	if _, err := c.GetPod(podName, podNamespace); err != nil {
+		return nil, err
+	}
+	return []string{c.model}, nil
+}
+
+func (c *simCache) GetModelAlias(alias string) ([]modelv1alpha1.ModelAliasBackend, bool) {
+	return nil, false
+}
+
+func (c *simCache) ListModelAliases() []string {
+	return nil
+}
+
+func (c *simCache) GetMetricValueByPod(podName, podNamespace, metricName string) (metrics.MetricValue, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	pod, ok := c.podIndex[podName]
+	if !ok {
+		return nil, &cache.MetricNotFoundError{CacheError: cache.ErrorTypeMetricNotFound, PodName: podName, MetricName: metricName}
+	}
+	scraped := c.scraped[podName]
+
+	var value float64
+	switch metricName {
+	case metrics.RealtimeNumRequestsRunning:
+		value = float64(pod.inflight())
+	case metrics.RealtimeNormalizedPendings:
+		value = min(float64(pod.inflight())/float64(pod.config.MaxRunning), 1.0)
+	case metrics.NumRequestsRunning:
+		value = scraped.running
+	case metrics.NumRequestsWaiting:
+		value = scraped.waiting
+	case metrics.GPUCacheUsagePerc:
+		value = scraped.kvCache
+	case metrics.CPUCacheUsagePerc:
+		value = 0
+	case metrics.GPUBusyTimeRatio:
+		value = scraped.busyTime
+	default:
+		return nil, &cache.MetricNotFoundError{CacheError: cache.ErrorTypeMetricNotFound, PodName: podName, MetricName: metricName}
+	}
+	return &metrics.SimpleMetricValue{Value: value}, nil
+}
+
+func (c *simCache) GetMetricValueByPodModel(podName, podNamespace, modelName string, metricName string) (metrics.MetricValue, error) {
+	if !c.HasModel(modelName) {
+		return nil, &cache.MetricNotFoundError{CacheError: cache.ErrorTypeMetricNotFound, PodName: podName, MetricName: metricName}
+	}
+	return c.GetMetricValueByPod(podName, podNamespace, metricName)
+}
+
+func (c *simCache) AddSubscriber(subscriber metrics.MetricSubscriber) {}
+
+// The simulator tracks requests by itself.
+func (c *simCache) AddRequestCount(ctx *types.RoutingContext, requestID string, modelName string) int64 {
+	return 0
+}
+
+func (c *simCache) DoneRequestCount(ctx *types.RoutingContext, requestID string, modelName string, traceTerm int64) {
+}
+
+func (c *simCache) DoneRequestTrace(ctx *types.RoutingContext, requestID string, modelName string, inputTokens, outputTokens, traceTerm int64) {
+}
+
+func (c *simCache) GetModelProfileByPod(pod *v1.Pod, modelName string) (*cache.ModelGPUProfile, error) {
+	return c.GetModelProfileByDeploymentName(utils.DeploymentNameFromPod(pod), modelName)
+}
+
+func (c *simCache) GetModelProfileByDeploymentName(deploymentName string, modelName string) (*cache.ModelGPUProfile, error) {
+	if c.profile == nil || !c.HasModel(modelName) {
+		return nil, cache.MissingProfileError{
+			CacheError: cache.ErrorMissingProfile,
+			ProfileKey: cache.ModelGPUProfileKey(modelName, deploymentName),
+		}
+	}
+	return c.profile, nil
+}
+
+func (c *simCache) GetOutputPredictor(modelName string) (types.OutputPredictor, error) {
+	if !c.HasModel(modelName) {
+		return nil, fmt.Errorf("model does not exist in the cache: %s", modelName)
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.predictor, nil
+}
+
+func (c *simCache) GetRouter(ctx *types.RoutingContext) (types.Router, error) {
+	if !c.HasModel(ctx.Model) {
+		return nil, fmt.Errorf("model does not exist in the cache: %s", ctx.Model)
+	}
+	router, err := c.getQueueRouter()
+	if err != nil {
+		return nil, err
+	}
+	return router, nil
+}
+
+// getQueueRouter returns the queue router of the model, creating it on first use.
+func (c *simCache) getQueueRouter() (types.QueueRouter, error) {
+	c.mu.RLock()
+	router := c.queueRouter
+	c.mu.RUnlock()
+	if router != nil {
+		return router, nil
+	}
+	if c.routerProvider == nil {
+		return nil, fmt.Errorf("queue router not available for model: %s", c.model)
+	}
+
+	// The provider may access the cache, so the router is created without holding the lock.
+	router, err := c.routerProvider(c.model)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.queueRouter == nil {
+		c.queueRouter = router
+	}
+	return c.queueRouter, nil
+}
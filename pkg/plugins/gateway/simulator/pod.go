@@ -0,0 +1,209 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"container/list"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vllm-project/aibrix/pkg/utils"
+)
+
+// PodConfig is the performance model of a simulated pod.
+type PodConfig struct {
+	// MaxRunning is the max number of requests running in a batch, more requests wait in the pod queue.
+	MaxRunning int
+	// KVCacheTokens is the capacity of the KV cache in tokens. Requests are admitted only if their prompt and output
+	// tokens fit in the free KV cache, and the prefix cache keeps the most recently used blocks of this capacity.
+	KVCacheTokens int
+	// BlockSize is the number of tokens of a KV cache block, prefixes are cached in full blocks only.
+	BlockSize int
+	// PrefillTokensPerSecond is the prefill throughput of a request, cached prefix tokens are not prefilled.
+	PrefillTokensPerSecond float64
+	// TPOT is the time per output token of a request running alone.
+	TPOT time.Duration
+	// BatchSlowdown is the increase of TPOT with a full batch, e.g. 1.0 doubles the TPOT of a full batch.
+	BatchSlowdown float64
+}
+
+// DefaultPodConfig returns a pod model roughly matching a 7B model on a single A100.
+func DefaultPodConfig() PodConfig {
+	return PodConfig{
+		MaxRunning:             32,
+		KVCacheTokens:          200000,
+		BlockSize:              16,
+		PrefillTokensPerSecond: 8000,
+		TPOT:                   20 * time.Millisecond,
+		BatchSlowdown:          1.0,
+	}
+}
+
+// inflightRequest is a request dispatched to a pod.
+type inflightRequest struct {
+	*Request
+	pod        *simPod
+	blocks     []uint64
+	dispatched time.Duration
+	firstToken time.Duration
+	finished   time.Duration
+	cachedRate float64 // Ratio of prompt tokens served by the prefix cache
+	kvTokens   int
+}
+
+// simPod simulates the request queue, the batch and the prefix cache of an inference engine.
+type simPod struct {
+	config PodConfig
+	pod    *v1.Pod
+
+	waiting    *list.List // FIFO of *inflightRequest
+	running    int
+	kvUsed     int
+	dispatched int
+
+	blocks     *list.List               // Cached prefix blocks, the most recently used first
+	blockIndex map[uint64]*list.Element // Block hash -> element of blocks
+}
+
+// simDeployment is the deployment all simulated pods belong to.
+const simDeployment = "simulated"
+
+func newSimPod(index int, model string, config PodConfig) *simPod {
+	name := fmt.Sprintf("simulated-pod-%d", index)
+	return &simPod{
+		config: config,
+		pod: &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					"model.aibrix.ai/name":     model,
+					utils.DeploymentIdentifier: simDeployment,
+				},
+			},
+			Status: v1.PodStatus{
+				PodIP:      fmt.Sprintf("10.0.%d.%d", index/256, index%256),
+				Phase:      v1.PodRunning,
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+			},
+		},
+		waiting:    list.New(),
+		blocks:     list.New(),
+		blockIndex: make(map[uint64]*list.Element),
+	}
+}
+
+// inflight returns the number of requests dispatched to the pod and not finished.
+func (p *simPod) inflight() int {
+	return p.running + p.waiting.Len()
+}
+
+// kvCacheUsage returns the ratio of the KV cache used by running requests.
+func (p *simPod) kvCacheUsage() float64 {
+	return min(float64(p.kvUsed)/float64(p.config.KVCacheTokens), 1.0)
+}
+
+// dispatch queues the request on the pod.
+func (p *simPod) dispatch(req *Request, now time.Duration) *inflightRequest {
+	inflight := &inflightRequest{
+		Request:    req,
+		pod:        p,
+		blocks:     prefixBlockHashes(req.Prompt, p.config.BlockSize),
+		dispatched: now,
+		kvTokens:   req.PromptTokens + req.OutputTokens,
+	}
+	p.waiting.PushBack(inflight)
+	p.dispatched++
+	return inflight
+}
+
+// admit starts the waiting requests that fit in the batch and the KV cache, and returns them.
+func (p *simPod) admit(now time.Duration) []*inflightRequest {
+	var admitted []*inflightRequest
+	for p.waiting.Len() > 0 && p.running < p.config.MaxRunning {
+		front := p.waiting.Front()
+		req := front.Value.(*inflightRequest)
+		if p.running > 0 && p.kvUsed+req.kvTokens > p.config.KVCacheTokens {
+			break
+		}
+		p.waiting.Remove(front)
+
+		cachedBlocks := p.matchPrefix(req.blocks)
+		if len(req.blocks) > 0 {
+			req.cachedRate = float64(cachedBlocks) / float64(len(req.blocks))
+		}
+		p.cacheBlocks(req.blocks)
+
+		uncachedTokens := float64(req.PromptTokens) * (1 - req.cachedRate)
+		prefill := time.Duration(uncachedTokens / p.config.PrefillTokensPerSecond * float64(time.Second))
+		tpot := time.Duration(float64(p.config.TPOT) * (1 + p.config.BatchSlowdown*float64(p.running)/float64(p.config.MaxRunning)))
+		req.firstToken = now + prefill + tpot
+		req.finished = req.firstToken + time.Duration(req.OutputTokens-1)*tpot
+
+		p.running++
+		p.kvUsed += req.kvTokens
+		admitted = append(admitted, req)
+	}
+	return admitted
+}
+
+// finish releases the batch slot and the KV cache of the request.
+func (p *simPod) finish(req *inflightRequest) {
+	p.running--
+	p.kvUsed -= req.kvTokens
+}
+
+// matchPrefix returns the number of leading blocks in the prefix cache.
+func (p *simPod) matchPrefix(blocks []uint64) int {
+	for i, block := range blocks {
+		if _, ok := p.blockIndex[block]; !ok {
+			return i
+		}
+	}
+	return len(blocks)
+}
+
+// cacheBlocks adds the blocks to the prefix cache, evicting the least recently used blocks over capacity.
+func (p *simPod) cacheBlocks(blocks []uint64) {
+	for _, block := range blocks {
+		if elem, ok := p.blockIndex[block]; ok {
+			p.blocks.MoveToFront(elem)
+		} else {
+			p.blockIndex[block] = p.blocks.PushFront(block)
+		}
+	}
+	for capacity := p.config.KVCacheTokens / p.config.BlockSize; p.blocks.Len() > capacity; {
+		delete(p.blockIndex, p.blocks.Remove(p.blocks.Back()).(uint64))
+	}
+}
+
+// prefixBlockHashes returns the chained hashes of the full blocks of the prompt, so that a block hash identifies
+// the whole prefix up to the block.
+func prefixBlockHashes(prompt string, blockSize int) []uint64 {
+	blockChars := blockSize * charactersPerToken
+	hashes := make([]uint64, 0, len(prompt)/blockChars)
+	h := fnv.New64a()
+	for end := blockChars; end <= len(prompt); end += blockChars {
+		_, _ = h.Write([]byte(prompt[end-blockChars : end]))
+		hashes = append(hashes, h.Sum64())
+	}
+	return hashes
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// Report summarizes the replay of a trace with a routing algorithm.
+type Report struct {
+	Algorithm string `json:"algorithm"`
+	Requests  int    `json:"requests"`
+	Completed int    `json:"completed"`
+	Failed    int    `json:"failed"`
+
+	TTFTP50 time.Duration `json:"ttft_p50"`
+	TTFTP90 time.Duration `json:"ttft_p90"`
+	TTFTP99 time.Duration `json:"ttft_p99"`
+	E2EP50  time.Duration `json:"e2e_p50"`
+	E2EP90  time.Duration `json:"e2e_p90"`
+	E2EP99  time.Duration `json:"e2e_p99"`
+
+	// PrefixHitRate is the ratio of the prompt tokens of completed requests served by the prefix cache.
+	PrefixHitRate float64 `json:"prefix_hit_rate"`
+	// LoadImbalance is the max number of requests dispatched to a pod over the mean, 1.0 if perfectly balanced.
+	LoadImbalance float64 `json:"load_imbalance"`
+	// InflightSpread is the mean difference of in-flight requests between the busiest and the idlest pods,
+	// observed on request arrivals.
+	InflightSpread float64 `json:"inflight_spread"`
+
+	ttfts          []time.Duration
+	e2es           []time.Duration
+	promptTokens   int
+	cachedTokens   float64
+	spreadSum      float64
+	spreadObserved int
+}
+
+func newReport(algorithm string, requests int) *Report {
+	return &Report{
+		Algorithm: algorithm,
+		Requests:  requests,
+		ttfts:     make([]time.Duration, 0, requests),
+		e2es:      make([]time.Duration, 0, requests),
+	}
+}
+
+func (r *Report) observeCompletion(req *inflightRequest) {
+	r.Completed++
+	r.ttfts = append(r.ttfts, req.firstToken-req.Arrival)
+	r.e2es = append(r.e2es, req.finished-req.Arrival)
+	r.promptTokens += req.PromptTokens
+	r.cachedTokens += req.cachedRate * float64(req.PromptTokens)
+}
+
+func (r *Report) observeInflight(pods []*simPod) {
+	minInflight, maxInflight := math.MaxInt, 0
+	for _, pod := range pods {
+		minInflight = min(minInflight, pod.inflight())
+		maxInflight = max(maxInflight, pod.inflight())
+	}
+	r.spreadSum += float64(maxInflight - minInflight)
+	r.spreadObserved++
+}
+
+func (r *Report) finish(pods []*simPod) {
+	r.TTFTP50, r.TTFTP90, r.TTFTP99 = percentiles(r.ttfts)
+	r.E2EP50, r.E2EP90, r.E2EP99 = percentiles(r.e2es)
+	if r.promptTokens > 0 {
+		r.PrefixHitRate = r.cachedTokens / float64(r.promptTokens)
+	}
+	if r.spreadObserved > 0 {
+		r.InflightSpread = r.spreadSum / float64(r.spreadObserved)
+	}
+
+	total, busiest := 0, 0
+	for _, pod := range pods {
+		total += pod.dispatched
+		busiest = max(busiest, pod.dispatched)
+	}
+	if total > 0 {
+		r.LoadImbalance = float64(busiest) / (float64(total) / float64(len(pods)))
+	}
+}
+
+// percentiles returns the 50th, 90th and 99th percentiles of the durations using the nearest-rank method.
+func percentiles(durations []time.Duration) (p50, p90, p99 time.Duration) {
+	if len(durations) == 0 {
+		return 0, 0, 0
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := func(p float64) time.Duration {
+		return sorted[max(int(math.Ceil(p*float64(len(sorted))))-1, 0)]
+	}
+	return rank(0.5), rank(0.9), rank(0.99)
+}
+
+// WriteReports writes the reports as a table.
+func WriteReports(w io.Writer, reports []*Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "ALGORITHM\tCOMPLETED\tFAILED\tTTFT P50\tTTFT P90\tTTFT P99\tE2E P50\tE2E P90\tE2E P99\tPREFIX HIT\tIMBALANCE\tSPREAD\t")
+	for _, r := range reports {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%.1f%%\t%.2f\t%.2f\t\n",
+			r.Algorithm, r.Completed, r.Failed,
+			formatLatency(r.TTFTP50), formatLatency(r.TTFTP90), formatLatency(r.TTFTP99),
+			formatLatency(r.E2EP50), formatLatency(r.E2EP90), formatLatency(r.E2EP99),
+			r.PrefixHitRate*100, r.LoadImbalance, r.InflightSpread)
+	}
+	return tw.Flush()
+}
+
+func formatLatency(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulator replays request traces against a simulated pod fleet, so that routing algorithms can be
+// compared offline. The simulated pods are exposed to the real routers through a cache.Cache implementation.
+package simulator
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/vllm-project/aibrix/pkg/cache"
+	routing "github.com/vllm-project/aibrix/pkg/plugins/gateway/algorithms"
+	"github.com/vllm-project/aibrix/pkg/types"
+)
+
+const (
+	defaultMetricInterval = 50 * time.Millisecond
+	sessionHeader         = "x-session-id"
+	defaultModel          = "simulated-model"
+)
+
+// Config configures the simulated fleet.
+type Config struct {
+	// Model is the model served by the simulated pods, all requests of the trace are sent to the model.
+	// Defaults to the model of the first request, or simulated-model if the trace has no model.
+	Model string
+	// Pods is the number of simulated pods.
+	Pods int
+	// Pod is the performance model of the simulated pods.
+	Pod PodConfig
+	// MetricInterval is the interval of the metrics scraped from pods in simulated time, e.g. the KV cache usage.
+	MetricInterval time.Duration
+	// Profile is the GPU profile of the model used by the slo routers, nil to route without profile.
+	Profile *cache.ModelGPUProfile
+}
+
+// Simulator replays a trace against the simulated fleet for routing algorithms.
+type Simulator struct {
+	config Config
+	trace  []*Request
+	cache  *simCache
+
+	epoch time.Time     // Wall clock time the replay started, the simulated time starts from.
+	now   time.Duration // Simulated time of the replay.
+}
+
+// New creates a simulator of the trace. The simulated fleet replaces the cache of the routers, so only one simulator
+// can be used at a time.
+func New(config Config, trace []*Request) (*Simulator, error) {
+	if config.Pods <= 0 {
+		return nil, fmt.Errorf("invalid number of pods: %d", config.Pods)
+	}
+	if config.Pod.MaxRunning <= 0 || config.Pod.KVCacheTokens <= 0 || config.Pod.BlockSize <= 0 ||
+		config.Pod.PrefillTokensPerSecond <= 0 || config.Pod.TPOT <= 0 {
+		return nil, fmt.Errorf("invalid pod config: %+v", config.Pod)
+	}
+	if config.MetricInterval <= 0 {
+		config.MetricInterval = defaultMetricInterval
+	}
+
+	// Requests are served by the simulated model regardless the model in the trace.
+	for _, req := range trace {
+		if config.Model == "" {
+			config.Model = req.Model
+		}
+		req.Model = config.Model
+	}
+	if config.Model == "" {
+		config.Model = defaultModel
+		for _, req := range trace {
+			req.Model = config.Model
+		}
+	}
+
+	s := &Simulator{
+		config: config,
+		trace:  trace,
+	}
+	// Queue routers, e.g. slo, route the queued requests in simulated time when the replay asks them to.
+	s.cache = newSimCache(config.Model, config.Pods, config.Pod, config.Profile, func(modelName string) (types.QueueRouter, error) {
+		return routing.NewManualSLORouter(modelName, s.clock)
+	})
+	cache.InitWithCache(s.cache)
+	routing.Init()
+	return s, nil
+}
+
+// Close restores the cache of the routers.
+func (s *Simulator) Close() {
+	cache.InitWithCache(nil)
+}
+
+// Run replays the trace with the routing algorithm on a fleet of idle pods.
+func (s *Simulator) Run(algorithm string) (*Report, error) {
+	alg, ok := routing.Validate(algorithm)
+	if !ok {
+		return nil, fmt.Errorf("unsupported routing algorithm: %s", algorithm)
+	}
+	s.cache.reset(s.config.Pods, s.config.Pod)
+	s.epoch, s.now = time.Now(), 0
+
+	r := &run{
+		Simulator: s,
+		algorithm: alg,
+		report:    newReport(algorithm, len(s.trace)),
+	}
+	return r.replay(), nil
+}
+
+// clock returns the simulated time as time of the routers.
+func (s *Simulator) clock() time.Time {
+	return s.epoch.Add(s.now)
+}
+
+// event is an event of the simulation, either the arrival or the completion of a request.
+type event struct {
+	at        time.Duration
+	seq       int
+	arrival   *Request
+	completed *inflightRequest
+}
+
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].at != q[j].at {
+		return q[i].at < q[j].at
+	}
+	return q[i].seq < q[j].seq
+}
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *eventQueue) Push(x any)   { *q = append(*q, x.(*event)) }
+func (q *eventQueue) Pop() any {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
+
+// queuedRequest is a request waiting in the queue of a queue router.
+type queuedRequest struct {
+	*Request
+	ctx *types.RoutingContext
+}
+
+// run is the state of a replay.
+type run struct {
+	*Simulator
+	algorithm types.RoutingAlgorithm
+
+	seq    int
+	events eventQueue
+
+	queueRouter *routing.ManualQueueRouter
+	queued      []*queuedRequest // In arrival order.
+
+	report *Report
+}
+
+func (r *run) replay() *Report {
+	for _, req := range r.trace {
+		r.schedule(&event{at: req.Arrival, arrival: req})
+	}
+	nextScrape := r.config.MetricInterval
+
+	for r.events.Len() > 0 {
+		e := heap.Pop(&r.events).(*event)
+		for ; nextScrape <= e.at; nextScrape += r.config.MetricInterval {
+			r.now = nextScrape
+			r.cache.scrape()
+		}
+		r.now = e.at
+
+		if e.arrival != nil {
+			r.arrive(e.arrival)
+		} else {
+			r.complete(e.completed)
+		}
+		r.routeQueued()
+	}
+
+	// Requests left in the queue can never be routed.
+	for _, queued := range r.queued {
+		queued.ctx.Delete()
+		r.report.Failed++
+	}
+	r.report.finish(r.cache.pods)
+	return r.report
+}
+
+func (r *run) schedule(e *event) {
+	e.seq = r.seq
+	r.seq++
+	heap.Push(&r.events, e)
+}
+
+// arrive routes the request and dispatches it to the target pod.
+func (r *run) arrive(req *Request) {
+	r.cache.mu.RLock()
+	r.report.observeInflight(r.cache.pods)
+	podList := r.cache.podList
+	r.cache.mu.RUnlock()
+
+	ctx := r.algorithm.NewContext(context.Background(), req.Model, req.Prompt, req.ID, req.User)
+	ctx.RequestTime = r.clock()
+	if req.SessionID != "" {
+		ctx.ReqHeaders = map[string]string{sessionHeader: req.SessionID}
+	}
+	router, err := routing.Select(ctx)
+	if err != nil {
+		klog.ErrorS(err, "failed to select router", "algorithm", r.algorithm, "requestID", req.ID)
+		r.report.Failed++
+		ctx.Delete()
+		return
+	}
+
+	if queueRouter, ok := router.(*routing.ManualQueueRouter); ok {
+		// Queue routers only enqueue the request, queued requests are routed by routeQueued.
+		r.queueRouter = queueRouter
+		if _, err = queueRouter.Route(ctx, podList); err != nil {
+			r.dispatch(req, ctx, err)
+			ctx.Delete()
+			return
+		}
+		r.queued = append(r.queued, &queuedRequest{Request: req, ctx: ctx})
+		return
+	}
+
+	_, err = router.Route(ctx, podList)
+	r.dispatch(req, ctx, err)
+	ctx.Delete()
+}
+
+// complete finishes the request and starts the waiting requests of the pod.
+func (r *run) complete(req *inflightRequest) {
+	r.cache.mu.Lock()
+	req.pod.finish(req)
+	admitted := req.pod.admit(r.now)
+	r.cache.mu.Unlock()
+
+	r.admit(admitted)
+	r.report.observeCompletion(req)
+	r.cache.predictor.AddTraceWithTimestamp(req.PromptTokens, req.OutputTokens, 1, r.clock())
+}
+
+// routeQueued asks the queue router to route the queued requests at the current simulated time, and dispatches
+// the requests routed or failed.
+func (r *run) routeQueued() {
+	if len(r.queued) == 0 {
+		return
+	}
+	r.cache.mu.RLock()
+	podList := r.cache.podList
+	r.cache.mu.RUnlock()
+	r.queueRouter.RouteQueued(podList)
+
+	queued := r.queued[:0]
+	for _, req := range r.queued {
+		if !req.ctx.HasRouted() && !req.ctx.HasError() {
+			queued = append(queued, req)
+			continue
+		}
+		r.dispatch(req.Request, req.ctx, req.ctx.GetError())
+		req.ctx.Delete()
+	}
+	clear(r.queued[len(queued):])
+	r.queued = queued
+}
+
+// dispatch queues the routed request on the target pod.
+func (r *run) dispatch(req *Request, ctx *types.RoutingContext, err error) {
+	if err == nil && !ctx.HasRouted() {
+		err = errors.New("no target pod")
+	}
+	if err != nil {
+		klog.V(4).InfoS("failed to route request", "algorithm", r.algorithm, "requestID", req.ID, "error", err)
+		r.report.Failed++
+		return
+	}
+
+	r.cache.mu.Lock()
+	pod, ok := r.cache.podIndex[ctx.TargetPod().Name]
+	var admitted []*inflightRequest
+	if ok {
+		pod.dispatch(req, r.now)
+		admitted = pod.admit(r.now)
+	}
+	r.cache.mu.Unlock()
+
+	if !ok {
+		klog.V(4).InfoS("request routed to unknown pod", "algorithm", r.algorithm, "requestID", req.ID, "pod", ctx.TargetPod().Name)
+		r.report.Failed++
+		return
+	}
+	r.admit(admitted)
+}
+
+// admit schedules the completion of the requests started by pods.
+func (r *run) admit(admitted []*inflightRequest) {
+	for _, req := range admitted {
+		r.schedule(&event{at: req.finished, completed: req})
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vllm-project/aibrix/pkg/cache"
+	routing "github.com/vllm-project/aibrix/pkg/plugins/gateway/algorithms"
+	"github.com/vllm-project/aibrix/pkg/types"
+)
+
+func TestLoadTrace(t *testing.T) {
+	trace := `{"timestamp": 200, "requests": [{"prompt": "hello world!", "output_length": 5, "session_id": "s1"}]}
+
+{"timestamp": 100, "requests": [{"Prompt Length": 8, "Output Length": 0, "model": "other"}, {"prompt_length": 4}]}
+{"timestamp": 300, "prompt": "single request", "output_length": 3, "user": "u1"}
+`
+	requests, err := LoadTrace(strings.NewReader(trace), "model")
+	require.NoError(t, err)
+	require.Len(t, requests, 4)
+
+	assert.Equal(t, 100*time.Millisecond, requests[0].Arrival)
+	assert.Equal(t, "other", requests[0].Model)
+	assert.Equal(t, 8, requests[0].PromptTokens)
+	assert.Equal(t, 1, requests[0].OutputTokens)
+	assert.Len(t, requests[0].Prompt, 8*charactersPerToken)
+	assert.NotEqual(t, requests[0].Prompt[:16], requests[1].Prompt[:16], "synthetic prompts share no prefix")
+
+	assert.Equal(t, "model", requests[2].Model)
+	assert.Equal(t, "hello world!", requests[2].Prompt)
+	assert.Equal(t, 3, requests[2].PromptTokens)
+	assert.Equal(t, "s1", requests[2].SessionID)
+
+	assert.Equal(t, 300*time.Millisecond, requests[3].Arrival)
+	assert.Equal(t, "u1", requests[3].User)
+
+	_, err = LoadTrace(strings.NewReader(`{"timestamp": 1, "requests": [{"output_length": 1}]}`), "model")
+	assert.Error(t, err)
+	_, err = LoadTrace(strings.NewReader(`not json`), "model")
+	assert.Error(t, err)
+}
+
+func TestSimPod(t *testing.T) {
+	config := PodConfig{
+		MaxRunning:             2,
+		KVCacheTokens:          64,
+		BlockSize:              4,
+		PrefillTokensPerSecond: 1000,
+		TPOT:                   10 * time.Millisecond,
+		BatchSlowdown:          1.0,
+	}
+	pod := newSimPod(0, "model", config)
+	prompt := strings.Repeat("a", 16*charactersPerToken)
+	newReq := func() *Request { return &Request{Prompt: prompt, PromptTokens: 16, OutputTokens: 4} }
+
+	first := pod.dispatch(newReq(), 0)
+	admitted := pod.admit(0)
+	require.Equal(t, []*inflightRequest{first}, admitted)
+	assert.Equal(t, 0.0, first.cachedRate)
+	// Prefill 16 tokens at 1000 tokens/s, then 4 tokens of 10ms
+	assert.Equal(t, 26*time.Millisecond, first.firstToken)
+	assert.Equal(t, 56*time.Millisecond, first.finished)
+
+	// The second request hits the prefix cache, and decodes slower in a batch of two.
+	second := pod.dispatch(newReq(), 0)
+	pod.admit(0)
+	assert.Equal(t, 1.0, second.cachedRate)
+	assert.Equal(t, 15*time.Millisecond, second.firstToken)
+
+	// The third request waits for a free slot of the batch.
+	third := pod.dispatch(newReq(), 0)
+	assert.Empty(t, pod.admit(0))
+	assert.Equal(t, 3, pod.inflight())
+	assert.Equal(t, 40.0/64, pod.kvCacheUsage())
+
+	pod.finish(first)
+	assert.Equal(t, []*inflightRequest{third}, pod.admit(first.finished))
+	assert.Equal(t, 2, pod.running)
+	assert.Equal(t, 0, pod.waiting.Len())
+
+	// Prefix blocks are evicted over the KV cache capacity.
+	pod.cacheBlocks(prefixBlockHashes(strings.Repeat("b", 64*charactersPerToken), config.BlockSize))
+	assert.Equal(t, 0, pod.matchPrefix(first.blocks))
+	assert.Equal(t, 16, pod.blocks.Len())
+}
+
+// newTestTrace returns a trace of requests sharing one of the system prompts, arriving every interval.
+func newTestTrace(requests, systemPrompts int, interval time.Duration) []*Request {
+	trace := make([]*Request, requests)
+	for i := range trace {
+		prompt := strings.Repeat(fmt.Sprintf("system prompt %d. ", i%systemPrompts), 100) + fmt.Sprintf("question %d", i)
+		trace[i] = &Request{
+			ID:           fmt.Sprintf("request-%d", i),
+			Arrival:      time.Duration(i) * interval,
+			Model:        "model",
+			Prompt:       prompt,
+			PromptTokens: len(prompt) / charactersPerToken,
+			OutputTokens: 50,
+		}
+	}
+	return trace
+}
+
+func TestSimulator_Run(t *testing.T) {
+	config := Config{Pods: 4, Pod: DefaultPodConfig()}
+	config.Pod.MaxRunning = 4
+	sim, err := New(config, newTestTrace(200, 4, 50*time.Millisecond))
+	require.NoError(t, err)
+	defer sim.Close()
+
+	_, err = sim.Run("unknown")
+	assert.Error(t, err)
+
+	reports := map[string]*Report{}
+	for _, algorithm := range []string{"random", "least-request", "slo"} {
+		report, err := sim.Run(algorithm)
+		require.NoError(t, err)
+		assert.Equal(t, 200, report.Requests)
+		assert.Equal(t, 200, report.Completed, algorithm)
+		assert.Equal(t, 0, report.Failed, algorithm)
+		assert.Greater(t, report.TTFTP50, time.Duration(0))
+		assert.LessOrEqual(t, report.TTFTP50, report.TTFTP99)
+		assert.Less(t, report.TTFTP99, report.E2EP99)
+		assert.Greater(t, report.PrefixHitRate, 0.0)
+		assert.GreaterOrEqual(t, report.LoadImbalance, 1.0)
+		reports[algorithm] = report
+	}
+	assert.LessOrEqual(t, reports["least-request"].InflightSpread, reports["random"].InflightSpread)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteReports(&out, []*Report{reports["random"], reports["least-request"]}))
+	assert.Contains(t, out.String(), "least-request")
+	assert.Len(t, strings.Split(strings.TrimSpace(out.String()), "\n"), 3)
+}
+
+func TestSimulator_RunSLOWithProfile(t *testing.T) {
+	data, err := os.ReadFile("../../../../python/aibrix/aibrix/gpu_optimizer/optimizer/profiling/result/simulator-llama2-7b-a100.json")
+	require.NoError(t, err)
+	profile := &cache.ModelGPUProfile{}
+	require.NoError(t, profile.Unmarshal(data))
+
+	config := Config{Pods: 2, Pod: DefaultPodConfig(), Profile: profile}
+	config.Pod.MaxRunning = 2
+	trace := newTestTrace(100, 4, 10*time.Millisecond)
+	sim, err := New(config, trace)
+	require.NoError(t, err)
+	defer sim.Close()
+
+	// Requests are queued while pods are busy, and routed in simulated time.
+	first, err := sim.Run("slo")
+	require.NoError(t, err)
+	assert.Equal(t, 100, first.Completed)
+	assert.Equal(t, 0, first.Failed)
+	assert.Greater(t, first.TTFTP99, first.TTFTP50)
+
+	// Replays in simulated time are deterministic.
+	second, err := sim.Run("slo")
+	require.NoError(t, err)
+	assert.Equal(t, first.TTFTP50, second.TTFTP50)
+	assert.Equal(t, first.E2EP99, second.E2EP99)
+}
+
+func TestSimulator_RunResetsQueueRouter(t *testing.T) {
+	sim, err := New(Config{Pods: 1, Pod: DefaultPodConfig()}, newTestTrace(10, 1, 10*time.Millisecond))
+	require.NoError(t, err)
+	defer sim.Close()
+
+	// Requests left in the queue by a run are not routed by the following runs.
+	router, err := sim.cache.getQueueRouter()
+	require.NoError(t, err)
+	stale := types.NewRoutingContext(context.Background(), routing.RouterSLO, "model", "prompt", "stale", "")
+	defer stale.Delete()
+	_, err = router.Route(stale, sim.cache.podList)
+	require.NoError(t, err)
+	require.Equal(t, 1, router.Len())
+
+	report, err := sim.Run("slo")
+	require.NoError(t, err)
+	assert.Equal(t, 10, report.Completed)
+	assert.Equal(t, 0, report.Failed)
+	assert.False(t, stale.HasRouted())
+	assert.Equal(t, 1, router.Len())
+
+	fresh, err := sim.cache.getQueueRouter()
+	require.NoError(t, err)
+	assert.NotSame(t, router, fresh)
+	assert.Equal(t, 0, fresh.Len())
+}
+
+func TestSimulator_InvalidConfig(t *testing.T) {
+	_, err := New(Config{Pods: 0, Pod: DefaultPodConfig()}, nil)
+	assert.Error(t, err)
+	_, err = New(Config{Pods: 1}, nil)
+	assert.Error(t, err)
+}
+
+func TestPercentiles(t *testing.T) {
+	durations := make([]time.Duration, 100)
+	for i := range durations {
+		durations[i] = time.Duration(100-i) * time.Millisecond
+	}
+	p50, p90, p99 := percentiles(durations)
+	assert.Equal(t, 50*time.Millisecond, p50)
+	assert.Equal(t, 90*time.Millisecond, p90)
+	assert.Equal(t, 99*time.Millisecond, p99)
+
+	p50, p90, p99 = percentiles(nil)
+	assert.Zero(t, p50+p90+p99)
+}
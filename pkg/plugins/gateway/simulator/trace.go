@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// charactersPerToken approximates the number of prompt characters of a token.
+const charactersPerToken = 4
+
+// Request is a request replayed from the trace.
+type Request struct {
+	ID           string
+	Arrival      time.Duration // Arrival time since the start of the trace
+	Model        string
+	User         string
+	SessionID    string
+	Prompt       string
+	PromptTokens int
+	OutputTokens int
+}
+
+// traceRequest is a request of a trace line, in the format of the workload generator in benchmarks/generator/workload_generator.
+type traceRequest struct {
+	Prompt             string `json:"prompt"`
+	PromptLength       int    `json:"prompt_length"`
+	OutputLength       int    `json:"output_length"`
+	LegacyPromptLength int    `json:"Prompt Length"`
+	LegacyOutputLength int    `json:"Output Length"`
+	Model              string `json:"model"`
+	User               string `json:"user"`
+	SessionID          string `json:"session_id"`
+}
+
+// traceEntry is a line of the trace, either a batch of requests or a single request arriving at the timestamp.
+type traceEntry struct {
+	Timestamp int64           `json:"timestamp"` // Milliseconds since the start of the trace
+	Requests  *[]traceRequest `json:"requests"`
+	traceRequest
+}
+
+// LoadTrace reads a JSONL request trace. Each line has a "timestamp" in milliseconds, and either a "requests" list or
+// the fields of a single request. Requests without model use the default model, and requests without prompt get a
+// unique synthetic prompt of the prompt length.
+func LoadTrace(r io.Reader, defaultModel string) ([]*Request, error) {
+	var requests []*Request
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var entry traceEntry
+		if err := json.Unmarshal([]byte(text), &entry); err != nil {
+			return nil, fmt.Errorf("invalid trace line %d: %v", line, err)
+		}
+		batch := []traceRequest{entry.traceRequest}
+		if entry.Requests != nil {
+			batch = *entry.Requests
+		}
+		for _, tr := range batch {
+			req := newRequest(fmt.Sprintf("request-%d", len(requests)), tr, defaultModel)
+			req.Arrival = time.Duration(entry.Timestamp) * time.Millisecond
+			if req.PromptTokens <= 0 {
+				return nil, fmt.Errorf("request of trace line %d has neither prompt nor prompt length", line)
+			}
+			requests = append(requests, req)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].Arrival < requests[j].Arrival
+	})
+	return requests, nil
+}
+
+func newRequest(id string, tr traceRequest, defaultModel string) *Request {
+	req := &Request{
+		ID:           id,
+		Model:        tr.Model,
+		User:         tr.User,
+		SessionID:    tr.SessionID,
+		Prompt:       tr.Prompt,
+		PromptTokens: firstPositive(tr.PromptLength, tr.LegacyPromptLength),
+		OutputTokens: max(firstPositive(tr.OutputLength, tr.LegacyOutputLength), 1),
+	}
+	if req.Model == "" {
+		req.Model = defaultModel
+	}
+	if req.PromptTokens <= 0 {
+		req.PromptTokens = (len(req.Prompt) + charactersPerToken - 1) / charactersPerToken
+	}
+	if req.Prompt == "" && req.PromptTokens > 0 {
+		req.Prompt = syntheticPrompt(id, req.PromptTokens)
+	}
+	return req
+}
+
+// syntheticPrompt returns a prompt of the tokens that shares no prefix with the prompts of other requests.
+func syntheticPrompt(id string, tokens int) string {
+	size := tokens * charactersPerToken
+	var sb strings.Builder
+	sb.Grow(size + len(id))
+	for sb.Len() < size {
+		sb.WriteString(id)
+		sb.WriteByte(' ')
+	}
+	return sb.String()[:size]
+}
+
+func firstPositive(values ...int) int {
+	for _, value := range values {
+		if value > 0 {
+			return value
+		}
+	}
+	return 0
+}
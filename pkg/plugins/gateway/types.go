@@ -22,10 +22,12 @@ import (
 )
 
 const (
-	HeaderErrorInvalidRouting = "x-error-invalid-routing-strategy"
+	HeaderErrorInvalidRouting        = "x-error-invalid-routing-strategy"
+	HeaderErrorInvalidRequestTimeout = "x-error-invalid-request-timeout"
 
 	// General Error Headers
 	HeaderErrorUser                  = "x-error-user"
+	HeaderErrorAuthentication        = "x-error-authentication"
 	HeaderErrorRouting               = "x-error-routing"
 	HeaderErrorRequestBodyProcessing = "x-error-request-body-processing"
 	HeaderErrorResponseUnmarshal     = "x-error-response-unmarshal"
@@ -34,6 +36,8 @@ const (
 	// Model & Deployment Headers
 	HeaderErrorNoModelInRequest = "x-error-no-model-in-request"
 	HeaderErrorNoModelBackends  = "x-error-no-model-backends"
+	HeaderErrorActivation       = "x-error-activation"
+	HeaderErrorRequestTimeout   = "x-error-request-timeout"
 
 	// Streaming Headers
 	HeaderErrorStream                    = "x-error-stream"
@@ -46,6 +50,14 @@ const (
 	HeaderRoutingStrategy    = "routing-strategy"
 	HeaderRequestID          = "request-id"
 	HeaderModel              = "model"
+	HeaderResolvedModel      = "x-aibrix-resolved-model"
+	HeaderResponseCache      = "x-aibrix-cache"
+	HeaderPriority           = "x-aibrix-priority"
+	HeaderRequestTimeout     = "x-request-timeout-ms"
+
+	// Retry Headers
+	HeaderRetryAttempts = "x-retry-attempts"
+	HeaderRetryFailures = "x-retry-failures"
 
 	// RPM & TPM Update Errors
 	HeaderUpdateTPM        = "x-update-tpm"
@@ -60,7 +72,21 @@ const (
 	DefaultTPMMultiplier = 1000
 
 	// Envs
-	EnvRoutingAlgorithm = "ROUTING_ALGORITHM"
+	EnvRoutingAlgorithm        = "ROUTING_ALGORITHM"
+	EnvAPIKeyAuthEnabled       = "AIBRIX_GATEWAY_API_KEY_AUTH_ENABLED"
+	EnvUserHeaderEnabled       = "AIBRIX_GATEWAY_USER_HEADER_ENABLED"
+	EnvActivatorEnabled        = "AIBRIX_GATEWAY_ACTIVATOR_ENABLED"
+	EnvOutlierDetectionEnabled = "AIBRIX_GATEWAY_OUTLIER_DETECTION_ENABLED"
+	EnvRoutingPolicyEnabled    = "AIBRIX_GATEWAY_ROUTING_POLICY_ENABLED"
+	EnvResponseCache           = "AIBRIX_GATEWAY_RESPONSE_CACHE"
+	EnvStreamUsageInjection    = "AIBRIX_GATEWAY_STREAM_USAGE_INJECTION_ENABLED"
+
+	// Supported request paths
+	PathChatCompletions = "/v1/chat/completions"
+	PathCompletions     = "/v1/completions"
+	PathEmbeddings      = "/v1/embeddings"
+	PathRerank          = "/v1/rerank"
+	PathScore           = "/v1/score"
 )
 
 var (
@@ -18,6 +18,9 @@ package gateway
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 
 	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -29,10 +32,11 @@ import (
 )
 
 // validateRequestBody validates input by unmarshaling request body into respective openai-golang struct based on requestpath.
+// usageInjected is true if the gateway asks for the usage of streaming requests of the path itself.
 // nolint:nakedret
-func validateRequestBody(requestID, requestPath string, requestBody []byte, user utils.User) (model, message string, stream bool, errRes *extProcPb.ProcessingResponse) {
+func validateRequestBody(requestID, requestPath string, requestBody []byte, user utils.User, usageInjected bool) (model, message string, stream bool, errRes *extProcPb.ProcessingResponse) {
 	var streamOptions openai.ChatCompletionStreamOptionsParam
-	if requestPath == "/v1/chat/completions" {
+	if requestPath == PathChatCompletions {
 		var jsonMap map[string]json.RawMessage
 		if err := json.Unmarshal(requestBody, &jsonMap); err != nil {
 			klog.ErrorS(err, "error to unmarshal request body", "requestID", requestID, "requestBody", string(requestBody))
@@ -50,10 +54,10 @@ func validateRequestBody(requestID, requestPath string, requestBody []byte, user
 		if message, errRes = getChatCompletionsMessage(requestID, chatCompletionObj); errRes != nil {
 			return
 		}
-		if errRes = validateStreamOptions(requestID, user, &stream, streamOptions, jsonMap); errRes != nil {
+		if errRes = validateStreamOptions(requestID, user, &stream, streamOptions, jsonMap, usageInjected); errRes != nil {
 			return
 		}
-	} else if requestPath == "/v1/completions" {
+	} else if requestPath == PathCompletions {
 		// openai.CompletionsNewParams does not support json unmarshal for CompletionNewParamsPromptUnion in release v0.1.0-beta.10
 		// once supported, input request will be directly unmarshal into openai.CompletionsNewParams
 		type Completion struct {
@@ -69,6 +73,18 @@ func validateRequestBody(requestID, requestPath string, requestBody []byte, user
 		}
 		model = completionObj.Model
 		message = completionObj.Prompt
+	} else if requestPath == PathEmbeddings {
+		if model, message, errRes = getEmbeddingsMessage(requestID, requestBody); errRes != nil {
+			return
+		}
+	} else if requestPath == PathRerank {
+		if model, message, errRes = getRerankMessage(requestID, requestBody); errRes != nil {
+			return
+		}
+	} else if requestPath == PathScore {
+		if model, message, errRes = getScoreMessage(requestID, requestBody); errRes != nil {
+			return
+		}
 	} else {
 		errRes = buildErrorResponse(envoyTypePb.StatusCode_NotImplemented, "unknown request path", HeaderErrorRequestBodyProcessing, "true")
 		return
@@ -79,7 +95,7 @@ func validateRequestBody(requestID, requestPath string, requestBody []byte, user
 }
 
 // validateStreamOptions validates whether stream options to include usage is set for user request
-func validateStreamOptions(requestID string, user utils.User, stream *bool, streamOptions openai.ChatCompletionStreamOptionsParam, jsonMap map[string]json.RawMessage) *extProcPb.ProcessingResponse {
+func validateStreamOptions(requestID string, user utils.User, stream *bool, streamOptions openai.ChatCompletionStreamOptionsParam, jsonMap map[string]json.RawMessage, usageInjected bool) *extProcPb.ProcessingResponse {
 	streamData, ok := jsonMap["stream"]
 	if !ok {
 		return nil
@@ -90,7 +106,8 @@ func validateStreamOptions(requestID string, user utils.User, stream *bool, stre
 		return buildErrorResponse(envoyTypePb.StatusCode_BadRequest, "stream incorrectly set", HeaderErrorStream, "stream incorrectly set")
 	}
 
-	if *stream && user.Tpm > 0 {
+	// The gateway asks for the usage itself if stream usage injection is enabled.
+	if *stream && user.Tpm > 0 && !usageInjected {
 		if !streamOptions.IncludeUsage.Value {
 			klog.ErrorS(nil, "no stream with usage option available", "requestID", requestID, "streamOption", streamOptions)
 			return buildErrorResponse(envoyTypePb.StatusCode_BadRequest, "include usage for stream options not set",
@@ -142,6 +159,149 @@ func getChatCompletionsMessage(requestID string, chatCompletionObj openai.ChatCo
 	return builder.String(), nil
 }
 
+// getEmbeddingsMessage returns model and message for embeddings request body
+func getEmbeddingsMessage(requestID string, requestBody []byte) (string, string, *extProcPb.ProcessingResponse) {
+	type Embeddings struct {
+		Model string          `json:"model"`
+		Input json.RawMessage `json:"input"`
+	}
+	embeddingsObj := Embeddings{}
+	if err := json.Unmarshal(requestBody, &embeddingsObj); err != nil {
+		klog.ErrorS(err, "error to unmarshal embeddings object", "requestID", requestID, "requestBody", string(requestBody))
+		return "", "", buildErrorResponse(envoyTypePb.StatusCode_BadRequest, "error processing request body", HeaderErrorRequestBodyProcessing, "true")
+	}
+	message, err := getTextInput(embeddingsObj.Input)
+	if err != nil {
+		klog.ErrorS(err, "invalid input in the embeddings request body", "requestID", requestID)
+		return embeddingsObj.Model, "", buildErrorResponse(envoyTypePb.StatusCode_BadRequest, "invalid input in the request body", HeaderErrorRequestBodyProcessing, "true")
+	}
+	return embeddingsObj.Model, message, nil
+}
+
+// getRerankMessage returns model and message for rerank request body, the message is the query followed by documents.
+func getRerankMessage(requestID string, requestBody []byte) (string, string, *extProcPb.ProcessingResponse) {
+	type Rerank struct {
+		Model     string          `json:"model"`
+		Query     string          `json:"query"`
+		Documents json.RawMessage `json:"documents"`
+	}
+	rerankObj := Rerank{}
+	if err := json.Unmarshal(requestBody, &rerankObj); err != nil {
+		klog.ErrorS(err, "error to unmarshal rerank object", "requestID", requestID, "requestBody", string(requestBody))
+		return "", "", buildErrorResponse(envoyTypePb.StatusCode_BadRequest, "error processing request body", HeaderErrorRequestBodyProcessing, "true")
+	}
+	documents, err := getTextInput(rerankObj.Documents)
+	if err != nil || rerankObj.Query == "" {
+		klog.ErrorS(err, "invalid query or documents in the rerank request body", "requestID", requestID)
+		return rerankObj.Model, "", buildErrorResponse(envoyTypePb.StatusCode_BadRequest, "invalid query or documents in the request body", HeaderErrorRequestBodyProcessing, "true")
+	}
+	return rerankObj.Model, rerankObj.Query + " " + documents, nil
+}
+
+// getScoreMessage returns model and message for score request body, the message is text_1 followed by text_2.
+func getScoreMessage(requestID string, requestBody []byte) (string, string, *extProcPb.ProcessingResponse) {
+	type Score struct {
+		Model string          `json:"model"`
+		Text1 json.RawMessage `json:"text_1"`
+		Text2 json.RawMessage `json:"text_2"`
+	}
+	scoreObj := Score{}
+	if err := json.Unmarshal(requestBody, &scoreObj); err != nil {
+		klog.ErrorS(err, "error to unmarshal score object", "requestID", requestID, "requestBody", string(requestBody))
+		return "", "", buildErrorResponse(envoyTypePb.StatusCode_BadRequest, "error processing request body", HeaderErrorRequestBodyProcessing, "true")
+	}
+	text1, err1 := getTextInput(scoreObj.Text1)
+	text2, err2 := getTextInput(scoreObj.Text2)
+	if err := errors.Join(err1, err2); err != nil {
+		klog.ErrorS(err, "invalid text_1 or text_2 in the score request body", "requestID", requestID)
+		return scoreObj.Model, "", buildErrorResponse(envoyTypePb.StatusCode_BadRequest, "invalid text_1 or text_2 in the request body", HeaderErrorRequestBodyProcessing, "true")
+	}
+	return scoreObj.Model, text1 + " " + text2, nil
+}
+
+// getTextInput flattens an input that can be a string, an array of strings, an array of token ids or
+// an array of token id arrays into a single space separated string. Token ids are kept in their decimal form
+// so that requests with the same tokens still share the same prefix.
+func getTextInput(input json.RawMessage) (string, error) {
+	if len(input) == 0 || string(input) == "null" {
+		return "", errors.New("input is empty")
+	}
+
+	var text string
+	if err := json.Unmarshal(input, &text); err == nil {
+		return text, nil
+	}
+
+	var texts []string
+	if err := json.Unmarshal(input, &texts); err == nil {
+		if len(texts) == 0 {
+			return "", errors.New("input is empty")
+		}
+		return strings.Join(texts, " "), nil
+	}
+
+	var tokens []int64
+	if err := json.Unmarshal(input, &tokens); err == nil {
+		if len(tokens) == 0 {
+			return "", errors.New("input is empty")
+		}
+		return joinTokens(tokens), nil
+	}
+
+	var tokenArrays [][]int64
+	if err := json.Unmarshal(input, &tokenArrays); err == nil {
+		if len(tokenArrays) == 0 {
+			return "", errors.New("input is empty")
+		}
+		parts := make([]string, 0, len(tokenArrays))
+		for _, tokens := range tokenArrays {
+			parts = append(parts, joinTokens(tokens))
+		}
+		return strings.Join(parts, " "), nil
+	}
+
+	return "", fmt.Errorf("unsupported input type: %s", string(input))
+}
+
+func joinTokens(tokens []int64) string {
+	parts := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		parts = append(parts, strconv.FormatInt(token, 10))
+	}
+	return strings.Join(parts, " ")
+}
+
+// isPoolingRequestPath returns true for the embeddings, rerank and score endpoints, whose responses carry
+// usage but no completion choices.
+func isPoolingRequestPath(requestPath string) bool {
+	return requestPath == PathEmbeddings || requestPath == PathRerank || requestPath == PathScore
+}
+
+// unmarshalPoolingResponse extracts model and usage from embeddings, rerank and score responses.
+// Rerank responses only report total_tokens, which are all counted as prompt tokens.
+func unmarshalPoolingResponse(body []byte) (string, openai.CompletionUsage, error) {
+	type PoolingResponse struct {
+		Model string `json:"model"`
+		Usage struct {
+			PromptTokens int64 `json:"prompt_tokens"`
+			TotalTokens  int64 `json:"total_tokens"`
+		} `json:"usage"`
+	}
+	var res PoolingResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return "", openai.CompletionUsage{}, err
+	}
+
+	usage := openai.CompletionUsage{
+		PromptTokens: res.Usage.PromptTokens,
+		TotalTokens:  res.Usage.TotalTokens,
+	}
+	if usage.PromptTokens == 0 {
+		usage.PromptTokens = usage.TotalTokens
+	}
+	return res.Model, usage, nil
+}
+
 // generateErrorResponse construct envoy proxy error response
 // deprecated: use buildErrorResponse
 func generateErrorResponse(statusCode envoyTypePb.StatusCode, headers []*configPb.HeaderValueOption, body string) *extProcPb.ProcessingResponse {
@@ -34,6 +34,7 @@ func Test_ValidateRequestBody(t *testing.T) {
 		messages    string
 		stream      bool
 		user        utils.User
+		injectUsage bool
 		statusCode  envoyTypePb.StatusCode
 	}{
 		{
@@ -117,6 +118,24 @@ func Test_ValidateRequestBody(t *testing.T) {
 			requestBody: []byte(`{"model": "llama2-7b", "stream": true, "stream_options": {"include_usage": false},  "messages": [{"role": "system", "content": "this is system"}]}`),
 			statusCode:  envoyTypePb.StatusCode_BadRequest,
 		},
+		{
+			message:     "/v1/chat/completions stream options is null with user.TPM >= 1 is OK if usage is injected",
+			requestPath: "/v1/chat/completions",
+			user:        utils.User{Tpm: 1},
+			injectUsage: true,
+			requestBody: []byte(`{"model": "llama2-7b", "stream": true, "messages": [{"role": "system", "content": "this is system"}]}`),
+			stream:      true,
+			statusCode:  envoyTypePb.StatusCode_OK,
+		},
+		{
+			message:     "/v1/chat/completions stream_options.include_usage == false with user.TPM >= 1 is OK if usage is injected",
+			user:        utils.User{Tpm: 1},
+			injectUsage: true,
+			requestPath: "/v1/chat/completions",
+			requestBody: []byte(`{"model": "llama2-7b", "stream": true, "stream_options": {"include_usage": false},  "messages": [{"role": "system", "content": "this is system"}]}`),
+			stream:      true,
+			statusCode:  envoyTypePb.StatusCode_OK,
+		},
 		{
 			message:     "/v1/chat/completions stream_options.include_usage == false with user.TPM == 0 is OK",
 			requestPath: "/v1/chat/completions",
@@ -133,10 +152,80 @@ func Test_ValidateRequestBody(t *testing.T) {
 			messages:    "this is system say this is test",
 			statusCode:  envoyTypePb.StatusCode_OK,
 		},
+		{
+			message:     "/v1/embeddings json unmarshal error",
+			requestPath: "/v1/embeddings",
+			requestBody: []byte("bad_request"),
+			statusCode:  envoyTypePb.StatusCode_BadRequest,
+		},
+		{
+			message:     "/v1/embeddings no input",
+			requestPath: "/v1/embeddings",
+			requestBody: []byte(`{"model": "bge-large"}`),
+			statusCode:  envoyTypePb.StatusCode_BadRequest,
+		},
+		{
+			message:     "/v1/embeddings string input",
+			requestPath: "/v1/embeddings",
+			requestBody: []byte(`{"model": "bge-large", "input": "say this is test"}`),
+			model:       "bge-large",
+			messages:    "say this is test",
+			statusCode:  envoyTypePb.StatusCode_OK,
+		},
+		{
+			message:     "/v1/embeddings array of strings input",
+			requestPath: "/v1/embeddings",
+			requestBody: []byte(`{"model": "bge-large", "input": ["say this", "is test"]}`),
+			model:       "bge-large",
+			messages:    "say this is test",
+			statusCode:  envoyTypePb.StatusCode_OK,
+		},
+		{
+			message:     "/v1/embeddings array of token arrays input",
+			requestPath: "/v1/embeddings",
+			requestBody: []byte(`{"model": "bge-large", "input": [[1, 2], [3]]}`),
+			model:       "bge-large",
+			messages:    "1 2 3",
+			statusCode:  envoyTypePb.StatusCode_OK,
+		},
+		{
+			message:     "/v1/embeddings unsupported input",
+			requestPath: "/v1/embeddings",
+			requestBody: []byte(`{"model": "bge-large", "input": {"text": "say this is test"}}`),
+			statusCode:  envoyTypePb.StatusCode_BadRequest,
+		},
+		{
+			message:     "/v1/rerank no query",
+			requestPath: "/v1/rerank",
+			requestBody: []byte(`{"model": "bge-reranker", "documents": ["doc1"]}`),
+			statusCode:  envoyTypePb.StatusCode_BadRequest,
+		},
+		{
+			message:     "/v1/rerank valid request body",
+			requestPath: "/v1/rerank",
+			requestBody: []byte(`{"model": "bge-reranker", "query": "what is test", "documents": ["doc1", "doc2"], "top_n": 1}`),
+			model:       "bge-reranker",
+			messages:    "what is test doc1 doc2",
+			statusCode:  envoyTypePb.StatusCode_OK,
+		},
+		{
+			message:     "/v1/score missing text_2",
+			requestPath: "/v1/score",
+			requestBody: []byte(`{"model": "bge-reranker", "text_1": "what is test"}`),
+			statusCode:  envoyTypePb.StatusCode_BadRequest,
+		},
+		{
+			message:     "/v1/score valid request body",
+			requestPath: "/v1/score",
+			requestBody: []byte(`{"model": "bge-reranker", "text_1": "what is test", "text_2": ["doc1", "doc2"]}`),
+			model:       "bge-reranker",
+			messages:    "what is test doc1 doc2",
+			statusCode:  envoyTypePb.StatusCode_OK,
+		},
 	}
 
 	for _, tt := range testCases {
-		model, messages, stream, errRes := validateRequestBody("1", tt.requestPath, tt.requestBody, tt.user)
+		model, messages, stream, errRes := validateRequestBody("1", tt.requestPath, tt.requestBody, tt.user, tt.injectUsage)
 
 		if tt.statusCode == 200 {
 			assert.Equal(t, (*extProcPb.ProcessingResponse)(nil), errRes, tt.message)
@@ -156,3 +245,53 @@ func Test_ValidateRequestBody(t *testing.T) {
 		}
 	}
 }
+
+func Test_unmarshalPoolingResponse(t *testing.T) {
+	testCases := []struct {
+		message      string
+		body         string
+		model        string
+		promptTokens int64
+		totalTokens  int64
+		hasError     bool
+	}{
+		{
+			message:  "invalid json",
+			body:     "bad_response",
+			hasError: true,
+		},
+		{
+			message:      "embeddings response",
+			body:         `{"object": "list", "data": [{"object": "embedding", "index": 0, "embedding": [0.1, 0.2]}], "model": "bge-large", "usage": {"prompt_tokens": 5, "total_tokens": 5}}`,
+			model:        "bge-large",
+			promptTokens: 5,
+			totalTokens:  5,
+		},
+		{
+			message:      "rerank response without prompt tokens",
+			body:         `{"id": "rerank-1", "model": "bge-reranker", "usage": {"total_tokens": 12}, "results": [{"index": 0, "relevance_score": 0.9}]}`,
+			model:        "bge-reranker",
+			promptTokens: 12,
+			totalTokens:  12,
+		},
+		{
+			message:      "score response",
+			body:         `{"id": "score-1", "object": "list", "model": "bge-reranker", "data": [{"index": 0, "object": "score", "score": 0.9}], "usage": {"prompt_tokens": 8, "total_tokens": 8}}`,
+			model:        "bge-reranker",
+			promptTokens: 8,
+			totalTokens:  8,
+		},
+	}
+
+	for _, tt := range testCases {
+		model, usage, err := unmarshalPoolingResponse([]byte(tt.body))
+		if tt.hasError {
+			assert.Error(t, err, tt.message)
+			continue
+		}
+		assert.NoError(t, err, tt.message)
+		assert.Equal(t, tt.model, model, tt.message)
+		assert.Equal(t, tt.promptTokens, usage.PromptTokens, tt.message)
+		assert.Equal(t, tt.totalTokens, usage.TotalTokens, tt.message)
+	}
+}
@@ -29,6 +29,9 @@ type QueueRouter interface {
 	Router
 
 	Len() int
+
+	// UserLen returns the number of queued requests of the user, zero if the queue does not keep requests by user.
+	UserLen(user string) int
 }
 
 // FallbackRouter enables router chaining by set a fallback router.
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -48,21 +49,30 @@ type RoutingAlgorithm string
 // It can be extended with more fields as needed in the future.
 type RoutingContext struct {
 	context.Context
-	Algorithm   RoutingAlgorithm
-	Model       string
-	Message     string
-	RequestID   string
-	User        *string
-	RequestTime time.Time // Time when the routing context is created.
-	PendingLoad float64   // Normalized pending load of request, available after AddRequestCount call. See cache.PendingLoadProvider
-	TraceTerm   int64     // Trace term identifier, available after AddRequestCount call.
-	RoutedTime  time.Time // Time consumed during routing.
+	Algorithm       RoutingAlgorithm
+	Model           string
+	ModelAlias      string // Virtual model name of the request if Model is resolved from a ModelAlias.
+	Message         string
+	RequestID       string
+	User            *string
+	Priority        string    // Priority class of the request, requests of unknown class are queued in the default class.
+	UserWeight      int64     // Fair queuing weight of the user of the request, zero for the default weight.
+	RequestTime     time.Time // Time when the routing context is created.
+	RequestDeadline time.Time // Time when the request expires, zero if the request has no deadline.
+	PendingLoad     float64   // Normalized pending load of request, available after AddRequestCount call. See cache.PendingLoadProvider
+	TraceTerm       int64     // Trace term identifier, available after AddRequestCount call.
+	RoutedTime      time.Time // Time consumed during routing.
 
 	ReqHeaders map[string]string
 	ReqBody    []byte
 	ReqPath    string
 
-	targetPodSet chan struct{}
+	// Parameters tune the router for the model of the request, e.g. set by the RoutingPolicy of the model.
+	// Parameters are shared across requests and must be treated as read-only.
+	Parameters map[string]string
+
+	targetPodMu  sync.Mutex    // Guards targetPodSet against ResetTargetPod.
+	targetPodSet chan struct{} // Closed once the target pod or an error is set.
 	targetPod    atomic.Pointer[v1.Pod]
 	lastError    atomic.Pointer[error]
 	tokens       []int           // Cache of tokenized prompts
@@ -110,6 +120,24 @@ func (r *RoutingContext) Elapsed(currentTime time.Time) time.Duration {
 	return currentTime.Sub(r.RequestTime)
 }
 
+// SetTimeout sets the deadline of the request to the timeout after the request was created.
+func (r *RoutingContext) SetTimeout(timeout time.Duration) {
+	r.RequestDeadline = r.RequestTime.Add(timeout)
+}
+
+// Expired returns true if the request has a deadline and the deadline has passed.
+func (r *RoutingContext) Expired(currentTime time.Time) bool {
+	return !r.RequestDeadline.IsZero() && !currentTime.Before(r.RequestDeadline)
+}
+
+// Remaining returns the time left before the deadline, and false if the request has no deadline.
+func (r *RoutingContext) Remaining(currentTime time.Time) (time.Duration, bool) {
+	if r.RequestDeadline.IsZero() {
+		return 0, false
+	}
+	return r.RequestDeadline.Sub(currentTime), true
+}
+
 // PromptTokens returns the tokenized prompt of the request.
 func (r *RoutingContext) PromptTokens() ([]int, error) {
 	if r.tokens == nil {
@@ -161,8 +189,30 @@ func (r *RoutingContext) Features() (RequestFeatures, error) {
 	return RequestFeatures{float64(outputLen), float64(promptLen)}, nil
 }
 
+// ParamInt returns the integer router parameter of the key, or def if the parameter is not set or invalid.
+func (r *RoutingContext) ParamInt(key string, def int) int {
+	if value, ok := r.Parameters[key]; ok {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return def
+}
+
+// ParamFloat returns the float router parameter of the key, or def if the parameter is not set or invalid.
+func (r *RoutingContext) ParamFloat(key string, def float64) float64 {
+	if value, ok := r.Parameters[key]; ok {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return def
+}
+
 // SetTargetPod sets the target pod of the routing context. All routers call this to set the target pod.
 func (r *RoutingContext) SetTargetPod(pod *v1.Pod) {
+	r.targetPodMu.Lock()
+	defer r.targetPodMu.Unlock()
 	if r.targetPod.CompareAndSwap(nilPod, pod) { // Use CompareAndSwap to ensure close channel only once
 		r.RoutedTime = time.Now()
 		close(r.targetPodSet)
@@ -180,21 +230,41 @@ func (r *RoutingContext) SetError(err error) {
 	r.SetTargetPod(nil)
 }
 
+// ResetTargetPod clears the routing result so that the request can be routed again, e.g. on retry.
+// Realtime statistics and trace flags are reset as well, call DoneRequestXXX on the cache before resetting
+// to release the statistics of the previous target pod.
+func (r *RoutingContext) ResetTargetPod() {
+	r.targetPodMu.Lock()
+	if r.targetPod.Load() != nilPod {
+		// Only a closed channel is replaced, so that waiters of the previous routing are never left blocked.
+		r.targetPodSet = make(chan struct{})
+		r.targetPod.Store(nilPod)
+	}
+	r.targetPodMu.Unlock()
+	r.lastError.Store(nil)
+	atomic.StoreInt32(&r.statsUpdated, statusInitial)
+	atomic.StoreInt32(&r.traceAdded, statusInitial)
+}
+
 // TargetPod returns the routing target pod of the request.
 // TargetPod blocks until the target pod is set or an error is set.
 func (r *RoutingContext) TargetPod() *v1.Pod {
-	targetPod := r.targetPod.Load()
-	if targetPod == nilPod {
+	for {
+		r.targetPodMu.Lock()
+		targetPod, targetPodSet := r.targetPod.Load(), r.targetPodSet
+		r.targetPodMu.Unlock()
+		if targetPod != nilPod {
+			return targetPod
+		}
+
 		r.debugWait()
 		select {
 		case <-r.Context.Done():
 			r.SetError(r.Context.Err())
-		case <-r.targetPodSet: // No blocking if targetPod is set after last "targetPod == nil"
+		case <-targetPodSet: // No blocking if targetPod is set after last "targetPod == nil"
 		}
-		targetPod = r.targetPod.Load()
+		// Loop in case the target pod has been reset after the channel is closed.
 	}
-
-	return targetPod
 }
 
 // GetError returns the error of the routing context.
@@ -261,6 +331,7 @@ func (r *RoutingContext) reset(ctx context.Context, algorithms RoutingAlgorithm,
 	r.Context = ctx
 	r.Algorithm = algorithms
 	r.Model = model
+	r.ModelAlias = ""
 	r.Message = message
 	r.RequestID = requestID
 	if user != "" {
@@ -268,13 +339,17 @@ func (r *RoutingContext) reset(ctx context.Context, algorithms RoutingAlgorithm,
 	} else {
 		r.User = nil
 	}
+	r.Priority = ""
+	r.UserWeight = 0
 	r.RequestTime = time.Now()
+	r.RequestDeadline = time.Time{}
 	r.PendingLoad = 0
 	r.TraceTerm = 0
 
 	r.ReqHeaders = map[string]string{}
 	r.ReqPath = ""
 	r.ReqBody = []byte{}
+	r.Parameters = nil
 	// RoutedTime will not be reset, it must before ReqeustTime at this time.
 
 	r.targetPodSet = make(chan struct{}) // Initialize channel
@@ -126,6 +126,36 @@ var _ = Describe("RouterContext", func() {
 		Expect(ctx.TargetPod()).To(BeIdenticalTo(pod))
 	})
 
+	It("should ResetTargetPod allow routing again", func() {
+		ctx := NewRoutingContext(context.Background(), "algorithm", "model", "message", "r1", "")
+		pod := &v1.Pod{}
+		ctx.SetTargetPod(pod)
+		Expect(ctx.CanAddStats()).To(BeTrue())
+		Expect(ctx.CanAddTrace()).To(BeTrue())
+
+		ctx.ResetTargetPod()
+		Expect(ctx.HasRouted()).To(BeFalse())
+		shouldBlock(func() { ctx.TargetPod() }, 100*time.Millisecond)
+		Expect(ctx.CanAddStats()).To(BeTrue())
+		Expect(ctx.CanAddTrace()).To(BeTrue())
+
+		pod2 := &v1.Pod{}
+		ctx.SetTargetPod(pod2)
+		Expect(ctx.TargetPod()).To(BeIdenticalTo(pod2))
+	})
+
+	It("should ResetTargetPod not block waiting routing", func() {
+		ctx := NewRoutingContext(context.Background(), "algorithm", "model", "message", "r1", "")
+		waited := make(chan *v1.Pod)
+		go func() { waited <- ctx.TargetPod() }()
+
+		// Reset while waiting, the waiter is unblocked by the next routing.
+		ctx.ResetTargetPod()
+		pod := &v1.Pod{}
+		ctx.SetTargetPod(pod)
+		Eventually(waited).Should(Receive(BeIdenticalTo(pod)))
+	})
+
 	It("should SetError also SetTargetPod", func() {
 		ctx := NewRoutingContext(context.Background(), "algorithm", "model", "message", "r1", "")
 		err := fmt.Errorf("test error")
@@ -176,4 +206,38 @@ var _ = Describe("RouterContext", func() {
 		// nolint: errcheck
 		shouldNotBlock(func() { ctx.GetError() }, 100*time.Millisecond)
 	})
+
+	It("should return router parameters or defaults", func() {
+		ctx := NewRoutingContext(context.Background(), "algorithm", "model", "message", "r1", "")
+		Expect(ctx.ParamInt("count", 4)).To(Equal(4))
+
+		ctx.Parameters = map[string]string{"count": "8", "weight": "0.5", "invalid": "x"}
+		Expect(ctx.ParamInt("count", 4)).To(Equal(8))
+		Expect(ctx.ParamFloat("weight", 1)).To(Equal(0.5))
+		Expect(ctx.ParamInt("invalid", 4)).To(Equal(4))
+		ctx.Delete()
+
+		ctx = NewRoutingContext(context.Background(), "algorithm", "model", "message", "r2", "")
+		Expect(ctx.Parameters).To(BeNil())
+		ctx.Delete()
+	})
+
+	It("should expire after the deadline", func() {
+		ctx := NewRoutingContext(context.Background(), "algorithm", "model", "message", "r1", "")
+		_, ok := ctx.Remaining(ctx.RequestTime)
+		Expect(ok).To(BeFalse())
+		Expect(ctx.Expired(ctx.RequestTime.Add(time.Hour))).To(BeFalse())
+
+		ctx.SetTimeout(time.Second)
+		remaining, ok := ctx.Remaining(ctx.RequestTime.Add(200 * time.Millisecond))
+		Expect(ok).To(BeTrue())
+		Expect(remaining).To(Equal(800 * time.Millisecond))
+		Expect(ctx.Expired(ctx.RequestTime.Add(999 * time.Millisecond))).To(BeFalse())
+		Expect(ctx.Expired(ctx.RequestTime.Add(time.Second))).To(BeTrue())
+		ctx.Delete()
+
+		ctx = NewRoutingContext(context.Background(), "algorithm", "model", "message", "r2", "")
+		Expect(ctx.RequestDeadline.IsZero()).To(BeTrue())
+		ctx.Delete()
+	})
 })
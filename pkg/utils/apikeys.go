@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const apiKeyPrefix = "sk-aibrix-"
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrAPIKeyExpired  = errors.New("api key has expired")
+	ErrAPIKeyRevoked  = errors.New("api key has been revoked")
+)
+
+// APIKey links a hashed api key to a User. The raw key is only returned on creation and never stored.
+type APIKey struct {
+	Hash      string     `json:"hash"`
+	User      string     `json:"user" validate:"required"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Revoked   bool       `json:"revoked"`
+}
+
+// Expired returns true if the key has an expiry before now.
+func (k APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// GenerateAPIKey returns a new random raw api key.
+func GenerateAPIKey() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(b), nil
+}
+
+// HashAPIKey returns the hex encoded sha256 of a raw api key.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseBearerToken extracts the token from an Authorization header value.
+func ParseBearerToken(authorization string) (string, bool) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func GetAPIKey(ctx context.Context, hash string, redisClient *redis.Client) (APIKey, error) {
+	val, err := redisClient.Get(ctx, genAPIKeyKey(hash)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return APIKey{}, ErrAPIKeyNotFound
+		}
+		return APIKey{}, err
+	}
+	key := &APIKey{}
+	if err = json.Unmarshal([]byte(val), key); err != nil {
+		return APIKey{}, err
+	}
+
+	return *key, nil
+}
+
+// SetAPIKey stores the key, which is kept in redis until shortly after its expiry.
+func SetAPIKey(ctx context.Context, k APIKey, redisClient *redis.Client) error {
+	if k.Hash == "" || k.User == "" {
+		return fmt.Errorf("api key hash and user are required")
+	}
+
+	b, err := json.Marshal(&k)
+	if err != nil {
+		return err
+	}
+
+	var ttl time.Duration
+	if k.ExpiresAt != nil {
+		ttl = time.Until(*k.ExpiresAt)
+		if ttl <= 0 {
+			return ErrAPIKeyExpired
+		}
+		// Keep expired keys for a while, so that they are rejected as expired rather than unknown.
+		ttl += 24 * time.Hour
+	}
+	return redisClient.Set(ctx, genAPIKeyKey(k.Hash), string(b), ttl).Err()
+}
+
+// RevokeAPIKey marks the key as revoked, revoked keys are kept so that they can be audited.
+func RevokeAPIKey(ctx context.Context, hash string, redisClient *redis.Client) error {
+	k, err := GetAPIKey(ctx, hash, redisClient)
+	if err != nil {
+		return err
+	}
+	k.Revoked = true
+
+	b, err := json.Marshal(&k)
+	if err != nil {
+		return err
+	}
+	return redisClient.Set(ctx, genAPIKeyKey(hash), string(b), redis.KeepTTL).Err()
+}
+
+// GetUserByAPIKey resolves the User of a raw api key, the key must exist, not be revoked nor expired.
+func GetUserByAPIKey(ctx context.Context, rawKey string, redisClient *redis.Client) (User, error) {
+	k, err := GetAPIKey(ctx, HashAPIKey(rawKey), redisClient)
+	if err != nil {
+		return User{}, err
+	}
+	if k.Revoked {
+		return User{}, ErrAPIKeyRevoked
+	}
+	if k.Expired(time.Now()) {
+		return User{}, ErrAPIKeyExpired
+	}
+
+	user, err := GetUser(ctx, User{Name: k.User}, redisClient)
+	if errors.Is(err, redis.Nil) {
+		return User{}, fmt.Errorf("%w: user %s of the api key does not exist", ErrAPIKeyNotFound, k.User)
+	}
+	return user, err
+}
+
+func genAPIKeyKey(hash string) string {
+	return fmt.Sprintf("aibrix-apikeys/%s", hash)
+}
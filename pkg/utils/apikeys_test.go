@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBearerToken(t *testing.T) {
+	token, ok := ParseBearerToken("Bearer sk-aibrix-123")
+	assert.True(t, ok)
+	assert.Equal(t, "sk-aibrix-123", token)
+
+	token, ok = ParseBearerToken("bearer  sk-aibrix-123 ")
+	assert.True(t, ok)
+	assert.Equal(t, "sk-aibrix-123", token)
+
+	_, ok = ParseBearerToken("Basic dXNlcjpwYXNz")
+	assert.False(t, ok)
+	_, ok = ParseBearerToken("Bearer ")
+	assert.False(t, ok)
+	_, ok = ParseBearerToken("")
+	assert.False(t, ok)
+}
+
+func TestGetUserByAPIKey(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	ctx := context.Background()
+
+	assert.NoError(t, SetUser(ctx, User{Name: "alice", Rpm: 10, Tpm: 100}, client))
+
+	key, err := GenerateAPIKey()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, apiKeyPrefix))
+	hash := HashAPIKey(key)
+	assert.NotContains(t, hash, key)
+
+	_, err = GetUserByAPIKey(ctx, key, client)
+	assert.ErrorIs(t, err, ErrAPIKeyNotFound)
+
+	assert.NoError(t, SetAPIKey(ctx, APIKey{Hash: hash, User: "alice", CreatedAt: time.Now()}, client))
+	user, err := GetUserByAPIKey(ctx, key, client)
+	assert.NoError(t, err)
+	assert.Equal(t, User{Name: "alice", Rpm: 10, Tpm: 100}, user)
+
+	// The raw key is never stored.
+	for _, k := range mr.Keys() {
+		v, _ := mr.Get(k)
+		assert.NotContains(t, v, key)
+	}
+
+	assert.NoError(t, RevokeAPIKey(ctx, hash, client))
+	_, err = GetUserByAPIKey(ctx, key, client)
+	assert.ErrorIs(t, err, ErrAPIKeyRevoked)
+	assert.ErrorIs(t, RevokeAPIKey(ctx, HashAPIKey("unknown"), client), ErrAPIKeyNotFound)
+
+	expired := time.Now().Add(-time.Minute)
+	assert.ErrorIs(t, SetAPIKey(ctx, APIKey{Hash: hash, User: "alice", ExpiresAt: &expired}, client), ErrAPIKeyExpired)
+
+	expiresAt := time.Now().Add(time.Hour)
+	assert.NoError(t, SetAPIKey(ctx, APIKey{Hash: hash, User: "alice", ExpiresAt: &expiresAt}, client))
+	_, err = GetUserByAPIKey(ctx, key, client)
+	assert.NoError(t, err)
+	apiKey, err := GetAPIKey(ctx, hash, client)
+	assert.NoError(t, err)
+	assert.True(t, apiKey.Expired(expiresAt))
+	assert.False(t, apiKey.Expired(expiresAt.Add(-time.Second)))
+
+	assert.NoError(t, DelUser(ctx, User{Name: "alice"}, client))
+	_, err = GetUserByAPIKey(ctx, key, client)
+	assert.ErrorIs(t, err, ErrAPIKeyNotFound)
+}
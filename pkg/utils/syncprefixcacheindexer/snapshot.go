@@ -0,0 +1,274 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncprefixcacheindexer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// snapshotVersion is bumped whenever the snapshot format changes incompatibly
+const snapshotVersion = 1
+
+// Snapshot is a serializable copy of the prefix hash table
+type Snapshot struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Seed must be restored so that restored prefix hashes match newly computed ones
+	Seed     uint64            `json:"seed"`
+	Contexts []ContextSnapshot `json:"contexts"`
+
+	// Pods records the event stream position of each subscribed pod, filled by the event consumer
+	Pods map[string]PodState `json:"pods,omitempty"`
+}
+
+// ContextSnapshot is the snapshot of a (model, lora_id) context
+type ContextSnapshot struct {
+	ModelName    string           `json:"model_name"`
+	LoraID       int64            `json:"lora_id"`
+	Prefixes     []PrefixSnapshot `json:"prefixes"`
+	EngineHashes map[int64]uint64 `json:"engine_hashes"` // engine block hash → aibrix prefix hash
+}
+
+// PrefixSnapshot is a prefix hash and the pods caching it
+type PrefixSnapshot struct {
+	Hash uint64   `json:"hash"`
+	Pods []string `json:"pods"`
+}
+
+// PodState is the event stream position of a pod when the snapshot was taken
+type PodState struct {
+	// UID distinguishes a recreated pod from the one the events came from
+	UID string `json:"uid,omitempty"`
+	// LastSequence is the last processed event sequence, -1 if none was processed
+	LastSequence int64 `json:"last_sequence"`
+}
+
+// Snapshot returns a copy of the table, contexts marked for eviction are skipped
+func (s *SyncPrefixHashTable) Snapshot() *Snapshot {
+	snapshot := &Snapshot{
+		Version:   snapshotVersion,
+		CreatedAt: time.Now(),
+		Seed:      s.seed,
+		Contexts:  make([]ContextSnapshot, 0),
+	}
+
+	s.contextMap.Range(func(key, value interface{}) bool {
+		ctx := key.(ModelContext)
+		contextData := value.(*ContextData)
+		if contextData.markedForEviction.Load() {
+			return true
+		}
+
+		// Same lock order as ProcessBlockStored
+		contextData.mappingMu.RLock()
+		contextData.prefixMu.RLock()
+		contextSnapshot := ContextSnapshot{
+			ModelName:    ctx.ModelName,
+			LoraID:       ctx.LoraID,
+			Prefixes:     make([]PrefixSnapshot, 0, len(contextData.prefixStore.prefixMap)),
+			EngineHashes: make(map[int64]uint64, len(contextData.hashMapping.engineToAibrix)),
+		}
+		for prefixHash, pods := range contextData.prefixStore.prefixMap {
+			prefix := PrefixSnapshot{Hash: prefixHash, Pods: make([]string, 0, len(pods))}
+			for podName := range pods {
+				prefix.Pods = append(prefix.Pods, podName)
+			}
+			contextSnapshot.Prefixes = append(contextSnapshot.Prefixes, prefix)
+		}
+		for engineHash, aibrixHash := range contextData.hashMapping.engineToAibrix {
+			contextSnapshot.EngineHashes[engineHash] = aibrixHash
+		}
+		contextData.prefixMu.RUnlock()
+		contextData.mappingMu.RUnlock()
+
+		snapshot.Contexts = append(snapshot.Contexts, contextSnapshot)
+		return true
+	})
+
+	return snapshot
+}
+
+// Restore loads a snapshot into the table, keeping only the pods in livePods.
+// Prefixes left without pods and engine hashes mapped to them are dropped.
+// It must be called before the table processes events or serves lookups.
+func (s *SyncPrefixHashTable) Restore(snapshot *Snapshot, livePods map[string]struct{}) error {
+	if snapshot == nil {
+		return nil
+	}
+	if snapshot.Version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d, expected %d", snapshot.Version, snapshotVersion)
+	}
+
+	s.seed = snapshot.Seed
+	now := time.Now().Unix()
+
+	for _, contextSnapshot := range snapshot.Contexts {
+		ctx := ModelContext{
+			ModelName: contextSnapshot.ModelName,
+			LoraID:    contextSnapshot.LoraID,
+		}
+
+		prefixMap := make(map[uint64]map[string]*PodInfo, len(contextSnapshot.Prefixes))
+		for _, prefix := range contextSnapshot.Prefixes {
+			pods := make(map[string]*PodInfo, len(prefix.Pods))
+			for _, podName := range prefix.Pods {
+				if _, alive := livePods[podName]; !alive {
+					continue
+				}
+				podInfo := &PodInfo{SourcePod: podName}
+				podInfo.LastAccessTime.Store(now)
+				pods[podName] = podInfo
+			}
+			if len(pods) > 0 {
+				prefixMap[prefix.Hash] = pods
+			}
+		}
+		if len(prefixMap) == 0 {
+			continue
+		}
+
+		contextData := s.getOrCreateContextData(ctx)
+		contextData.mappingMu.Lock()
+		contextData.prefixMu.Lock()
+		for prefixHash, pods := range prefixMap {
+			if _, exists := contextData.prefixStore.prefixMap[prefixHash]; !exists {
+				contextData.prefixStore.totalPrefixes++
+			}
+			contextData.prefixStore.prefixMap[prefixHash] = pods
+		}
+		for engineHash, aibrixHash := range contextSnapshot.EngineHashes {
+			if _, exists := prefixMap[aibrixHash]; !exists {
+				continue
+			}
+			contextData.hashMapping.engineToAibrix[engineHash] = aibrixHash
+			s.updateBlockIndex(engineHash, ctx, true)
+		}
+		contextData.prefixStore.lastAccess.Store(now)
+		contextData.prefixMu.Unlock()
+		contextData.mappingMu.Unlock()
+	}
+
+	return nil
+}
+
+// SnapshotStore persists snapshots of the prefix hash table
+type SnapshotStore interface {
+	// Save replaces the stored snapshot
+	Save(ctx context.Context, snapshot *Snapshot) error
+	// Load returns the stored snapshot, or nil if there is none
+	Load(ctx context.Context) (*Snapshot, error)
+}
+
+// FileSnapshotStore stores the snapshot in a local file
+type FileSnapshotStore struct {
+	path string
+}
+
+// NewFileSnapshotStore creates a snapshot store writing to path
+func NewFileSnapshotStore(path string) *FileSnapshotStore {
+	return &FileSnapshotStore{path: path}
+}
+
+// Save writes the snapshot to a temporary file then renames it, so a crash never leaves a partial snapshot
+func (f *FileSnapshotStore) Save(ctx context.Context, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("failed to replace snapshot file: %w", err)
+	}
+	return nil
+}
+
+// Load reads the snapshot file, a missing file is not an error
+func (f *FileSnapshotStore) Load(ctx context.Context) (*Snapshot, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+	return decodeSnapshot(data)
+}
+
+// RedisSnapshotStore stores the snapshot under a Redis key
+type RedisSnapshotStore struct {
+	client *redis.Client
+	key    string
+}
+
+// NewRedisSnapshotStore creates a snapshot store writing to key
+func NewRedisSnapshotStore(client *redis.Client, key string) *RedisSnapshotStore {
+	return &RedisSnapshotStore{client: client, key: key}
+}
+
+// Save writes the snapshot to Redis
+func (r *RedisSnapshotStore) Save(ctx context.Context, snapshot *Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := r.client.Set(ctx, r.key, data, 0).Err(); err != nil {
+		return fmt.Errorf("failed to save snapshot to redis: %w", err)
+	}
+	return nil
+}
+
+// Load reads the snapshot from Redis, a missing key is not an error
+func (r *RedisSnapshotStore) Load(ctx context.Context) (*Snapshot, error) {
+	data, err := r.client.Get(ctx, r.key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load snapshot from redis: %w", err)
+	}
+	return decodeSnapshot(data)
+}
+
+func decodeSnapshot(data []byte) (*Snapshot, error) {
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
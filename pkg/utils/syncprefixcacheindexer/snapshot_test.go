@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syncprefixcacheindexer
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newSnapshotTestTable creates a table with blocks stored by pod1 and pod2
+func newSnapshotTestTable(t *testing.T) *SyncPrefixHashTable {
+	table := NewSyncPrefixHashTable()
+	t.Cleanup(table.Close)
+
+	tokens := makeTokens(32)
+	parent := int64(1001)
+	events := []BlockStored{
+		{BlockHashes: []int64{1001}, Tokens: [][]byte{tokens[:16]}, ModelName: testModelName, LoraID: -1, SourcePod: testPod1Name},
+		{BlockHashes: []int64{1002}, ParentBlockHash: &parent, Tokens: [][]byte{tokens[16:]}, ModelName: testModelName, LoraID: -1, SourcePod: testPod1Name},
+		{BlockHashes: []int64{1001}, Tokens: [][]byte{tokens[:16]}, ModelName: testModelName, LoraID: -1, SourcePod: "pod2"},
+	}
+	for _, event := range events {
+		if err := table.ProcessBlockStored(event); err != nil {
+			t.Fatalf("failed to store blocks: %v", err)
+		}
+	}
+	return table
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	table := newSnapshotTestTable(t)
+	tokens := makeTokens(32)
+	allPods := map[string]struct{}{testPod1Name: {}, "pod2": {}}
+	expectedMatch, expectedHashes := table.MatchPrefix(testModelName, -1, tokens, allPods)
+
+	restored := NewSyncPrefixHashTable()
+	defer restored.Close()
+	if err := restored.Restore(table.Snapshot(), allPods); err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+
+	match, hashes := restored.MatchPrefix(testModelName, -1, tokens, allPods)
+	if !reflect.DeepEqual(expectedHashes, hashes) {
+		t.Errorf("expected prefix hashes %v, got %v", expectedHashes, hashes)
+	}
+	if !reflect.DeepEqual(expectedMatch, match) {
+		t.Errorf("expected match %v, got %v", expectedMatch, match)
+	}
+
+	// Engine hash mappings are restored, so events keep working after the restore
+	if err := restored.ProcessBlockRemoved(BlockRemoved{BlockHashes: []int64{1002}, ModelName: testModelName, LoraID: -1}); err != nil {
+		t.Fatalf("failed to remove blocks: %v", err)
+	}
+	match, _ = restored.MatchPrefix(testModelName, -1, tokens, allPods)
+	if !reflect.DeepEqual(map[string]int{testPod1Name: 50, "pod2": 50}, match) {
+		t.Errorf("unexpected match after removal: %v", match)
+	}
+}
+
+func TestRestoreDropsDeadPods(t *testing.T) {
+	table := newSnapshotTestTable(t)
+
+	restored := NewSyncPrefixHashTable()
+	defer restored.Close()
+	if err := restored.Restore(table.Snapshot(), map[string]struct{}{"pod2": {}}); err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+
+	contexts := restored.Snapshot().Contexts
+	if len(contexts) != 1 {
+		t.Fatalf("expected 1 context, got %d", len(contexts))
+	}
+	// Only the first block is cached by pod2, the second block and its mapping are dropped
+	if len(contexts[0].Prefixes) != 1 || !reflect.DeepEqual([]string{"pod2"}, contexts[0].Prefixes[0].Pods) {
+		t.Errorf("unexpected prefixes %v", contexts[0].Prefixes)
+	}
+	if _, exists := contexts[0].EngineHashes[1002]; exists || len(contexts[0].EngineHashes) != 1 {
+		t.Errorf("unexpected engine hashes %v", contexts[0].EngineHashes)
+	}
+
+	// Contexts without live pods are not restored
+	empty := NewSyncPrefixHashTable()
+	defer empty.Close()
+	if err := empty.Restore(table.Snapshot(), nil); err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+	if count := empty.contextCount.Load(); count != 0 {
+		t.Errorf("expected no context, got %d", count)
+	}
+
+	if err := empty.Restore(&Snapshot{Version: snapshotVersion + 1}, nil); err == nil {
+		t.Error("expected error for unsupported snapshot version")
+	}
+}
+
+func TestSnapshotStores(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	stores := map[string]SnapshotStore{
+		"file":  NewFileSnapshotStore(filepath.Join(t.TempDir(), "snapshot.json")),
+		"redis": NewRedisSnapshotStore(client, "aibrix:kv_sync_snapshot"),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			snapshot, err := store.Load(ctx)
+			if err != nil || snapshot != nil {
+				t.Fatalf("expected no snapshot, got %v, %v", snapshot, err)
+			}
+
+			expected := newSnapshotTestTable(t).Snapshot()
+			expected.Pods = map[string]PodState{testPod1Name: {UID: "uid-1", LastSequence: 42}}
+			if err := store.Save(ctx, expected); err != nil {
+				t.Fatalf("failed to save: %v", err)
+			}
+
+			snapshot, err = store.Load(ctx)
+			if err != nil {
+				t.Fatalf("failed to load: %v", err)
+			}
+			if !snapshot.CreatedAt.Equal(expected.CreatedAt) {
+				t.Errorf("expected created at %v, got %v", expected.CreatedAt, snapshot.CreatedAt)
+			}
+			snapshot.CreatedAt = expected.CreatedAt
+			if !reflect.DeepEqual(expected, snapshot) {
+				t.Errorf("expected %+v, got %+v", expected, snapshot)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// HuggingFaceTokenizerConfig represents configuration for a local Hugging Face tokenizer
+type HuggingFaceTokenizerConfig struct {
+	Path string // Path of the tokenizer.json file
+}
+
+// hfTokenizerFile is the subset of the Hugging Face tokenizer.json format used for encoding and decoding.
+// The pipeline components are decoded by their "type" field.
+type hfTokenizerFile struct {
+	AddedTokens   []hfAddedToken  `json:"added_tokens"`
+	Normalizer    json.RawMessage `json:"normalizer"`
+	PreTokenizer  json.RawMessage `json:"pre_tokenizer"`
+	PostProcessor json.RawMessage `json:"post_processor"`
+	Decoder       json.RawMessage `json:"decoder"`
+	Model         json.RawMessage `json:"model"`
+}
+
+type hfAddedToken struct {
+	ID      int    `json:"id"`
+	Content string `json:"content"`
+	Special bool   `json:"special"`
+	LStrip  bool   `json:"lstrip"`
+	RStrip  bool   `json:"rstrip"`
+}
+
+// huggingFaceTokenizer implements local tokenization from a Hugging Face tokenizer.json file.
+// It supports BPE models, the common normalizers, pre-tokenizers, post-processors and decoders,
+// and added tokens, which cover the tokenizers of Llama, Qwen and Mistral models.
+type huggingFaceTokenizer struct {
+	model         *bpeModel
+	normalizer    hfNormalizer
+	preTokenizer  hfPreTokenizer
+	postProcessor hfPostProcessor
+	decoder       hfDecoder
+
+	addedTokens       map[byte][]hfAddedToken // first byte of the content -> tokens, longest first
+	addedTokensByID   map[int]hfAddedToken
+	specialTokenIDs   map[int]struct{}
+	addedTokenStrings map[string]int
+}
+
+// NewHuggingFaceTokenizer creates a tokenizer from a tokenizer.json file, such as one mounted from a ConfigMap
+func NewHuggingFaceTokenizer(config HuggingFaceTokenizerConfig) (Tokenizer, error) {
+	if config.Path == "" {
+		return nil, ErrInvalidConfig{Message: "tokenizer path cannot be empty"}
+	}
+	data, err := os.ReadFile(config.Path)
+	if err != nil {
+		return nil, ErrInvalidConfig{Message: fmt.Sprintf("failed to read tokenizer file %s: %v", config.Path, err)}
+	}
+	return newHuggingFaceTokenizerFromBytes(data)
+}
+
+func newHuggingFaceTokenizerFromBytes(data []byte) (*huggingFaceTokenizer, error) {
+	var file hfTokenizerFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, ErrInvalidConfig{Message: fmt.Sprintf("failed to parse tokenizer file: %v", err)}
+	}
+
+	t := &huggingFaceTokenizer{
+		addedTokens:       map[byte][]hfAddedToken{},
+		addedTokensByID:   map[int]hfAddedToken{},
+		specialTokenIDs:   map[int]struct{}{},
+		addedTokenStrings: map[string]int{},
+	}
+	var err error
+	if t.model, err = newBPEModel(file.Model); err != nil {
+		return nil, err
+	}
+	if t.normalizer, err = newHFNormalizer(file.Normalizer); err != nil {
+		return nil, err
+	}
+	if t.preTokenizer, err = newHFPreTokenizer(file.PreTokenizer); err != nil {
+		return nil, err
+	}
+	if t.postProcessor, err = newHFPostProcessor(file.PostProcessor); err != nil {
+		return nil, err
+	}
+	if t.decoder, err = newHFDecoder(file.Decoder); err != nil {
+		return nil, err
+	}
+
+	for _, token := range file.AddedTokens {
+		if token.Content == "" {
+			continue
+		}
+		t.addedTokens[token.Content[0]] = append(t.addedTokens[token.Content[0]], token)
+		t.addedTokensByID[token.ID] = token
+		t.addedTokenStrings[token.Content] = token.ID
+		if token.Special {
+			t.specialTokenIDs[token.ID] = struct{}{}
+		}
+	}
+	for _, tokens := range t.addedTokens {
+		// Match the longest added token first
+		sort.SliceStable(tokens, func(i, j int) bool { return len(tokens[i].Content) > len(tokens[j].Content) })
+	}
+	return t, nil
+}
+
+func (t *huggingFaceTokenizer) TokenizeInputText(text string) ([]byte, error) {
+	return intToByteArray(t.Encode(text, true)), nil
+}
+
+// Encode returns the token ids of the text, with the special tokens of the post-processor if addSpecialTokens is set.
+func (t *huggingFaceTokenizer) Encode(text string, addSpecialTokens bool) []int {
+	var ids []int
+	for _, segment := range t.splitAddedTokens(text) {
+		if segment.addedID >= 0 {
+			ids = append(ids, segment.addedID)
+			continue
+		}
+
+		normalized := segment.text
+		if t.normalizer != nil {
+			normalized = t.normalizer.normalize(normalized)
+		}
+		if normalized == "" {
+			continue
+		}
+		pieces := []hfPiece{{text: normalized, first: segment.first}}
+		if t.preTokenizer != nil {
+			pieces = t.preTokenizer.preTokenize(pieces)
+		}
+		for _, piece := range pieces {
+			if piece.text != "" {
+				ids = append(ids, t.model.tokenize(piece.text)...)
+			}
+		}
+	}
+
+	if addSpecialTokens && t.postProcessor != nil {
+		ids = t.postProcessor.process(ids)
+	}
+	return ids
+}
+
+// Decode returns the text of the token ids, special tokens are skipped if skipSpecialTokens is set.
+func (t *huggingFaceTokenizer) Decode(ids []int, skipSpecialTokens bool) (string, error) {
+	tokens := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := t.specialTokenIDs[id]; ok && skipSpecialTokens {
+			continue
+		}
+		if added, ok := t.addedTokensByID[id]; ok {
+			tokens = append(tokens, added.Content)
+			continue
+		}
+		token, ok := t.model.idToToken(id)
+		if !ok {
+			return "", ErrDetokenizationFailed{Message: fmt.Sprintf("unknown token id %d", id)}
+		}
+		tokens = append(tokens, token)
+	}
+
+	if t.decoder != nil {
+		tokens = t.decoder.decode(tokens)
+	}
+	return strings.Join(tokens, ""), nil
+}
+
+// TokenizeWithOptions tokenizes completion inputs, chat inputs require a chat template which is not supported locally
+func (t *huggingFaceTokenizer) TokenizeWithOptions(ctx context.Context, input TokenizeInput) (*TokenizeResult, error) {
+	if input.Type != CompletionInput {
+		return nil, ErrUnsupportedOperation{Engine: "huggingface", Operation: fmt.Sprintf("tokenize %s input", input.Type)}
+	}
+
+	ids := t.Encode(input.Text, input.AddSpecialTokens)
+	result := &TokenizeResult{
+		Count:  len(ids),
+		Tokens: ids,
+	}
+	if input.ReturnTokenStrings {
+		result.TokenStrings = make([]string, len(ids))
+		for i, id := range ids {
+			if added, ok := t.addedTokensByID[id]; ok {
+				result.TokenStrings[i] = added.Content
+			} else {
+				result.TokenStrings[i], _ = t.model.idToToken(id)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (t *huggingFaceTokenizer) Detokenize(ctx context.Context, tokens []int) (string, error) {
+	return t.Decode(tokens, true)
+}
+
+// hfSegment is a part of the input text, either an added token or a text to tokenize with the model.
+type hfSegment struct {
+	text    string
+	addedID int
+	first   bool // The segment starts at the beginning of the input
+}
+
+// splitAddedTokens splits the text on the added tokens, matching the longest added token first.
+func (t *huggingFaceTokenizer) splitAddedTokens(text string) []hfSegment {
+	if len(t.addedTokens) == 0 {
+		return []hfSegment{{text: text, addedID: -1, first: true}}
+	}
+
+	var segments []hfSegment
+	start := 0
+	for i := 0; i < len(text); {
+		token, ok := t.matchAddedToken(text[i:])
+		if !ok {
+			i++
+			continue
+		}
+
+		end := i
+		if token.LStrip {
+			end = len(strings.TrimRightFunc(text[:i], unicode.IsSpace))
+			end = max(end, start)
+		}
+		if end > start {
+			segments = append(segments, hfSegment{text: text[start:end], addedID: -1, first: start == 0})
+		}
+		segments = append(segments, hfSegment{addedID: token.ID, first: i == 0})
+
+		i += len(token.Content)
+		if token.RStrip {
+			i = len(text) - len(strings.TrimLeftFunc(text[i:], unicode.IsSpace))
+		}
+		start = i
+	}
+	if start < len(text) {
+		segments = append(segments, hfSegment{text: text[start:], addedID: -1, first: start == 0})
+	}
+	return segments
+}
+
+func (t *huggingFaceTokenizer) matchAddedToken(text string) (hfAddedToken, bool) {
+	for _, token := range t.addedTokens[text[0]] {
+		if strings.HasPrefix(text, token.Content) {
+			return token, true
+		}
+	}
+	return hfAddedToken{}, false
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// bpeCacheCapacity is the max number of words whose tokens are cached, words are not cached once it is reached.
+const bpeCacheCapacity = 10000
+
+type bpeModelConfig struct {
+	Type                    string            `json:"type"`
+	UnkToken                *string           `json:"unk_token"`
+	ContinuingSubwordPrefix *string           `json:"continuing_subword_prefix"`
+	EndOfWordSuffix         *string           `json:"end_of_word_suffix"`
+	FuseUnk                 bool              `json:"fuse_unk"`
+	ByteFallback            bool              `json:"byte_fallback"`
+	IgnoreMerges            bool              `json:"ignore_merges"`
+	Vocab                   map[string]int    `json:"vocab"`
+	Merges                  []json.RawMessage `json:"merges"`
+}
+
+type bpePair struct {
+	left, right int
+}
+
+type bpeMerge struct {
+	rank int
+	id   int
+}
+
+// bpeModel implements the BPE model of Hugging Face tokenizers.
+type bpeModel struct {
+	vocab                   map[string]int
+	vocabR                  map[int]string
+	merges                  map[bpePair]bpeMerge
+	unkID                   int // -1 without unknown token
+	continuingSubwordPrefix string
+	endOfWordSuffix         string
+	fuseUnk                 bool
+	byteFallback            bool
+	ignoreMerges            bool
+
+	cacheMu sync.RWMutex
+	cache   map[string][]int
+}
+
+func newBPEModel(data json.RawMessage) (*bpeModel, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidConfig{Message: "tokenizer model is missing"}
+	}
+	var config bpeModelConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, ErrInvalidConfig{Message: fmt.Sprintf("failed to parse tokenizer model: %v", err)}
+	}
+	if config.Type != "BPE" && (config.Type != "" || config.Merges == nil) {
+		return nil, ErrInvalidConfig{Message: fmt.Sprintf("unsupported tokenizer model type %q", config.Type)}
+	}
+
+	m := &bpeModel{
+		vocab:        config.Vocab,
+		vocabR:       make(map[int]string, len(config.Vocab)),
+		merges:       make(map[bpePair]bpeMerge, len(config.Merges)),
+		unkID:        -1,
+		fuseUnk:      config.FuseUnk,
+		byteFallback: config.ByteFallback,
+		ignoreMerges: config.IgnoreMerges,
+		cache:        map[string][]int{},
+	}
+	for token, id := range config.Vocab {
+		m.vocabR[id] = token
+	}
+	if config.UnkToken != nil {
+		id, ok := m.vocab[*config.UnkToken]
+		if !ok {
+			return nil, ErrInvalidConfig{Message: fmt.Sprintf("unknown token %q is not in the vocabulary", *config.UnkToken)}
+		}
+		m.unkID = id
+	}
+	if config.ContinuingSubwordPrefix != nil {
+		m.continuingSubwordPrefix = *config.ContinuingSubwordPrefix
+	}
+	if config.EndOfWordSuffix != nil {
+		m.endOfWordSuffix = *config.EndOfWordSuffix
+	}
+
+	for rank, raw := range config.Merges {
+		left, right, err := parseBPEMerge(raw)
+		if err != nil {
+			return nil, err
+		}
+		leftID, ok := m.vocab[left]
+		if !ok {
+			return nil, ErrInvalidConfig{Message: fmt.Sprintf("merge %d: token %q is not in the vocabulary", rank, left)}
+		}
+		rightID, ok := m.vocab[right]
+		if !ok {
+			return nil, ErrInvalidConfig{Message: fmt.Sprintf("merge %d: token %q is not in the vocabulary", rank, right)}
+		}
+		merged := left + strings.TrimPrefix(right, m.continuingSubwordPrefix)
+		id, ok := m.vocab[merged]
+		if !ok {
+			return nil, ErrInvalidConfig{Message: fmt.Sprintf("merge %d: token %q is not in the vocabulary", rank, merged)}
+		}
+		m.merges[bpePair{left: leftID, right: rightID}] = bpeMerge{rank: rank, id: id}
+	}
+	return m, nil
+}
+
+// parseBPEMerge parses a merge, either a "left right" string or a ["left", "right"] pair.
+func parseBPEMerge(raw json.RawMessage) (string, string, error) {
+	var merge string
+	if err := json.Unmarshal(raw, &merge); err == nil {
+		left, right, ok := strings.Cut(merge, " ")
+		if !ok {
+			return "", "", ErrInvalidConfig{Message: fmt.Sprintf("invalid merge %q", merge)}
+		}
+		return left, right, nil
+	}
+
+	var pair []string
+	if err := json.Unmarshal(raw, &pair); err != nil || len(pair) != 2 {
+		return "", "", ErrInvalidConfig{Message: fmt.Sprintf("invalid merge %s", string(raw))}
+	}
+	return pair[0], pair[1], nil
+}
+
+func (m *bpeModel) idToToken(id int) (string, bool) {
+	token, ok := m.vocabR[id]
+	return token, ok
+}
+
+// tokenize returns the token ids of a pre-tokenized word.
+func (m *bpeModel) tokenize(word string) []int {
+	if m.ignoreMerges {
+		if id, ok := m.vocab[word]; ok {
+			return []int{id}
+		}
+	}
+
+	m.cacheMu.RLock()
+	ids, ok := m.cache[word]
+	m.cacheMu.RUnlock()
+	if ok {
+		return ids
+	}
+
+	ids = m.mergeWord(word)
+	m.cacheMu.Lock()
+	if len(m.cache) < bpeCacheCapacity {
+		m.cache[word] = ids
+	}
+	m.cacheMu.Unlock()
+	return ids
+}
+
+// mergeWord splits the word into characters, then applies the merge of the lowest rank until none applies.
+func (m *bpeModel) mergeWord(word string) []int {
+	runes := []rune(word)
+	symbols := make([]int, 0, len(runes))
+	unk := false
+	for i, r := range runes {
+		token := string(r)
+		if i > 0 {
+			token = m.continuingSubwordPrefix + token
+		}
+		if i == len(runes)-1 {
+			token += m.endOfWordSuffix
+		}
+
+		if id, ok := m.vocab[token]; ok {
+			symbols = append(symbols, id)
+			unk = false
+			continue
+		}
+		if m.byteFallback {
+			if ids, ok := m.byteFallbackIDs(string(r)); ok {
+				symbols = append(symbols, ids...)
+				unk = false
+				continue
+			}
+		}
+		if m.unkID >= 0 && !(m.fuseUnk && unk) {
+			symbols = append(symbols, m.unkID)
+		}
+		unk = m.unkID >= 0
+	}
+
+	for len(symbols) > 1 {
+		best := -1
+		var bestMerge bpeMerge
+		for i := 0; i < len(symbols)-1; i++ {
+			merge, ok := m.merges[bpePair{left: symbols[i], right: symbols[i+1]}]
+			if ok && (best < 0 || merge.rank < bestMerge.rank) {
+				best, bestMerge = i, merge
+			}
+		}
+		if best < 0 {
+			break
+		}
+		symbols[best] = bestMerge.id
+		symbols = append(symbols[:best+1], symbols[best+2:]...)
+	}
+	return symbols
+}
+
+// byteFallbackIDs returns the ids of the <0xXX> tokens of each byte of the character.
+func (m *bpeModel) byteFallbackIDs(char string) ([]int, bool) {
+	ids := make([]int, 0, len(char))
+	for i := 0; i < len(char); i++ {
+		id, ok := m.vocab[fmt.Sprintf("<0x%02X>", char[i])]
+		if !ok {
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+	return ids, true
+}
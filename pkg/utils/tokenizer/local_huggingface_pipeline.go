@@ -0,0 +1,886 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dlclark/regexp2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// gpt2Pattern is the pre-tokenization pattern of the ByteLevel pre-tokenizer.
+const gpt2Pattern = `'s|'t|'re|'ve|'m|'ll|'d| ?\p{L}+| ?\p{N}+| ?[^\s\p{L}\p{N}]+|\s+(?!\S)|\s+`
+
+// hfComponent holds the type and the raw configuration of a pipeline component.
+type hfComponent struct {
+	Type string `json:"type"`
+	raw  json.RawMessage
+}
+
+func parseHFComponent(kind string, data json.RawMessage) (*hfComponent, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	c := &hfComponent{raw: data}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, ErrInvalidConfig{Message: fmt.Sprintf("failed to parse %s: %v", kind, err)}
+	}
+	return c, nil
+}
+
+func (c *hfComponent) decode(kind string, v interface{}) error {
+	if err := json.Unmarshal(c.raw, v); err != nil {
+		return ErrInvalidConfig{Message: fmt.Sprintf("failed to parse %s %s: %v", kind, c.Type, err)}
+	}
+	return nil
+}
+
+func (c *hfComponent) unsupported(kind string) error {
+	return ErrInvalidConfig{Message: fmt.Sprintf("unsupported %s type %q", kind, c.Type)}
+}
+
+// hfPattern is the pattern of the Split pre-tokenizer and of the Replace normalizer and decoder.
+type hfPattern struct {
+	String *string `json:"String"`
+	Regex  *string `json:"Regex"`
+}
+
+func (p hfPattern) compile() (*regexp2.Regexp, error) {
+	expr := ""
+	switch {
+	case p.String != nil:
+		expr = regexp2.Escape(*p.String)
+	case p.Regex != nil:
+		expr = *p.Regex
+	default:
+		return nil, ErrInvalidConfig{Message: "pattern must be a String or a Regex"}
+	}
+	re, err := regexp2.Compile(expr, regexp2.None)
+	if err != nil {
+		return nil, ErrInvalidConfig{Message: fmt.Sprintf("invalid pattern %q: %v", expr, err)}
+	}
+	return re, nil
+}
+
+// hfMatch is a range of runes of a text, which matches a pattern or not.
+type hfMatch struct {
+	start, end int
+	match      bool
+}
+
+// findMatches splits the runes into the ranges matching the regex and the ranges in between.
+func findMatches(re *regexp2.Regexp, runes []rune) []hfMatch {
+	var matches []hfMatch
+	prev := 0
+	m, _ := re.FindRunesMatch(runes)
+	for m != nil {
+		if m.Length > 0 {
+			if m.Index > prev {
+				matches = append(matches, hfMatch{start: prev, end: m.Index})
+			}
+			matches = append(matches, hfMatch{start: m.Index, end: m.Index + m.Length, match: true})
+			prev = m.Index + m.Length
+		}
+		m, _ = re.FindNextMatch(m)
+	}
+	if prev < len(runes) {
+		matches = append(matches, hfMatch{start: prev, end: len(runes)})
+	}
+	return matches
+}
+
+// findRuneMatches splits the runes into the ranges of runes satisfying f and the ranges in between,
+// each rune satisfying f is a range of its own.
+func findRuneMatches(f func(rune) bool, runes []rune) []hfMatch {
+	var matches []hfMatch
+	prev := 0
+	for i, r := range runes {
+		if !f(r) {
+			continue
+		}
+		if i > prev {
+			matches = append(matches, hfMatch{start: prev, end: i})
+		}
+		matches = append(matches, hfMatch{start: i, end: i + 1, match: true})
+		prev = i + 1
+	}
+	if prev < len(runes) {
+		matches = append(matches, hfMatch{start: prev, end: len(runes)})
+	}
+	return matches
+}
+
+// splitBehavior is what to do with the matches of a pattern when splitting a text.
+type splitBehavior string
+
+const (
+	splitRemoved            splitBehavior = "Removed"
+	splitIsolated           splitBehavior = "Isolated"
+	splitMergedWithPrevious splitBehavior = "MergedWithPrevious"
+	splitMergedWithNext     splitBehavior = "MergedWithNext"
+	splitContiguous         splitBehavior = "Contiguous"
+)
+
+// split returns the ranges of the split text according to the behavior.
+func (b splitBehavior) split(matches []hfMatch) ([]hfMatch, error) {
+	var result []hfMatch
+	previousMatch := false
+	switch b {
+	case splitRemoved:
+		for _, m := range matches {
+			if !m.match {
+				result = append(result, m)
+			}
+		}
+	case splitIsolated:
+		result = matches
+	case splitMergedWithPrevious:
+		for _, m := range matches {
+			if m.match && !previousMatch && len(result) > 0 {
+				result[len(result)-1].end = m.end
+			} else {
+				result = append(result, m)
+			}
+			previousMatch = m.match
+		}
+	case splitMergedWithNext:
+		for i := len(matches) - 1; i >= 0; i-- {
+			m := matches[i]
+			if m.match && !previousMatch && len(result) > 0 {
+				result[len(result)-1].start = m.start
+			} else {
+				result = append(result, m)
+			}
+			previousMatch = m.match
+		}
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+	case splitContiguous:
+		for i, m := range matches {
+			if i > 0 && m.match == previousMatch {
+				result[len(result)-1].end = m.end
+			} else {
+				result = append(result, m)
+			}
+			previousMatch = m.match
+		}
+	default:
+		return nil, ErrInvalidConfig{Message: fmt.Sprintf("unsupported split behavior %q", b)}
+	}
+	return result, nil
+}
+
+// hfNormalizer normalizes the text before pre-tokenization.
+type hfNormalizer interface {
+	normalize(text string) string
+}
+
+type normalizerFunc func(string) string
+
+func (f normalizerFunc) normalize(text string) string {
+	return f(text)
+}
+
+type normalizerSequence []hfNormalizer
+
+func (s normalizerSequence) normalize(text string) string {
+	for _, n := range s {
+		text = n.normalize(text)
+	}
+	return text
+}
+
+func newHFNormalizer(data json.RawMessage) (hfNormalizer, error) {
+	const kind = "normalizer"
+	c, err := parseHFComponent(kind, data)
+	if c == nil || err != nil {
+		return nil, err
+	}
+
+	switch c.Type {
+	case "NFC":
+		return normalizerFunc(norm.NFC.String), nil
+	case "NFD":
+		return normalizerFunc(norm.NFD.String), nil
+	case "NFKC":
+		return normalizerFunc(norm.NFKC.String), nil
+	case "NFKD":
+		return normalizerFunc(norm.NFKD.String), nil
+	case "Lowercase":
+		return normalizerFunc(strings.ToLower), nil
+	case "StripAccents":
+		return normalizerFunc(func(text string) string {
+			return strings.Map(func(r rune) rune {
+				if unicode.Is(unicode.Mn, r) {
+					return -1
+				}
+				return r
+			}, text)
+		}), nil
+	case "Strip":
+		var config struct {
+			Left  bool `json:"strip_left"`
+			Right bool `json:"strip_right"`
+		}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		return normalizerFunc(func(text string) string {
+			if config.Left {
+				text = strings.TrimLeftFunc(text, unicode.IsSpace)
+			}
+			if config.Right {
+				text = strings.TrimRightFunc(text, unicode.IsSpace)
+			}
+			return text
+		}), nil
+	case "Prepend":
+		var config struct {
+			Prepend string `json:"prepend"`
+		}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		return normalizerFunc(func(text string) string {
+			if text == "" {
+				return text
+			}
+			return config.Prepend + text
+		}), nil
+	case "Replace":
+		replace, err := newReplace(c, kind)
+		if err != nil {
+			return nil, err
+		}
+		return normalizerFunc(replace), nil
+	case "Sequence":
+		var config struct {
+			Normalizers []json.RawMessage `json:"normalizers"`
+		}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		var seq normalizerSequence
+		for _, raw := range config.Normalizers {
+			n, err := newHFNormalizer(raw)
+			if err != nil {
+				return nil, err
+			}
+			if n != nil {
+				seq = append(seq, n)
+			}
+		}
+		return seq, nil
+	default:
+		return nil, c.unsupported(kind)
+	}
+}
+
+// newReplace returns the function replacing the pattern of a Replace normalizer or decoder by its content.
+func newReplace(c *hfComponent, kind string) (func(string) string, error) {
+	var config struct {
+		Pattern hfPattern `json:"pattern"`
+		Content string    `json:"content"`
+	}
+	if err := c.decode(kind, &config); err != nil {
+		return nil, err
+	}
+	if config.Pattern.String != nil {
+		return func(text string) string {
+			return strings.ReplaceAll(text, *config.Pattern.String, config.Content)
+		}, nil
+	}
+
+	re, err := config.Pattern.compile()
+	if err != nil {
+		return nil, err
+	}
+	// Replace literally, the content is not an expansion template
+	content := strings.ReplaceAll(config.Content, "$", "$$")
+	return func(text string) string {
+		replaced, err := re.Replace(text, content, -1, -1)
+		if err != nil {
+			return text
+		}
+		return replaced
+	}, nil
+}
+
+// hfPiece is a piece of pre-tokenized text.
+type hfPiece struct {
+	text  string
+	first bool // The piece starts at the beginning of the input
+}
+
+// hfPreTokenizer splits the normalized text into the pieces tokenized by the model.
+type hfPreTokenizer interface {
+	preTokenize(pieces []hfPiece) []hfPiece
+}
+
+type preTokenizerFunc func([]hfPiece) []hfPiece
+
+func (f preTokenizerFunc) preTokenize(pieces []hfPiece) []hfPiece {
+	return f(pieces)
+}
+
+type preTokenizerSequence []hfPreTokenizer
+
+func (s preTokenizerSequence) preTokenize(pieces []hfPiece) []hfPiece {
+	for _, p := range s {
+		pieces = p.preTokenize(pieces)
+	}
+	return pieces
+}
+
+// splitPieces splits each piece into the ranges returned by split, dropping empty ranges.
+func splitPieces(pieces []hfPiece, split func(piece hfPiece, runes []rune) []hfMatch) []hfPiece {
+	result := make([]hfPiece, 0, len(pieces))
+	for _, piece := range pieces {
+		runes := []rune(piece.text)
+		for _, m := range split(piece, runes) {
+			if m.end > m.start {
+				result = append(result, hfPiece{text: string(runes[m.start:m.end]), first: piece.first && m.start == 0})
+			}
+		}
+	}
+	return result
+}
+
+// newSplitPreTokenizer returns a pre-tokenizer splitting pieces on the matches of find.
+func newSplitPreTokenizer(find func([]rune) []hfMatch, behavior splitBehavior, invert bool) (hfPreTokenizer, error) {
+	if _, err := behavior.split(nil); err != nil {
+		return nil, err
+	}
+	return preTokenizerFunc(func(pieces []hfPiece) []hfPiece {
+		return splitPieces(pieces, func(_ hfPiece, runes []rune) []hfMatch {
+			matches := find(runes)
+			if invert {
+				for i := range matches {
+					matches[i].match = !matches[i].match
+				}
+			}
+			matches, _ = behavior.split(matches)
+			return matches
+		})
+	}), nil
+}
+
+func regexFinder(re *regexp2.Regexp) func([]rune) []hfMatch {
+	return func(runes []rune) []hfMatch {
+		return findMatches(re, runes)
+	}
+}
+
+func runeFinder(f func(rune) bool) func([]rune) []hfMatch {
+	return func(runes []rune) []hfMatch {
+		return findRuneMatches(f, runes)
+	}
+}
+
+// isPunctuation reports whether the rune is a punctuation, including the ascii symbols such as '$' or '+'.
+func isPunctuation(r rune) bool {
+	return unicode.IsPunct(r) || r < utf8.RuneSelf && unicode.IsSymbol(r)
+}
+
+func newHFPreTokenizer(data json.RawMessage) (hfPreTokenizer, error) {
+	const kind = "pre-tokenizer"
+	c, err := parseHFComponent(kind, data)
+	if c == nil || err != nil {
+		return nil, err
+	}
+
+	switch c.Type {
+	case "ByteLevel":
+		config := struct {
+			AddPrefixSpace bool `json:"add_prefix_space"`
+			UseRegex       bool `json:"use_regex"`
+		}{UseRegex: true}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		return newByteLevelPreTokenizer(config.AddPrefixSpace, config.UseRegex), nil
+	case "Metaspace":
+		metaspace, err := newMetaspace(c, kind)
+		if err != nil {
+			return nil, err
+		}
+		return metaspace, nil
+	case "Split":
+		var config struct {
+			Pattern  hfPattern     `json:"pattern"`
+			Behavior splitBehavior `json:"behavior"`
+			Invert   bool          `json:"invert"`
+		}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		re, err := config.Pattern.compile()
+		if err != nil {
+			return nil, err
+		}
+		return newSplitPreTokenizer(regexFinder(re), config.Behavior, config.Invert)
+	case "Whitespace":
+		re := regexp2.MustCompile(`\w+|[^\w\s]+`, regexp2.None)
+		return newSplitPreTokenizer(regexFinder(re), splitRemoved, true)
+	case "WhitespaceSplit":
+		return newSplitPreTokenizer(runeFinder(unicode.IsSpace), splitRemoved, false)
+	case "Punctuation":
+		config := struct {
+			Behavior splitBehavior `json:"behavior"`
+		}{Behavior: splitIsolated}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		return newSplitPreTokenizer(runeFinder(isPunctuation), config.Behavior, false)
+	case "BertPreTokenizer":
+		whitespace, _ := newSplitPreTokenizer(runeFinder(unicode.IsSpace), splitRemoved, false)
+		punctuation, _ := newSplitPreTokenizer(runeFinder(isPunctuation), splitIsolated, false)
+		return preTokenizerSequence{whitespace, punctuation}, nil
+	case "Digits":
+		var config struct {
+			IndividualDigits bool `json:"individual_digits"`
+		}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		behavior := splitContiguous
+		if config.IndividualDigits {
+			behavior = splitIsolated
+		}
+		return newSplitPreTokenizer(runeFinder(unicode.IsNumber), behavior, false)
+	case "Sequence":
+		var config struct {
+			PreTokenizers []json.RawMessage `json:"pretokenizers"`
+		}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		var seq preTokenizerSequence
+		for _, raw := range config.PreTokenizers {
+			p, err := newHFPreTokenizer(raw)
+			if err != nil {
+				return nil, err
+			}
+			if p != nil {
+				seq = append(seq, p)
+			}
+		}
+		return seq, nil
+	default:
+		return nil, c.unsupported(kind)
+	}
+}
+
+// bytesToUnicode maps each byte to a printable character, as in GPT-2 byte-level BPE.
+var bytesToUnicode, unicodeToBytes = func() ([256]rune, map[rune]byte) {
+	var b2u [256]rune
+	u2b := make(map[rune]byte, 256)
+	n := 0
+	for b := 0; b < 256; b++ {
+		r := rune(b)
+		if !(b >= '!' && b <= '~' || b >= 0xA1 && b <= 0xAC || b >= 0xAE && b <= 0xFF) {
+			r = rune(256 + n)
+			n++
+		}
+		b2u[b] = r
+		u2b[r] = byte(b)
+	}
+	return b2u, u2b
+}()
+
+func newByteLevelPreTokenizer(addPrefixSpace, useRegex bool) hfPreTokenizer {
+	re := regexp2.MustCompile(gpt2Pattern, regexp2.None)
+	return preTokenizerFunc(func(pieces []hfPiece) []hfPiece {
+		if addPrefixSpace {
+			for i := range pieces {
+				if !strings.HasPrefix(pieces[i].text, " ") {
+					pieces[i].text = " " + pieces[i].text
+				}
+			}
+		}
+		if useRegex {
+			pieces = splitPieces(pieces, func(_ hfPiece, runes []rune) []hfMatch {
+				return findMatches(re, runes)
+			})
+		}
+
+		for i := range pieces {
+			var sb strings.Builder
+			for j := 0; j < len(pieces[i].text); j++ {
+				sb.WriteRune(bytesToUnicode[pieces[i].text[j]])
+			}
+			pieces[i].text = sb.String()
+		}
+		return pieces
+	})
+}
+
+type metaspace struct {
+	replacement   string
+	prependScheme string
+	split         bool
+}
+
+func newMetaspace(c *hfComponent, kind string) (*metaspace, error) {
+	var config struct {
+		Replacement    *string `json:"replacement"`
+		PrependScheme  *string `json:"prepend_scheme"`
+		AddPrefixSpace *bool   `json:"add_prefix_space"`
+		Split          *bool   `json:"split"`
+	}
+	if err := c.decode(kind, &config); err != nil {
+		return nil, err
+	}
+
+	m := &metaspace{replacement: "▁", prependScheme: "always", split: true}
+	if config.Replacement != nil {
+		m.replacement = *config.Replacement
+	}
+	if config.Split != nil {
+		m.split = *config.Split
+	}
+	switch {
+	case config.PrependScheme != nil:
+		m.prependScheme = *config.PrependScheme
+	case config.AddPrefixSpace != nil && !*config.AddPrefixSpace:
+		// Legacy configuration without prepend scheme
+		m.prependScheme = "never"
+	}
+	if m.prependScheme != "always" && m.prependScheme != "first" && m.prependScheme != "never" {
+		return nil, ErrInvalidConfig{Message: fmt.Sprintf("unsupported metaspace prepend scheme %q", m.prependScheme)}
+	}
+	return m, nil
+}
+
+func (m *metaspace) preTokenize(pieces []hfPiece) []hfPiece {
+	replacement := []rune(m.replacement)
+	for i := range pieces {
+		text := strings.ReplaceAll(pieces[i].text, " ", m.replacement)
+		if !strings.HasPrefix(text, m.replacement) &&
+			(m.prependScheme == "always" || m.prependScheme == "first" && pieces[i].first) {
+			text = m.replacement + text
+		}
+		pieces[i].text = text
+	}
+	if !m.split || len(replacement) != 1 {
+		return pieces
+	}
+
+	return splitPieces(pieces, func(_ hfPiece, runes []rune) []hfMatch {
+		matches, _ := splitMergedWithNext.split(findRuneMatches(func(r rune) bool { return r == replacement[0] }, runes))
+		return matches
+	})
+}
+
+func (m *metaspace) decode(tokens []string) []string {
+	for i, token := range tokens {
+		if i == 0 && m.prependScheme != "never" {
+			token = strings.TrimPrefix(token, m.replacement)
+		}
+		tokens[i] = strings.ReplaceAll(token, m.replacement, " ")
+	}
+	return tokens
+}
+
+// hfPostProcessor adds the special tokens to the token ids of a single sequence.
+type hfPostProcessor interface {
+	process(ids []int) []int
+}
+
+type postProcessorFunc func([]int) []int
+
+func (f postProcessorFunc) process(ids []int) []int {
+	return f(ids)
+}
+
+type postProcessorSequence []hfPostProcessor
+
+func (s postProcessorSequence) process(ids []int) []int {
+	for _, p := range s {
+		ids = p.process(ids)
+	}
+	return ids
+}
+
+// hfTemplatePiece is a piece of a TemplateProcessing template, either a special token or the sequence.
+type hfTemplatePiece struct {
+	SpecialToken *struct {
+		ID string `json:"id"`
+	} `json:"SpecialToken"`
+	Sequence *struct {
+		ID string `json:"id"`
+	} `json:"Sequence"`
+}
+
+// hfSpecialTokenPair is a [token, id] pair of the Bert and Roberta post-processors.
+type hfSpecialTokenPair struct {
+	Token string
+	ID    int
+}
+
+func (p *hfSpecialTokenPair) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &[]interface{}{&p.Token, &p.ID})
+}
+
+func newHFPostProcessor(data json.RawMessage) (hfPostProcessor, error) {
+	const kind = "post-processor"
+	c, err := parseHFComponent(kind, data)
+	if c == nil || err != nil {
+		return nil, err
+	}
+
+	switch c.Type {
+	case "TemplateProcessing":
+		var config struct {
+			Single        []hfTemplatePiece `json:"single"`
+			SpecialTokens map[string]struct {
+				IDs []int `json:"ids"`
+			} `json:"special_tokens"`
+		}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		for _, piece := range config.Single {
+			if piece.SpecialToken == nil {
+				continue
+			}
+			if _, ok := config.SpecialTokens[piece.SpecialToken.ID]; !ok {
+				return nil, ErrInvalidConfig{Message: fmt.Sprintf("special token %q of the template is missing", piece.SpecialToken.ID)}
+			}
+		}
+		return postProcessorFunc(func(ids []int) []int {
+			result := make([]int, 0, len(ids)+len(config.Single))
+			for _, piece := range config.Single {
+				if piece.SpecialToken != nil {
+					result = append(result, config.SpecialTokens[piece.SpecialToken.ID].IDs...)
+				} else {
+					result = append(result, ids...)
+				}
+			}
+			return result
+		}), nil
+	case "BertProcessing", "RobertaProcessing":
+		var config struct {
+			Sep hfSpecialTokenPair `json:"sep"`
+			Cls hfSpecialTokenPair `json:"cls"`
+		}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		return postProcessorFunc(func(ids []int) []int {
+			result := make([]int, 0, len(ids)+2)
+			result = append(result, config.Cls.ID)
+			result = append(result, ids...)
+			return append(result, config.Sep.ID)
+		}), nil
+	case "ByteLevel":
+		// Only trims the offsets, the ids are unchanged
+		return postProcessorSequence{}, nil
+	case "Sequence":
+		var config struct {
+			Processors []json.RawMessage `json:"processors"`
+		}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		var seq postProcessorSequence
+		for _, raw := range config.Processors {
+			p, err := newHFPostProcessor(raw)
+			if err != nil {
+				return nil, err
+			}
+			if p != nil {
+				seq = append(seq, p)
+			}
+		}
+		return seq, nil
+	default:
+		return nil, c.unsupported(kind)
+	}
+}
+
+// hfDecoder converts the tokens back to the strings joined into the decoded text.
+type hfDecoder interface {
+	decode(tokens []string) []string
+}
+
+type decoderFunc func([]string) []string
+
+func (f decoderFunc) decode(tokens []string) []string {
+	return f(tokens)
+}
+
+type decoderSequence []hfDecoder
+
+func (s decoderSequence) decode(tokens []string) []string {
+	for _, d := range s {
+		tokens = d.decode(tokens)
+	}
+	return tokens
+}
+
+func newHFDecoder(data json.RawMessage) (hfDecoder, error) {
+	const kind = "decoder"
+	c, err := parseHFComponent(kind, data)
+	if c == nil || err != nil {
+		return nil, err
+	}
+
+	switch c.Type {
+	case "ByteLevel":
+		return decoderFunc(decodeByteLevel), nil
+	case "ByteFallback":
+		return decoderFunc(decodeByteFallback), nil
+	case "Fuse":
+		return decoderFunc(func(tokens []string) []string {
+			return []string{strings.Join(tokens, "")}
+		}), nil
+	case "Metaspace":
+		metaspace, err := newMetaspace(c, kind)
+		if err != nil {
+			return nil, err
+		}
+		return metaspace, nil
+	case "Replace":
+		replace, err := newReplace(c, kind)
+		if err != nil {
+			return nil, err
+		}
+		return decoderFunc(func(tokens []string) []string {
+			for i := range tokens {
+				tokens[i] = replace(tokens[i])
+			}
+			return tokens
+		}), nil
+	case "Strip":
+		var config struct {
+			Content string `json:"content"`
+			Start   int    `json:"start"`
+			Stop    int    `json:"stop"`
+		}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		return decoderFunc(func(tokens []string) []string {
+			for i, token := range tokens {
+				for n := 0; n < config.Start && strings.HasPrefix(token, config.Content); n++ {
+					token = strings.TrimPrefix(token, config.Content)
+				}
+				for n := 0; n < config.Stop && strings.HasSuffix(token, config.Content); n++ {
+					token = strings.TrimSuffix(token, config.Content)
+				}
+				tokens[i] = token
+			}
+			return tokens
+		}), nil
+	case "BPEDecoder":
+		config := struct {
+			Suffix string `json:"suffix"`
+		}{Suffix: "</w>"}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		return decoderFunc(func(tokens []string) []string {
+			for i := range tokens {
+				replacement := " "
+				if i == len(tokens)-1 {
+					replacement = ""
+				}
+				tokens[i] = strings.ReplaceAll(tokens[i], config.Suffix, replacement)
+			}
+			return tokens
+		}), nil
+	case "Sequence":
+		var config struct {
+			Decoders []json.RawMessage `json:"decoders"`
+		}
+		if err := c.decode(kind, &config); err != nil {
+			return nil, err
+		}
+		var seq decoderSequence
+		for _, raw := range config.Decoders {
+			d, err := newHFDecoder(raw)
+			if err != nil {
+				return nil, err
+			}
+			if d != nil {
+				seq = append(seq, d)
+			}
+		}
+		return seq, nil
+	default:
+		return nil, c.unsupported(kind)
+	}
+}
+
+// decodeByteLevel maps the characters of the tokens back to bytes, tokens with characters outside of the
+// byte-level alphabet, such as added tokens, are kept as is.
+func decodeByteLevel(tokens []string) []string {
+	var buf []byte
+	for _, token := range tokens {
+		bytes := make([]byte, 0, len(token))
+		for _, r := range token {
+			b, ok := unicodeToBytes[r]
+			if !ok {
+				bytes = []byte(token)
+				break
+			}
+			bytes = append(bytes, b)
+		}
+		buf = append(buf, bytes...)
+	}
+	return []string{strings.ToValidUTF8(string(buf), "�")}
+}
+
+// decodeByteFallback converts the consecutive <0xXX> tokens to the string of their bytes.
+func decodeByteFallback(tokens []string) []string {
+	result := make([]string, 0, len(tokens))
+	var buf []byte
+	flush := func() {
+		if len(buf) == 0 {
+			return
+		}
+		if utf8.Valid(buf) {
+			result = append(result, string(buf))
+		} else {
+			for range buf {
+				result = append(result, "�")
+			}
+		}
+		buf = buf[:0]
+	}
+
+	for _, token := range tokens {
+		if len(token) == 6 && strings.HasPrefix(token, "<0x") && strings.HasSuffix(token, ">") {
+			if b, err := strconv.ParseUint(token[3:5], 16, 8); err == nil {
+				buf = append(buf, byte(b))
+				continue
+			}
+		}
+		flush()
+		result = append(result, token)
+	}
+	flush()
+	return result
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tokenizer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// huggingFaceGoldenCase is a case of a golden file, the ids follow the encoding of the Hugging Face tokenizers library.
+type huggingFaceGoldenCase struct {
+	Text             string  `json:"text"`
+	AddSpecialTokens bool    `json:"add_special_tokens"`
+	IDs              []int   `json:"ids"`
+	Decoded          *string `json:"decoded"` // Defaults to the text
+}
+
+// TestHuggingFaceTokenizerGolden tests the fixtures in testdata against their golden files:
+//   - byte_level_bpe: GPT-2 style byte-level BPE with regex pre-tokenization
+//   - sentencepiece_bpe: Llama 2 style BPE with prepend/replace normalizers and byte fallback
+//   - split_byte_level_bpe: Llama 3 style regex split, ignore merges and template post-processing
+func TestHuggingFaceTokenizerGolden(t *testing.T) {
+	fixtures, err := filepath.Glob("testdata/*.golden.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, fixtures)
+
+	for _, golden := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(golden), ".golden.json")
+		t.Run(name, func(t *testing.T) {
+			tok, err := NewTokenizer("huggingface", HuggingFaceTokenizerConfig{Path: filepath.Join("testdata", name+".json")})
+			require.NoError(t, err)
+			hf := tok.(*huggingFaceTokenizer)
+
+			data, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			var cases []huggingFaceGoldenCase
+			require.NoError(t, json.Unmarshal(data, &cases))
+
+			for _, c := range cases {
+				assert.Equal(t, c.IDs, hf.Encode(c.Text, c.AddSpecialTokens), "encode %q", c.Text)
+
+				decoded, err := hf.Decode(c.IDs, true)
+				assert.NoError(t, err)
+				expected := c.Text
+				if c.Decoded != nil {
+					expected = *c.Decoded
+				}
+				assert.Equal(t, expected, decoded, "decode %v", c.IDs)
+			}
+		})
+	}
+}
+
+func TestHuggingFaceTokenizer(t *testing.T) {
+	tok, err := NewHuggingFaceTokenizer(HuggingFaceTokenizerConfig{Path: "testdata/sentencepiece_bpe.json"})
+	require.NoError(t, err)
+
+	// TokenizeInputText adds the special tokens
+	tokens, err := tok.TokenizeInputText("hello world")
+	assert.NoError(t, err)
+	assert.Equal(t, intToByteArray([]int{1, 271, 276}), tokens)
+
+	extended, ok := tok.(extendedTokenizer)
+	require.True(t, ok)
+	result, err := extended.TokenizeWithOptions(context.Background(), TokenizeInput{
+		Type:               CompletionInput,
+		Text:               "hello world",
+		ReturnTokenStrings: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &TokenizeResult{Count: 2, Tokens: []int{271, 276}, TokenStrings: []string{"▁hello", "▁world"}}, result)
+
+	_, err = extended.TokenizeWithOptions(context.Background(), TokenizeInput{Type: ChatInput})
+	assert.ErrorAs(t, err, &ErrUnsupportedOperation{})
+
+	text, err := extended.Detokenize(context.Background(), []int{1, 271, 276, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", text)
+	_, err = extended.Detokenize(context.Background(), []int{1000})
+	assert.ErrorAs(t, err, &ErrDetokenizationFailed{})
+}
+
+func TestHuggingFaceTokenizerInvalidConfig(t *testing.T) {
+	_, err := NewHuggingFaceTokenizer(HuggingFaceTokenizerConfig{})
+	assert.ErrorAs(t, err, &ErrInvalidConfig{})
+	_, err = NewHuggingFaceTokenizer(HuggingFaceTokenizerConfig{Path: "testdata/missing.json"})
+	assert.ErrorAs(t, err, &ErrInvalidConfig{})
+	_, err = NewTokenizer("huggingface", nil)
+	assert.Error(t, err)
+
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"invalid json", `{`},
+		{"missing model", `{}`},
+		{"unsupported model", `{"model": {"type": "WordPiece", "vocab": {}}}`},
+		{"unknown merge token", `{"model": {"type": "BPE", "vocab": {"a": 0}, "merges": ["a b"]}}`},
+		{"unsupported normalizer", `{"normalizer": {"type": "Precompiled"}, "model": {"type": "BPE", "vocab": {}, "merges": []}}`},
+		{"invalid split behavior", `{"pre_tokenizer": {"type": "Split", "pattern": {"String": " "}, "behavior": "Unknown"}, "model": {"type": "BPE", "vocab": {}, "merges": []}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newHuggingFaceTokenizerFromBytes([]byte(tt.json))
+			assert.ErrorAs(t, err, &ErrInvalidConfig{})
+		})
+	}
+}
+
+func TestSplitBehavior(t *testing.T) {
+	// "a-b--c" split on "-"
+	matches := []hfMatch{{0, 1, false}, {1, 2, true}, {2, 3, false}, {3, 4, true}, {4, 5, true}, {5, 6, false}}
+	tests := []struct {
+		behavior splitBehavior
+		expected []hfMatch
+	}{
+		{splitRemoved, []hfMatch{{0, 1, false}, {2, 3, false}, {5, 6, false}}},
+		{splitIsolated, matches},
+		{splitMergedWithPrevious, []hfMatch{{0, 2, false}, {2, 4, false}, {4, 5, true}, {5, 6, false}}},
+		{splitMergedWithNext, []hfMatch{{0, 1, false}, {1, 3, false}, {3, 4, true}, {4, 6, false}}},
+		{splitContiguous, []hfMatch{{0, 1, false}, {1, 2, true}, {2, 3, false}, {3, 5, true}, {5, 6, false}}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.behavior), func(t *testing.T) {
+			result, err := tt.behavior.split(append([]hfMatch(nil), matches...))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestMetaspacePreTokenizer(t *testing.T) {
+	tests := []struct {
+		config   string
+		expected []string
+	}{
+		{`{"type": "Metaspace", "replacement": "▁", "prepend_scheme": "always", "split": true}`, []string{"▁hello", "▁world", "▁again"}},
+		{`{"type": "Metaspace", "replacement": "▁", "prepend_scheme": "first", "split": false}`, []string{"▁hello▁world", "again"}},
+		{`{"type": "Metaspace", "replacement": "▁", "add_prefix_space": false}`, []string{"hello", "▁world", "again"}},
+	}
+	for _, tt := range tests {
+		p, err := newHFPreTokenizer(json.RawMessage(tt.config))
+		require.NoError(t, err)
+		pieces := p.preTokenize([]hfPiece{{text: "hello world", first: true}, {text: "again"}})
+		var texts []string
+		for _, piece := range pieces {
+			texts = append(texts, piece.text)
+		}
+		assert.Equal(t, tt.expected, texts, tt.config)
+	}
+}
@@ -24,7 +24,7 @@ import (
 // This file contains the main factory function for creating tokenizer instances
 
 // NewTokenizer creates a tokenizer instance based on the provided type
-// Supports "tiktoken", "character", and "remote" tokenizer types
+// Supports "tiktoken", "character", "huggingface", and "remote" tokenizer types
 func NewTokenizer(tokenizerType string, config interface{}) (Tokenizer, error) {
 	switch tokenizerType {
 	case "tiktoken":
@@ -35,6 +35,14 @@ func NewTokenizer(tokenizerType string, config interface{}) (Tokenizer, error) {
 		// Character tokenizer doesn't require configuration
 		return NewCharacterTokenizer(), nil
 
+	case "huggingface":
+		// Local tokenizer loaded from a Hugging Face tokenizer.json file
+		hfConfig, ok := config.(HuggingFaceTokenizerConfig)
+		if !ok {
+			return nil, fmt.Errorf("invalid config type for huggingface tokenizer")
+		}
+		return NewHuggingFaceTokenizer(hfConfig)
+
 	case "remote":
 		// Generic remote tokenizer
 		remoteConfig, ok := config.(RemoteTokenizerConfig)
@@ -29,6 +29,10 @@ type User struct {
 	Name string `json:"name" validate:"required"`
 	Rpm  int64  `json:"rpm"`
 	Tpm  int64  `json:"tpm"`
+	// Priority is the priority class of the user's requests, it overrides the priority requested by header.
+	Priority string `json:"priority,omitempty"`
+	// Weight is the share of the user's queued requests relative to other users when fair queuing is enabled, 1 if not set.
+	Weight int64 `json:"weight,omitempty"`
 }
 
 func CheckUser(ctx context.Context, u User, redisClient *redis.Client) bool {
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	modelapi "github.com/vllm-project/aibrix/api/model/v1alpha1"
+)
+
+type RoutingPolicyWebhook struct{}
+
+// integerRoutingParameters are the router parameters the gateway plugin reads as integers, the other router
+// parameters are read as floats.
+var integerRoutingParameters = sets.New("imbalance-abs-count", "window-size")
+
+// SetupRoutingPolicyWebhook will setup the manager to manage the RoutingPolicy webhook
+func SetupRoutingPolicyWebhook(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&modelapi.RoutingPolicy{}).
+		WithValidator(&RoutingPolicyWebhook{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-model-aibrix-ai-v1alpha1-routingpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=model.aibrix.ai,resources=routingpolicies,verbs=create;update,versions=v1alpha1,name=vroutingpolicy.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &RoutingPolicyWebhook{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (w *RoutingPolicyWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policy, ok := obj.(*modelapi.RoutingPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a RoutingPolicy object but got %T", obj)
+	}
+	return nil, validateRoutingPolicy(policy).ToAggregate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (w *RoutingPolicyWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	policy, ok := newObj.(*modelapi.RoutingPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a RoutingPolicy object but got %T", newObj)
+	}
+	return nil, validateRoutingPolicy(policy).ToAggregate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (w *RoutingPolicyWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateRoutingPolicy checks the fields of the policy. Whether the algorithm and its parameters are supported
+// depends on the gateway plugin configuration, the gateway plugin ignores the policies it can not apply.
+func validateRoutingPolicy(policy *modelapi.RoutingPolicy) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if strings.TrimSpace(policy.Spec.ModelName) == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("modelName"), "modelName must be set"))
+	}
+
+	if policy.Spec.Algorithm == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("algorithm"), "algorithm must be set"))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(policy.Spec.Algorithm) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("algorithm"), policy.Spec.Algorithm, msg))
+		}
+	}
+
+	paramsPath := specPath.Child("parameters")
+	for key, value := range policy.Spec.Parameters {
+		for _, msg := range validation.IsDNS1123Label(key) {
+			allErrs = append(allErrs, field.Invalid(paramsPath.Key(key), key, msg))
+		}
+		if strings.TrimSpace(value) == "" {
+			allErrs = append(allErrs, field.Invalid(paramsPath.Key(key), value, "parameter value must not be empty"))
+		} else if msg := validateParameterValue(key, value); msg != "" {
+			allErrs = append(allErrs, field.Invalid(paramsPath.Key(key), value, msg))
+		}
+	}
+
+	if policy.Spec.RequestTimeout != nil && policy.Spec.RequestTimeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("requestTimeout"), policy.Spec.RequestTimeout.Duration.String(),
+			"requestTimeout must not be negative"))
+	}
+
+	return allErrs
+}
+
+// validateParameterValue returns why the value of the router parameter can not be read by the gateway plugin, or ""
+// if it is valid. The gateway plugin ignores invalid values, which would silently apply the defaults.
+func validateParameterValue(key, value string) string {
+	if integerRoutingParameters.Has(key) {
+		if _, err := strconv.Atoi(value); err != nil {
+			return "parameter value must be an integer"
+		}
+		return ""
+	}
+	if f, err := strconv.ParseFloat(value, 64); err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return "parameter value must be a number"
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Aibrix Team.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	modelapi "github.com/vllm-project/aibrix/api/model/v1alpha1"
+)
+
+func TestValidateRoutingPolicy(t *testing.T) {
+	newPolicy := func(mutate func(spec *modelapi.RoutingPolicySpec)) *modelapi.RoutingPolicy {
+		policy := &modelapi.RoutingPolicy{
+			Spec: modelapi.RoutingPolicySpec{
+				ModelName: "llama",
+				Algorithm: "vtc-basic",
+				Parameters: map[string]string{
+					"window-size":     "10",
+					"fairness-weight": "0.5",
+				},
+				RequestTimeout: &metav1.Duration{Duration: 30 * time.Second},
+			},
+		}
+		if mutate != nil {
+			mutate(&policy.Spec)
+		}
+		return policy
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(spec *modelapi.RoutingPolicySpec)
+		fields []string
+	}{
+		{name: "valid"},
+		{
+			name:   "missing model and algorithm",
+			mutate: func(spec *modelapi.RoutingPolicySpec) { spec.ModelName, spec.Algorithm = " ", "" },
+			fields: []string{"spec.modelName", "spec.algorithm"},
+		},
+		{
+			name:   "invalid algorithm",
+			mutate: func(spec *modelapi.RoutingPolicySpec) { spec.Algorithm = "Prefix_Cache" },
+			fields: []string{"spec.algorithm"},
+		},
+		{
+			name:   "invalid parameter key",
+			mutate: func(spec *modelapi.RoutingPolicySpec) { spec.Parameters["Window_Size"] = "10" },
+			fields: []string{"spec.parameters[Window_Size]"},
+		},
+		{
+			name:   "empty parameter value",
+			mutate: func(spec *modelapi.RoutingPolicySpec) { spec.Parameters["max-pod-load"] = "" },
+			fields: []string{"spec.parameters[max-pod-load]"},
+		},
+		{
+			name:   "non-integer parameter value",
+			mutate: func(spec *modelapi.RoutingPolicySpec) { spec.Parameters["window-size"] = "1.5" },
+			fields: []string{"spec.parameters[window-size]"},
+		},
+		{
+			name: "non-numeric parameter values",
+			mutate: func(spec *modelapi.RoutingPolicySpec) {
+				spec.Parameters["fairness-weight"] = "high"
+				spec.Parameters["max-pod-load"] = "NaN"
+			},
+			fields: []string{"spec.parameters[fairness-weight]", "spec.parameters[max-pod-load]"},
+		},
+		{
+			name:   "negative request timeout",
+			mutate: func(spec *modelapi.RoutingPolicySpec) { spec.RequestTimeout.Duration = -time.Second },
+			fields: []string{"spec.requestTimeout"},
+		},
+		{
+			name:   "zero request timeout",
+			mutate: func(spec *modelapi.RoutingPolicySpec) { spec.RequestTimeout.Duration = 0 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateRoutingPolicy(newPolicy(tt.mutate))
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}